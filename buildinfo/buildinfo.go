@@ -0,0 +1,25 @@
+// Package buildinfo holds build metadata injected at link time, e.g.:
+//
+//	go build -ldflags "-X github.com/gaanon/gorecipes_v2/buildinfo.Version=v1.2.0 \
+//	  -X github.com/gaanon/gorecipes_v2/buildinfo.Commit=$(git rev-parse --short HEAD) \
+//	  -X github.com/gaanon/gorecipes_v2/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+package buildinfo
+
+// Set via -ldflags -X; the defaults identify a local, unstamped build.
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
+
+// Info is the build metadata reported by GET /version.
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+}
+
+// Get returns the build metadata of the running binary.
+func Get() Info {
+	return Info{Version: Version, Commit: Commit, BuildTime: BuildTime}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // DBConfig holds the database connection parameters.
@@ -15,6 +17,24 @@ type DBConfig struct {
 	Password string
 	DBName   string
 	SSLMode  string // e.g., "disable", "require", "verify-full"
+
+	// SlowQueryThreshold logs any query taking at least this long; zero disables slow-query logging.
+	SlowQueryThreshold time.Duration
+	// RedactSlowQueryArgs omits the query arguments from slow-query logs.
+	RedactSlowQueryArgs bool
+	// StatementTimeout makes PostgreSQL cancel any statement running longer than this; zero leaves the server default.
+	StatementTimeout time.Duration
+	// AcquireTimeout bounds how long a query waits for a free pooled connection before the request fails
+	// with 503; zero waits until the request's own deadline.
+	AcquireTimeout time.Duration
+	// QueryMode is "simple" to use PostgreSQL's simple query protocol, as needed behind PgBouncer in
+	// transaction mode; empty or "extended" keeps pgx's default of cached prepared statements.
+	QueryMode string
+
+	// ConnectAttempts is how many times to ping the database on startup before giving up.
+	ConnectAttempts int
+	// ConnectRetryInterval is the wait before the first retry; it doubles after each failed attempt.
+	ConnectRetryInterval time.Duration
 }
 
 // getEnv reads an environment variable or returns a default value.
@@ -38,6 +58,60 @@ func getEnvAsInt(key string, fallback int) int {
 	return valueInt
 }
 
+// getEnvAsBool reads an environment variable as a boolean or returns a default value.
+func getEnvAsBool(key string, fallback bool) bool {
+	valueStr := getEnv(key, "")
+	if valueStr == "" {
+		return fallback
+	}
+	valueBool, err := strconv.ParseBool(valueStr)
+	if err != nil {
+		return fallback
+	}
+	return valueBool
+}
+
+// getEnvAsFloat reads an environment variable as a floating-point number or returns a default value.
+func getEnvAsFloat(key string, fallback float64) float64 {
+	valueStr := getEnv(key, "")
+	if valueStr == "" {
+		return fallback
+	}
+	valueFloat, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return fallback
+	}
+	return valueFloat
+}
+
+// getEnvAsDuration reads an environment variable as a Go duration (e.g. "15s", "1m") or returns a default value.
+func getEnvAsDuration(key string, fallback time.Duration) time.Duration {
+	valueStr := getEnv(key, "")
+	if valueStr == "" {
+		return fallback
+	}
+	valueDuration, err := time.ParseDuration(valueStr)
+	if err != nil {
+		return fallback
+	}
+	return valueDuration
+}
+
+// getEnvAsSlice reads a comma-separated environment variable as a slice of trimmed, non-empty strings.
+func getEnvAsSlice(key string, fallback []string) []string {
+	valueStr := getEnv(key, "")
+	if valueStr == "" {
+		return fallback
+	}
+	var values []string
+	for _, v := range strings.Split(valueStr, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
 // DefaultDBConfig returns a database configuration, loading values from environment variables with fallbacks.
 func DefaultDBConfig() DBConfig {
 	return DBConfig{
@@ -47,6 +121,15 @@ func DefaultDBConfig() DBConfig {
 		Password: getEnv("DB_PASSWORD", "your_db_password"),
 		DBName:   getEnv("DB_NAME", "recipes_db"),
 		SSLMode:  getEnv("DB_SSLMODE", "disable"),
+
+		SlowQueryThreshold:  time.Duration(getEnvAsInt("SLOW_QUERY_MS", 0)) * time.Millisecond,
+		RedactSlowQueryArgs: getEnvAsBool("SLOW_QUERY_REDACT_ARGS", false),
+		StatementTimeout:    getEnvAsDuration("DB_STATEMENT_TIMEOUT", 0),
+		AcquireTimeout:      getEnvAsDuration("DB_ACQUIRE_TIMEOUT", 5*time.Second),
+		QueryMode:           getEnv("DB_QUERY_MODE", ""),
+
+		ConnectAttempts:      getEnvAsInt("DB_CONNECT_ATTEMPTS", 5),
+		ConnectRetryInterval: time.Duration(getEnvAsInt("DB_CONNECT_RETRY_MS", 1000)) * time.Millisecond,
 	}
 }
 
@@ -55,3 +138,174 @@ func (cfg DBConfig) ConnectionString() string {
 	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.DBName, cfg.SSLMode)
 }
+
+// ServerConfig holds the HTTP server settings.
+type ServerConfig struct {
+	// TrustedProxies lists the proxy IPs/CIDRs allowed to set client IP headers (X-Forwarded-For etc.).
+	// An empty list means no proxy is trusted and the client IP is always the remote address.
+	TrustedProxies []string
+	// APIBasePath is the path prefix all API routes are served under, e.g. "/recipes-api" behind a
+	// path-routing reverse proxy. It has a leading slash and no trailing slash.
+	APIBasePath string
+	// AdminAPIKey must be sent as "Authorization: Bearer <key>" to call the <APIBasePath>/admin endpoints.
+	// When empty, the admin endpoints reject every request.
+	AdminAPIKey string
+	// ShutdownTimeout is how long in-flight requests get to finish on SIGINT/SIGTERM before connections are closed.
+	ShutdownTimeout time.Duration
+	// RequestTimeout bounds how long a request may take to handle; later responses become 408. Zero disables it.
+	RequestTimeout time.Duration
+
+	// ReadHeaderTimeout limits how long a client may take to send the request headers (slowloris protection).
+	ReadHeaderTimeout time.Duration
+	// ReadTimeout limits how long reading a whole request, including the body, may take.
+	ReadTimeout time.Duration
+	// WriteTimeout limits how long a response may take from the end of the request headers; keep it above
+	// RequestTimeout so the 408 response can still be written.
+	WriteTimeout time.Duration
+	// IdleTimeout is how long an idle keep-alive connection stays open.
+	IdleTimeout time.Duration
+	// TLSCertFile and TLSKeyFile enable HTTPS, and with it HTTP/2, when both are set.
+	TLSCertFile string
+	TLSKeyFile  string
+
+	// APICORS is the cross-origin policy for the API routes under APIBasePath.
+	APICORS CORSConfig
+	// DocsCORS is the cross-origin policy for the Swagger UI under /swagger, kept separate from the API's.
+	DocsCORS CORSConfig
+	// DocsFrameAncestors lists the sources allowed to embed the Swagger UI in a frame (CSP frame-ancestors).
+	DocsFrameAncestors []string
+}
+
+// CORSConfig holds a cross-origin resource sharing policy.
+type CORSConfig struct {
+	// AllowedOrigins lists the origins (e.g. "https://app.example.com") allowed to make cross-origin requests;
+	// "*" allows any origin. Empty allows none, so browsers block cross-origin calls.
+	AllowedOrigins []string
+	// MaxAge is how long browsers may cache a preflight response (Access-Control-Max-Age); 0 leaves it to the browser.
+	MaxAge time.Duration
+	// ExposedHeaders lists the response headers, beyond the CORS-safelisted ones, that scripts may read
+	// (Access-Control-Expose-Headers), e.g. the Location of a created recipe.
+	ExposedHeaders []string
+}
+
+// DefaultServerConfig returns a server configuration, loading values from environment variables with fallbacks.
+func DefaultServerConfig() ServerConfig {
+	return ServerConfig{
+		TrustedProxies: getEnvAsSlice("TRUSTED_PROXIES", nil),
+		APIBasePath:    normalizeBasePath(getEnv("API_BASE_PATH", "/api/v1")),
+		AdminAPIKey:    getEnv("ADMIN_API_KEY", ""),
+
+		ShutdownTimeout: getEnvAsDuration("SHUTDOWN_TIMEOUT", 15*time.Second),
+		RequestTimeout:  getEnvAsDuration("REQUEST_TIMEOUT", 30*time.Second),
+
+		ReadHeaderTimeout: getEnvAsDuration("READ_HEADER_TIMEOUT", 5*time.Second),
+		ReadTimeout:       getEnvAsDuration("READ_TIMEOUT", 30*time.Second),
+		WriteTimeout:      getEnvAsDuration("WRITE_TIMEOUT", 60*time.Second),
+		IdleTimeout:       getEnvAsDuration("IDLE_TIMEOUT", 120*time.Second),
+		TLSCertFile:       getEnv("TLS_CERT_FILE", ""),
+		TLSKeyFile:        getEnv("TLS_KEY_FILE", ""),
+
+		APICORS: CORSConfig{
+			AllowedOrigins: getEnvAsSlice("CORS_ALLOWED_ORIGINS", nil),
+			MaxAge:         getEnvAsDuration("CORS_MAX_AGE", 10*time.Minute),
+			ExposedHeaders: getEnvAsSlice("CORS_EXPOSED_HEADERS", []string{"Location", "ETag", "Last-Modified", "Retry-After", "X-Request-ID"}),
+		},
+		DocsCORS:           CORSConfig{AllowedOrigins: getEnvAsSlice("DOCS_CORS_ALLOWED_ORIGINS", nil)},
+		DocsFrameAncestors: getEnvAsSlice("DOCS_FRAME_ANCESTORS", []string{"'self'"}),
+	}
+}
+
+// normalizeBasePath gives a URL path prefix a single leading slash and no trailing slash, so "api/", "/api"
+// and "/api/" all become "/api" and "/" becomes "" (the root).
+func normalizeBasePath(path string) string {
+	path = strings.Trim(strings.TrimSpace(path), "/")
+	if path == "" {
+		return ""
+	}
+	return "/" + path
+}
+
+// APIConfig holds settings that change how the API handlers validate and shape requests.
+// The zero value keeps the default, lenient behaviour.
+type APIConfig struct {
+	// RequireIngredientsAndSteps rejects recipes without at least one ingredient and one step.
+	RequireIngredientsAndSteps bool
+	// RequireCreatedBy rejects recipe creates without created_by with 422, for deployments that enforce ownership.
+	RequireCreatedBy bool
+	// MaxPageSize caps page_size on list endpoints; larger requests are clamped. Zero means 100.
+	MaxPageSize int
+	// QuantityDecimals rounds ingredient quantities in responses to this many decimals. Zero means 3;
+	// negative disables rounding. Quantities are always stored at full precision.
+	QuantityDecimals int
+	// MaxNameLength caps the length, in characters, of ingredient, unit and tag names in recipe requests,
+	// and of ingredient renames.
+	// Zero keeps the database column limits (255, 50 and 100); it can only tighten them.
+	MaxNameLength int
+	// DefaultRecipeSort orders recipe lists when the request has no sort, e.g. "title" or "-created_at".
+	// It accepts the same values as the sort parameter. Empty means "-updated_at".
+	DefaultRecipeSort string
+	// JSONKeyCase is the key casing of JSON responses when the request has no case parameter:
+	// "snake" (as the models are tagged) or "camel". Empty means "snake".
+	JSONKeyCase string
+	// AnonymousUserID is the UUID recorded as created_by on recipes created anonymously without one, so
+	// created_by is never NULL. Empty leaves it NULL. REQUIRE_CREATED_BY is checked before it applies.
+	AnonymousUserID string
+	// SearchFuzzyThreshold is the minimum pg_trgm title similarity (0-1) of the fuzzy search that runs when
+	// full-text search finds nothing, e.g. for misspelled queries. Zero disables the fallback.
+	SearchFuzzyThreshold float64
+}
+
+// DefaultAPIConfig returns an API configuration, loading values from environment variables with fallbacks.
+func DefaultAPIConfig() APIConfig {
+	return APIConfig{
+		RequireIngredientsAndSteps: getEnvAsBool("REQUIRE_INGREDIENTS_AND_STEPS", false),
+		RequireCreatedBy:           getEnvAsBool("REQUIRE_CREATED_BY", false),
+		MaxPageSize:                getEnvAsInt("MAX_PAGE_SIZE", 100),
+		QuantityDecimals:           getEnvAsInt("QUANTITY_DECIMALS", 3),
+		DefaultRecipeSort:          getEnv("DEFAULT_RECIPE_SORT", "-updated_at"),
+		MaxNameLength:              getEnvAsInt("MAX_NAME_LENGTH", 0),
+		JSONKeyCase:                getEnv("JSON_KEY_CASE", "snake"),
+		AnonymousUserID:            getEnv("ANONYMOUS_USER_ID", ""),
+		SearchFuzzyThreshold:       getEnvAsFloat("SEARCH_FUZZY_THRESHOLD", 0.3),
+	}
+}
+
+// PhotoConfig holds settings for storing uploaded recipe photos.
+type PhotoConfig struct {
+	// Dir is the local directory photos and their thumbnails are written to.
+	Dir string
+	// ThumbnailWidth is the width in pixels of generated thumbnails; the height keeps the aspect ratio.
+	ThumbnailWidth int
+	// MaxBytes is the largest photo accepted for upload; larger uploads are rejected with 413. 0 disables the limit.
+	MaxBytes int64
+	// MaxPixels is the largest width × height of an uploaded photo, checked before it is decoded. 0 disables the limit.
+	MaxPixels int64
+	// AllowedTypes lists the sniffed content types accepted for upload, e.g. "image/png". Empty allows every supported format.
+	AllowedTypes []string
+}
+
+// DefaultPhotoConfig returns a photo configuration, loading values from environment variables with fallbacks.
+func DefaultPhotoConfig() PhotoConfig {
+	return PhotoConfig{
+		Dir:            getEnv("PHOTO_DIR", "uploads"),
+		ThumbnailWidth: getEnvAsInt("PHOTO_THUMBNAIL_WIDTH", 300),
+		MaxBytes:       int64(getEnvAsInt("MAX_PHOTO_BYTES", 10<<20)),
+		MaxPixels:      int64(getEnvAsInt("MAX_PHOTO_PIXELS", 25_000_000)),
+		AllowedTypes:   getEnvAsSlice("ALLOWED_PHOTO_TYPES", []string{"image/jpeg", "image/png", "image/gif"}),
+	}
+}
+
+// TelemetryConfig holds the OpenTelemetry tracing settings.
+type TelemetryConfig struct {
+	// OTLPEndpoint is the OTLP/HTTP collector URL, e.g. "http://jaeger:4318". Empty disables tracing.
+	OTLPEndpoint string
+	ServiceName  string
+}
+
+// DefaultTelemetryConfig returns a telemetry configuration, loading values from environment variables with fallbacks.
+func DefaultTelemetryConfig() TelemetryConfig {
+	return TelemetryConfig{
+		OTLPEndpoint: getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+		ServiceName:  getEnv("OTEL_SERVICE_NAME", "gorecipes"),
+	}
+}
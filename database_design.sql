@@ -3,36 +3,37 @@
 
 -- Enable UUID extension for generating unique IDs
 CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+CREATE EXTENSION IF NOT EXISTS pg_trgm; -- Trigram similarity for the fuzzy fallback of recipe search
 
 -- Create ENUM types for better data consistency
-CREATE TYPE measurement_system AS ENUM ('metric', 'imperial');
+CREATE TYPE measurement_system AS ENUM ('metric', 'imperial', 'other'); -- 'other': units such as pinch, or created from free text
+CREATE TYPE recipe_difficulty AS ENUM ('easy', 'medium', 'hard');
+CREATE TYPE step_type AS ENUM ('normal', 'optional', 'make_ahead');
 
 -- Main recipes table
 CREATE TABLE recipes (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     title VARCHAR(255) NOT NULL,
+    slug VARCHAR(255) NOT NULL UNIQUE, -- Derived from title, e.g. "apple-pie", "apple-pie-2"
     description TEXT,
     photo_filename VARCHAR(255), -- Local filename for stored photos
     serves INTEGER CHECK (serves > 0),
+    yield_quantity DECIMAL(10,2) CHECK (yield_quantity > 0), -- What the recipe makes, e.g. 24 (cookies); independent of serves
+    yield_unit VARCHAR(50), -- e.g. "cookies", "loaves"
+    source_name VARCHAR(255), -- Attribution, e.g. a cookbook title or website name
+    source_url VARCHAR(2048),
+    notes TEXT, -- General notes or chef's tips, separate from step instructions and ingredient notes
     prep_time_minutes INTEGER CHECK (prep_time_minutes >= 0),
     cook_time_minutes INTEGER CHECK (cook_time_minutes >= 0),
     total_time_minutes INTEGER GENERATED ALWAYS AS (prep_time_minutes + cook_time_minutes) STORED,
+    difficulty recipe_difficulty, -- NULL when not specified
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     created_by UUID, -- For multi-user systems
     
-    -- Full-text search vector for efficient searching
-    search_vector TSVECTOR GENERATED ALWAYS AS (
-        to_tsvector('english', COALESCE(title, '') || ' ' || COALESCE(description, ''))
-    ) STORED
-);
-
--- Ingredients master table (normalized approach for consistency)
-CREATE TABLE ingredients (
-    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
-    name VARCHAR(255) NOT NULL UNIQUE,
-    category VARCHAR(100), -- e.g., 'dairy', 'vegetables', 'spices'
-    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+    -- Full-text search vector for efficient searching: title (weight A), description (B) and
+    -- ingredient names (C). Ingredients live in other tables, so the application maintains it on write.
+    search_vector TSVECTOR
 );
 
 -- Units of measurement table
@@ -47,14 +48,30 @@ CREATE TABLE measurement_units (
     FOREIGN KEY (base_unit_id) REFERENCES measurement_units(id)
 );
 
+-- Ingredients master table (normalized approach for consistency)
+CREATE TABLE ingredients (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL UNIQUE,
+    category VARCHAR(100), -- e.g., 'dairy', 'vegetables', 'spices'
+    seasons SMALLINT[] CHECK (seasons <@ ARRAY[1,2,3,4,5,6,7,8,9,10,11,12]::SMALLINT[]), -- Months (1-12) in season; NULL means year-round
+    unit_price DECIMAL(12,4) CHECK (unit_price >= 0), -- Price of one price_unit_id (or of one item when it is NULL), for cost estimates
+    price_unit_id UUID, -- e.g. kilogram for a price per kg
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (price_unit_id) REFERENCES measurement_units(id)
+);
+
 -- Recipe ingredients junction table
 CREATE TABLE recipe_ingredients (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     recipe_id UUID NOT NULL,
     ingredient_id UUID NOT NULL,
     quantity DECIMAL(10,3), -- Can be null for "to taste" items
+    quantity_min DECIMAL(10,3), -- A range such as "2-3 cloves" is stored in quantity_min/quantity_max instead of quantity
+    quantity_max DECIMAL(10,3) CHECK (quantity_max >= quantity_min),
     unit_id UUID,
-    notes TEXT, -- For additional info like "chopped", "to taste", "optional"
+    notes TEXT, -- For additional info like "to taste", "optional"
+    preparation VARCHAR(255), -- How to prepare it, e.g. "finely chopped", "room temperature"
     sort_order INTEGER NOT NULL DEFAULT 0, -- To maintain ingredient order
     
     FOREIGN KEY (recipe_id) REFERENCES recipes(id) ON DELETE CASCADE,
@@ -72,7 +89,9 @@ CREATE TABLE recipe_steps (
     step_number INTEGER NOT NULL,
     instruction TEXT NOT NULL,
     duration_minutes INTEGER CHECK (duration_minutes >= 0), -- Optional timing per step
+    duration_seconds INTEGER CHECK (duration_seconds >= 0), -- Seconds part (0-59) with duration_minutes, or the whole duration on its own
     temperature VARCHAR(50), -- e.g., "190°C", "gas mark 5"
+    step_type step_type NOT NULL DEFAULT 'normal', -- 'optional' or 'make_ahead' steps are shown differently
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     
     FOREIGN KEY (recipe_id) REFERENCES recipes(id) ON DELETE CASCADE,
@@ -85,6 +104,7 @@ CREATE TABLE tags (
     name VARCHAR(100) NOT NULL UNIQUE,
     description TEXT,
     color VARCHAR(7), -- Hex color code for UI
+    archived BOOLEAN NOT NULL DEFAULT FALSE, -- Hidden from tag listings but still applied to recipes
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
 
@@ -97,6 +117,35 @@ CREATE TABLE recipe_tags (
     FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
 );
 
+-- Equipment a recipe needs (e.g. "cast iron skillet"), found or created by name like tags
+CREATE TABLE equipment (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE recipe_equipment (
+    recipe_id UUID NOT NULL,
+    equipment_id UUID NOT NULL,
+
+    PRIMARY KEY (recipe_id, equipment_id),
+    FOREIGN KEY (recipe_id) REFERENCES recipes(id) ON DELETE CASCADE,
+    FOREIGN KEY (equipment_id) REFERENCES equipment(id) ON DELETE CASCADE
+);
+
+-- Translated recipe titles/descriptions; the recipes row holds the base language
+CREATE TABLE recipe_translations (
+    recipe_id UUID NOT NULL,
+    lang VARCHAR(10) NOT NULL, -- Primary language subtag, lowercased, e.g. 'fr'
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    
+    PRIMARY KEY (recipe_id, lang),
+    FOREIGN KEY (recipe_id) REFERENCES recipes(id) ON DELETE CASCADE
+);
+
 -- Recipe ratings and reviews (optional feature)
 CREATE TABLE recipe_ratings (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
@@ -124,6 +173,7 @@ CREATE TABLE collections (
 CREATE TABLE collection_recipes (
     collection_id UUID NOT NULL,
     recipe_id UUID NOT NULL,
+    sort_order INTEGER NOT NULL DEFAULT 0, -- Position within the collection, from 0
     added_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     
     PRIMARY KEY (collection_id, recipe_id),
@@ -134,9 +184,11 @@ CREATE TABLE collection_recipes (
 -- Indexes for performance
 CREATE INDEX idx_recipes_title ON recipes USING GIN(to_tsvector('english', title));
 CREATE INDEX idx_recipes_search_vector ON recipes USING GIN(search_vector);
+CREATE INDEX idx_recipes_title_trgm ON recipes USING GIN(title gin_trgm_ops);
 CREATE INDEX idx_recipes_created_at ON recipes(created_at DESC);
 CREATE INDEX idx_recipes_serves ON recipes(serves);
 CREATE INDEX idx_recipes_total_time ON recipes(total_time_minutes);
+CREATE INDEX idx_recipes_difficulty ON recipes(difficulty);
 
 CREATE INDEX idx_recipe_ingredients_recipe_id ON recipe_ingredients(recipe_id);
 CREATE INDEX idx_recipe_ingredients_ingredient_id ON recipe_ingredients(ingredient_id);
@@ -146,11 +198,17 @@ CREATE INDEX idx_recipe_steps_recipe_id ON recipe_steps(recipe_id);
 CREATE INDEX idx_recipe_steps_step_number ON recipe_steps(recipe_id, step_number);
 
 CREATE INDEX idx_ingredients_name ON ingredients(name);
+CREATE UNIQUE INDEX idx_ingredients_name_lower ON ingredients(LOWER(name)); -- "Salt" and "salt" are one ingredient
 CREATE INDEX idx_ingredients_category ON ingredients(category);
 
 CREATE INDEX idx_measurement_units_system ON measurement_units(system);
 CREATE INDEX idx_measurement_units_name ON measurement_units(name);
 
+CREATE INDEX idx_recipe_equipment_equipment_id ON recipe_equipment(equipment_id);
+
+CREATE INDEX idx_collection_recipes_sort_order ON collection_recipes(collection_id, sort_order);
+CREATE INDEX idx_collection_recipes_recipe_id ON collection_recipes(recipe_id);
+
 -- Triggers for automatic timestamp updates
 CREATE OR REPLACE FUNCTION update_updated_at_column()
 RETURNS TRIGGER AS $$
@@ -164,6 +222,10 @@ CREATE TRIGGER update_recipes_updated_at
     BEFORE UPDATE ON recipes 
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+CREATE TRIGGER update_recipe_translations_updated_at 
+    BEFORE UPDATE ON recipe_translations 
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
 CREATE TRIGGER update_collections_updated_at 
     BEFORE UPDATE ON collections 
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -186,6 +248,12 @@ INSERT INTO measurement_units (name, abbreviation, system, conversion_factor) VA
 ('pint', 'pt', 'imperial', 473.18), -- ml equivalent
 ('quart', 'qt', 'imperial', 946.35); -- ml equivalent
 
+-- Link convertible units to their base unit so quantities can be normalized (e.g. kg -> g)
+UPDATE measurement_units SET base_unit_id = (SELECT id FROM measurement_units WHERE name = 'gram')
+WHERE name IN ('kilogram', 'ounce', 'pound');
+UPDATE measurement_units SET base_unit_id = (SELECT id FROM measurement_units WHERE name = 'millilitre')
+WHERE name IN ('litre', 'cup', 'tablespoon', 'teaspoon', 'fluid ounce', 'pint', 'quart');
+
 -- Sample ingredients
 INSERT INTO ingredients (name, category) VALUES
 ('asparagus', 'vegetables'),
@@ -24,24 +24,32 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/recipes": {
-            "get": {
-                "description": "Get a list of all recipes (basic details).",
+        "/admin/cleanup-tags": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete every tag that is not assigned to any recipe and return how many were removed.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "recipes"
+                    "admin"
                 ],
-                "summary": "List recipes",
+                "summary": "Delete unused tags",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Recipe"
-                            }
+                            "$ref": "#/definitions/models.TagCleanupResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin API key",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
                         }
                     },
                     "500": {
@@ -51,9 +59,99 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/recompute-times": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Verify that every recipe's total_time_minutes equals prep_time_minutes + cook_time_minutes and report mismatches.\nIf total_time_minutes is not a generated column in this database, mismatches are also fixed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Check recipe total times",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TotalTimeCheckReport"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin API key",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reindex-search": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Recompute search_vector for all recipes from title, description and ingredient names, batch_size recipes per statement.\nIf search_vector is a generated column in this database, Postgres already keeps it in sync (from title and description) and nothing is rewritten.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Re-index recipe search",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Recipes updated per statement (default 500)",
+                        "name": "batch_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SearchReindexReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid batch_size",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid admin API key",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections": {
             "post": {
-                "description": "Create a new recipe with ingredients, steps, and tags.",
+                "description": "Create a named collection, such as a cookbook, to group recipes in a chosen order.",
                 "consumes": [
                     "application/json"
                 ],
@@ -61,17 +159,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "recipes"
+                    "collections"
                 ],
-                "summary": "Create a new recipe",
+                "summary": "Create a collection",
                 "parameters": [
                     {
-                        "description": "Recipe to create",
-                        "name": "recipe",
+                        "description": "Collection to create",
+                        "name": "collection",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.RecipeRequest"
+                            "$ref": "#/definitions/models.CollectionRequest"
                         }
                     }
                 ],
@@ -79,7 +177,13 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Recipe"
+                            "$ref": "#/definitions/models.Collection"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the created collection"
+                            }
                         }
                     },
                     "400": {
@@ -97,20 +201,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/recipes/{id}": {
+        "/collections/{id}": {
             "get": {
-                "description": "Get a single recipe by its UUID, including ingredients, steps, and tags.",
+                "description": "Get a collection with the number of recipes in it. Use /collections/{id}/recipes for the recipes themselves.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "recipes"
+                    "collections"
                 ],
-                "summary": "Get a recipe by ID",
+                "summary": "Get a collection",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Recipe ID (UUID)",
+                        "description": "Collection ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -120,7 +224,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Recipe"
+                            "$ref": "#/definitions/models.Collection"
                         }
                     },
                     "400": {
@@ -130,7 +234,57 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Recipe not found",
+                        "description": "Collection not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/recipes": {
+            "get": {
+                "description": "List summaries of the recipes in a collection, in collection order.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "List a collection's recipes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CollectionRecipe"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Collection not found",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
@@ -143,8 +297,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "Update an existing recipe by its UUID. All fields are replaced.",
+            "post": {
+                "description": "Add a recipe at sort_order, moving later recipes down, or at the end when sort_order is omitted.\nA recipe can be in many collections, but only once in each. The collection's recipes are returned.",
                 "consumes": [
                     "application/json"
                 ],
@@ -152,24 +306,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "recipes"
+                    "collections"
                 ],
-                "summary": "Update an existing recipe",
+                "summary": "Add a recipe to a collection",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Recipe ID (UUID)",
+                        "description": "Collection ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Recipe data to update",
+                        "description": "Recipe to add",
                         "name": "recipe",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.RecipeRequest"
+                            "$ref": "#/definitions/models.CollectionRecipeRequest"
                         }
                     }
                 ],
@@ -177,17 +331,26 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Recipe"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CollectionRecipe"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid input or ID format",
+                        "description": "Invalid ID or input, or the recipe does not exist",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
                     },
                     "404": {
-                        "description": "Recipe not found",
+                        "description": "Collection not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "The recipe is already in the collection",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
@@ -199,23 +362,97 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a single recipe by its UUID.",
+            }
+        },
+        "/collections/{id}/recipes/order": {
+            "put": {
+                "description": "Set the order of a collection's recipes. recipe_ids must list every recipe of the collection exactly once.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "recipes"
+                    "collections"
                 ],
-                "summary": "Delete a recipe by ID",
+                "summary": "Reorder a collection's recipes",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Recipe ID (UUID)",
+                        "description": "Collection ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Recipe IDs in the desired order",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CollectionOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.CollectionRecipe"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or input",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Collection not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "The IDs do not match the collection's current recipes",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{id}/recipes/{recipe_id}": {
+            "delete": {
+                "description": "Remove a recipe from a collection; later recipes move up. The recipe itself is not deleted.\nRemoving a recipe that is not in the collection succeeds, so the call is idempotent.",
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Remove a recipe from a collection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Collection ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Recipe ID (UUID)",
+                        "name": "recipe_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -229,7 +466,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Recipe not found",
+                        "description": "Collection not found",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
@@ -242,63 +479,3721 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handlers.APIError": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "type": "string"
-                },
-                "status": {
-                    "description": "Optional: include HTTP status in body",
-                    "type": "integer"
-                }
-            }
-        },
-        "models.MeasurementSystem": {
-            "type": "string",
-            "enum": [
-                "metric",
-                "imperial"
-            ],
-            "x-enum-varnames": [
-                "Metric",
-                "Imperial"
-            ]
         },
-        "models.MeasurementUnit": {
-            "type": "object",
-            "properties": {
-                "abbreviation": {
-                    "type": "string"
-                },
-                "base_unit_id": {
-                    "type": "string"
-                },
+        "/ingredients": {
+            "get": {
+                "description": "List ingredients by name, a page at a time. With uncategorized=true, only those without a category,\nsuch as ingredients created on the fly by recipes, so they can be filled in with PATCH /ingredients/{id}.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "List ingredients",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only ingredients without a category",
+                        "name": "uncategorized",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of ingredients per page (default 50, at most 200)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Ingredient"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/parse": {
+            "post": {
+                "description": "Parse raw ingredient lines such as \"2 cups flour, sifted\" into quantity, unit, name and notes, as imports do.\nNothing is saved. Results are in the order of the submitted lines; a blank line gives an empty name.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "Preview ingredient parsing",
+                "parameters": [
+                    {
+                        "description": "Raw ingredient lines",
+                        "name": "lines",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ParsedIngredient"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/popular": {
+            "get": {
+                "description": "List the ingredients used by the most recipes, with their recipe counts, most used first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "List popular ingredients",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of ingredients to return (default 20, at most 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.IngredientCount"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid limit",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/{id}": {
+            "put": {
+                "description": "Rename an ingredient and/or change its category. The change applies to every recipe using it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "Update an ingredient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ingredient ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ingredient data to update",
+                        "name": "ingredient",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.IngredientRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Ingredient"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or ID format",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Ingredient not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Another ingredient already has this name",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Change only the fields present in the body: category, unit_price and price_unit_id; null clears a field.\nMeant for enriching ingredients created on the fly by recipes (see GET /ingredients?uncategorized=true).\nClearing unit_price also clears price_unit_id. Use PUT /ingredients/{id} to rename.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "Update some of an ingredient's details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ingredient ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "ingredient",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.IngredientPatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Ingredient"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input, ID format or unit",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Ingredient not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/{id}/price": {
+            "put": {
+                "description": "Set the price of one price_unit_id of an ingredient, e.g. 2.40 per kilogram, used by GET /recipes/{id}/cost.\nOmit price_unit_id to price per item (e.g. per egg); a null unit_price clears the price.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "Set an ingredient's price",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ingredient ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Price of the ingredient",
+                        "name": "price",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.IngredientPriceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Ingredient"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input, ID format or unit",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Ingredient not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/{id}/seasons": {
+            "put": {
+                "description": "Set the months (1-12) an ingredient is in season, used by GET /recipes?in_season=true. An empty list marks it available year-round.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "Set an ingredient's seasons",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ingredient ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Months the ingredient is in season",
+                        "name": "seasons",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.IngredientSeasonsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Ingredient"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or ID format",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Ingredient not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/ingredients/{id}/stats": {
+            "get": {
+                "description": "Get how many recipes use an ingredient and, for each unit it is used with, how often and the average quantity.\nAverages are per unit, since quantities in different units cannot be compared directly.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ingredients"
+                ],
+                "summary": "Get ingredient usage statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ingredient ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.IngredientStats"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Ingredient not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes": {
+            "get": {
+                "description": "Get a paginated list of recipes (basic details), most recently updated first unless sort or DEFAULT_RECIPE_SORT says otherwise.\nUse page/page_size for offset pagination, or pass the returned next_cursor as after for keyset pagination.\nWith updated_since, only recipes changed since then are returned, oldest change first; page through large deltas with after.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "List recipes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number for offset pagination (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of recipes per page (default 20; larger values are clamped to MAX_PAGE_SIZE, default 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from a previous response's next_cursor (keyset pagination)",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred language (e.g. fr); overrides Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "easy",
+                            "medium",
+                            "hard"
+                        ],
+                        "type": "string",
+                        "description": "Only recipes with this difficulty",
+                        "name": "difficulty",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only recipes serving at least this many",
+                        "name": "min_serves",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only recipes serving at most this many",
+                        "name": "max_serves",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only recipes needing this equipment (case-insensitive name)",
+                        "name": "equipment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Skip recipes carrying this tag (case-insensitive); repeatable",
+                        "name": "exclude_tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only recipes with an ingredient whose name contains this text (case-insensitive), e.g. choc",
+                        "name": "ingredient_contains",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only recipes without any tag, e.g. to find recipes still to be tagged",
+                        "name": "untagged",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only recipes whose ingredients are all in season this month (server time); ingredients without seasons count as year-round",
+                        "name": "in_season",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 timestamp; only recipes updated at or after it, oldest change first (incremental sync)",
+                        "name": "updated_since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order by title, created_at, updated_at, prep_time_minutes, cook_time_minutes or total_time_minutes; prefix - for descending. Defaults to DEFAULT_RECIPE_SORT (-updated_at). next_cursor is only returned for updated_at orderings",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RecipeListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new recipe with ingredients, steps, and tags.\nAn ingredient unit_name needs a quantity. Suspicious but valid input (a cook time of 0, no steps, a quantity without a unit) is reported in warnings, also when validation fails.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Create a new recipe",
+                "parameters": [
+                    {
+                        "description": "Recipe to create",
+                        "name": "recipe",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RecipeRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Reject ingredient lines that exactly repeat an earlier one",
+                        "name": "strict",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Recipe"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the created recipe"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or unknown ingredient ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "An ingredient listed twice (names match case-insensitively), or a concurrent slug clash",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "created_by is missing and the server requires it",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete every listed recipe in a single transaction. IDs that match no recipe are counted as not_found rather than failing the request.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Delete recipes in bulk",
+                "parameters": [
+                    {
+                        "description": "Recipes to delete",
+                        "name": "recipes",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RecipeBulkDeleteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RecipeBulkDeleteResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/exists": {
+            "post": {
+                "description": "Report which of the listed recipe IDs belong to a recipe and which do not, e.g. to prune stale references\nfrom a meal plan. Each ID is listed once, in request order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Check that recipes exist",
+                "parameters": [
+                    {
+                        "description": "Recipe IDs to check",
+                        "name": "recipes",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RecipeExistsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RecipeExistsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/import/mealie": {
+            "post": {
+                "description": "Create recipes from Mealie's JSON export: a single recipe object or an array of them (at most 200).\nIngredients may be structured or plain strings such as \"1 1/2 cups flour, sifted\"; instructions become steps, categories become tags and tools become equipment.\nEach recipe is validated and created on its own. Fields that cannot be mapped (e.g. nutrition, images, section titles) are dropped and listed in that recipe's warnings.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Import recipes from Mealie",
+                "parameters": [
+                    {
+                        "description": "Mealie recipe export (object or array)",
+                        "name": "export",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.MealieRecipe"
+                            }
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "UUID to record as created_by on every imported recipe",
+                        "name": "created_by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RecipeImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload or query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/search": {
+            "get": {
+                "description": "Full-text search over recipe titles, descriptions and ingredient names, most relevant first.\nTitle matches rank above description matches, which rank above ingredient matches; each result's rank can be used as a threshold.\nWith prefix=true each word also matches longer words, e.g. \"choco\" matches \"chocolate\" (useful for search-as-you-type).\nWhen nothing matches, recipes with similar titles are returned instead, flagged fuzzy and ranked by similarity,\nso misspellings such as \"chocolat cak\" still find \"Chocolate Cake\" (see SEARCH_FUZZY_THRESHOLD).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Search recipes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search text",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Match words by prefix (default false)",
+                        "name": "prefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of results (default 20; larger values are clamped to MAX_PAGE_SIZE, default 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RecipeSearchResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/slug/{slug}": {
+            "get": {
+                "description": "Get a single recipe by its URL-friendly slug, including ingredients, steps, and tags.\nThe title and description are translated when a translation exists for ?lang= or Accept-Language.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Get a recipe by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipe slug, e.g. apple-pie",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred language (e.g. fr); overrides Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "HTTP date; 304 is returned if the recipe has not changed since",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Recipe"
+                        },
+                        "headers": {
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "When the recipe (or its translation) last changed"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified since If-Modified-Since"
+                    },
+                    "404": {
+                        "description": "Recipe not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/{id}": {
+            "get": {
+                "description": "Get a single recipe by its UUID, including ingredients, steps, and tags.\nThe title and description are translated when a translation exists for ?lang= or Accept-Language.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Get a recipe by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipe ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred language (e.g. fr); overrides Accept-Language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return ingredients grouped by category in ingredient_groups instead of ingredients",
+                        "name": "group_ingredients",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "metric",
+                            "imperial"
+                        ],
+                        "type": "string",
+                        "description": "Convert ingredient quantities to this system where conversion data allows; each ingredient's converted tells whether it was",
+                        "name": "units",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "HTTP date; 304 is returned if the recipe has not changed since",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Recipe"
+                        },
+                        "headers": {
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "When the recipe (or its translation) last changed"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified since If-Modified-Since"
+                    },
+                    "400": {
+                        "description": "Invalid ID format or query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing recipe by its UUID. All fields are replaced.\nInput is validated and warnings are reported as on create.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Update an existing recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipe ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Recipe data to update",
+                        "name": "recipe",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RecipeRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Reject ingredient lines that exactly repeat an earlier one",
+                        "name": "strict",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Recipe"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input, ID format or unknown ingredient ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "An ingredient listed twice (names match case-insensitively), or a concurrent slug clash",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a single recipe by its UUID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Delete a recipe by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipe ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/{id}/cost": {
+            "get": {
+                "description": "Multiply each ingredient's quantity by its unit price (see PUT /ingredients/{id}/price), converting between\nunits that share a base unit, e.g. 500 g priced per kg. A quantity range is costed at its midpoint.\nIngredients without a price, without a quantity, or in a unit that cannot be converted are left out of\nthe total and flagged with a reason; complete tells whether any were. Costs are rounded to 2 decimals.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Estimate a recipe's cost",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipe ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RecipeCost"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/{id}/difficulty-estimate": {
+            "get": {
+                "description": "Score a recipe from 0 to 100 from its number of steps and ingredients, its total time and whether any step\nneeds a temperature, with each factor's contribution. Scores below 35 are easy, from 65 hard, medium in between.\nThe estimate is computed on each request and never stored; the recipe's own difficulty, if set, is returned as manual.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Estimate a recipe's difficulty",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipe ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.DifficultyEstimate"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/{id}/ingredients": {
+            "post": {
+                "description": "Append an ingredient to a recipe, after its current ingredients; sort_order in the request is ignored.\nThe ingredient and unit are found or created by name as when creating a recipe.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Add an ingredient to a recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipe ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ingredient to add",
+                        "name": "ingredient",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RecipeIngredientRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.RecipeIngredient"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the recipe's ingredient"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input, ID format or unknown ingredient ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "The recipe already has this ingredient",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/{id}/ingredients/order": {
+            "put": {
+                "description": "Set the order of a recipe's ingredients. ingredient_ids must list every ingredient of the recipe exactly once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Reorder recipe ingredients",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipe ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ingredient IDs in the desired order",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.IngredientOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RecipeIngredient"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or input",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "The IDs do not match the recipe's current ingredients",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/{id}/ingredients/{ingredient_id}": {
+            "delete": {
+                "description": "Remove an ingredient from a recipe and return the remaining ingredients; later ingredients move up so\nsort_order has no gaps. The ingredient itself is not deleted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Remove an ingredient from a recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipe ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ingredient ID (UUID)",
+                        "name": "ingredient_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.RecipeIngredient"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found, or the ingredient is not in the recipe",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/{id}/markdown": {
+            "get": {
+                "description": "Render a recipe as Markdown: a title heading, an ingredient list with quantities and units,\nnumbered steps, equipment, notes and tags. Quantities are rounded like in JSON responses.",
+                "produces": [
+                    "text/markdown"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Export a recipe as Markdown",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipe ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The recipe as Markdown",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/{id}/photo": {
+            "get": {
+                "description": "Serve the photo of a recipe, or its thumbnail with size=thumb.",
+                "produces": [
+                    "image/jpeg",
+                    "image/png",
+                    "image/gif"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Get a recipe photo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipe ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "original",
+                            "thumb"
+                        ],
+                        "type": "string",
+                        "description": "Photo size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or size",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe or photo not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Upload a JPEG, PNG or GIF photo for a recipe. A JPEG thumbnail is generated alongside it and any previous photo is replaced.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Upload a recipe photo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipe ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Photo file",
+                        "name": "photo",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RecipePhotoResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or photo, or photo larger than MAX_PHOTO_PIXELS",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "413": {
+                        "description": "Photo larger than MAX_PHOTO_BYTES",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "415": {
+                        "description": "Photo type not allowed or not matching its declared type",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/{id}/print": {
+            "get": {
+                "description": "Render a recipe as a self-contained HTML page with inline styles, for printing: the title, servings,\ntimes, ingredients and numbered steps. Quantities are rounded like in JSON responses.",
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Get a printable recipe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipe ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The recipe as HTML",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/{id}/similar": {
+            "get": {
+                "description": "List recipes sharing the most tags and ingredients with the given recipe, highest overlap first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "List similar recipes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipe ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of recipes (default 10, max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SimilarRecipe"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/{id}/steps/{number}": {
+            "get": {
+                "description": "Get a single step of a recipe by its step number, e.g. to resume cook mode at that step.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Get a recipe step",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipe ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Step number (1-based)",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RecipeStep"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or step number",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Step not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/{id}/tags": {
+            "get": {
+                "description": "Get the tags of a recipe, ordered by name, without the rest of the recipe. An untagged recipe gives an empty array.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Get a recipe's tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipe ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Tag"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/recipes/{id}/translations/{lang}": {
+            "put": {
+                "description": "Set the title and description of a recipe in another language.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Create or replace a recipe translation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipe ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language code (e.g. fr)",
+                        "name": "lang",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Translated fields",
+                        "name": "translation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RecipeTranslationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RecipeTranslation"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input, ID format or language",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/schema/recipe": {
+            "get": {
+                "description": "Returns a JSON Schema (draft 2020-12) for the body of POST /recipes and PUT /recipes/{id}.\nIt is generated from the same validation rules the API applies, so clients can validate locally.\nRules that need the database (e.g. that an ingredient_id exists) are not expressed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Get the recipe request JSON Schema",
+                "responses": {
+                    "200": {
+                        "description": "JSON Schema",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/shopping-list": {
+            "post": {
+                "description": "Consolidate the ingredients of the given recipes, optionally scaled to a number of servings.\nQuantities of the same ingredient in convertible units (e.g. g and kg) are summed and shown in the largest\nunit used that keeps the amount at least 1; amounts in units that cannot be converted stay separate.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shopping"
+                ],
+                "summary": "Build a shopping list",
+                "parameters": [
+                    {
+                        "description": "Recipes to shop for",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ShoppingListRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ShoppingListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/shopping-list/diff": {
+            "post": {
+                "description": "Consolidate the ingredients of the given recipes like POST /shopping-list, then subtract what is already in the pantry.\nA pantry amount only covers the ingredient in units convertible to its unit (e.g. kg covers g); an amount\nwithout a unit covers unitless quantities such as \"2 eggs\". A pantry item without a quantity covers the ingredient\nentirely. Unquantified lines such as \"salt to taste\" are covered by any pantry item of the ingredient.\nIngredients fully covered are left out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shopping"
+                ],
+                "summary": "Build a shopping list minus the pantry",
+                "parameters": [
+                    {
+                        "description": "Recipes to shop for and the pantry",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ShoppingListDiffRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ShoppingListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or unknown unit_id",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Recipe not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats": {
+            "get": {
+                "description": "Get totals across all recipes for an overview dashboard: recipes, distinct ingredients in use and tags,\naverage prep and cook times (over recipes that set them), and the unarchived tag on the most recipes.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recipes"
+                ],
+                "summary": "Get recipe statistics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RecipeStats"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/popular": {
+            "get": {
+                "description": "List the tags carried by the most recipes, with their recipe counts, most used first.\nArchived tags are left out unless include_archived is set.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "List popular tags",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of tags to return (default 10, at most 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Also list archived tags",
+                        "name": "include_archived",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TagCount"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid limit",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{id}/archive": {
+            "post": {
+                "description": "Hide a tag from tag listings without deleting it. Recipes keep the tag. Archiving an archived tag is a no-op.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Archive a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Tag not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{id}/recipes": {
+            "post": {
+                "description": "Link a tag to every listed recipe. Recipes that already have the tag, or do not exist, are skipped, so the call is idempotent.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Assign a tag to recipes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Recipes to tag",
+                        "name": "assignment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TagAssignmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TagAssignmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or ID format",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Tag not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{id}/unarchive": {
+            "post": {
+                "description": "Show an archived tag in tag listings again. Unarchiving a tag that is not archived is a no-op.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tags"
+                ],
+                "summary": "Unarchive a tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Tag not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/units": {
+            "get": {
+                "description": "List all measurement units with their abbreviation and system, e.g. to populate a unit dropdown.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "units"
+                ],
+                "summary": "List measurement units",
+                "parameters": [
+                    {
+                        "enum": [
+                            "metric",
+                            "imperial",
+                            "other"
+                        ],
+                        "type": "string",
+                        "description": "Only units of this system",
+                        "name": "system",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.MeasurementUnit"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.APIError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Machine-readable error code, e.g. \"recipe_not_found\"",
+                    "type": "string"
+                },
+                "details": {
+                    "description": "Optional: structured details, e.g. per-field validation errors"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "description": "Correlation ID of the request, as in the X-Request-ID response header",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Optional: include HTTP status in body",
+                    "type": "integer"
+                },
+                "warnings": {
+                    "description": "Warnings flags suspicious but valid input next to blocking validation errors; see collectWarnings.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ValidationWarning"
+                    }
+                }
+            }
+        },
+        "models.Collection": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_public": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "recipe_count": {
+                    "description": "Computed",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CollectionOrderRequest": {
+            "type": "object",
+            "required": [
+                "recipe_ids"
+            ],
+            "properties": {
+                "recipe_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.CollectionRecipe": {
+            "type": "object",
+            "properties": {
+                "added_at": {
+                    "type": "string"
+                },
+                "cook_time_minutes": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "difficulty": {
+                    "$ref": "#/definitions/models.Difficulty"
+                },
+                "equipment": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Equipment"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ingredient_count": {
+                    "description": "Computed; lets list views show a count without the full ingredient list",
+                    "type": "integer"
+                },
+                "ingredient_groups": {
+                    "description": "IngredientGroups replaces Ingredients when the client asks for ingredients grouped by category.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IngredientGroup"
+                    }
+                },
+                "ingredients": {
+                    "description": "Fields for related data, to be populated when fetching a full recipe",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RecipeIngredient"
+                    }
+                },
+                "is_owner": {
+                    "description": "Whether created_by is the authenticated user; always false when anonymous",
+                    "type": "boolean"
+                },
+                "lang": {
+                    "description": "Set when Title/Description come from a translation",
+                    "type": "string"
+                },
+                "notes": {
+                    "description": "General notes or chef's tips; only on the full recipe, not in lists",
+                    "type": "string"
+                },
+                "photo_filename": {
+                    "type": "string"
+                },
+                "prep_time_minutes": {
+                    "type": "integer"
+                },
+                "serves": {
+                    "type": "integer"
+                },
+                "slug": {
+                    "description": "URL-friendly unique identifier derived from the title",
+                    "type": "string"
+                },
+                "sort_order": {
+                    "description": "Position in the collection, from 0",
+                    "type": "integer"
+                },
+                "source_name": {
+                    "description": "Where the recipe came from, e.g. a cookbook or website",
+                    "type": "string"
+                },
+                "source_url": {
+                    "type": "string"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RecipeStep"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Tag"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "total_time_minutes": {
+                    "description": "Read-only from DB",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warnings": {
+                    "description": "Warnings lists likely data-entry mistakes that did not fail validation. Only set on create and update responses.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ValidationWarning"
+                    }
+                },
+                "yield_quantity": {
+                    "description": "e.g. 24 in \"makes 24 cookies\"; independent of Serves",
+                    "type": "number"
+                },
+                "yield_unit": {
+                    "description": "e.g. \"cookies\"",
+                    "type": "string"
+                }
+            }
+        },
+        "models.CollectionRecipeRequest": {
+            "type": "object",
+            "required": [
+                "recipe_id"
+            ],
+            "properties": {
+                "recipe_id": {
+                    "type": "string"
+                },
+                "sort_order": {
+                    "description": "SortOrder inserts the recipe at this position, moving later recipes down. Omitted or past the end appends it.",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "models.CollectionRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "created_by": {
+                    "description": "Optional, depends on auth context",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 10000
+                },
+                "is_public": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                }
+            }
+        },
+        "models.Difficulty": {
+            "type": "string",
+            "enum": [
+                "easy",
+                "medium",
+                "hard"
+            ],
+            "x-enum-varnames": [
+                "DifficultyEasy",
+                "DifficultyMedium",
+                "DifficultyHard"
+            ]
+        },
+        "models.DifficultyEstimate": {
+            "type": "object",
+            "properties": {
+                "difficulty": {
+                    "description": "The score's band: easy below 35, hard from 65",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Difficulty"
+                        }
+                    ]
+                },
+                "factors": {
+                    "description": "What the score is made of, in a fixed order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DifficultyFactor"
+                    }
+                },
+                "manual": {
+                    "description": "The difficulty set on the recipe, if any",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Difficulty"
+                        }
+                    ]
+                },
+                "recipe_id": {
+                    "type": "string"
+                },
+                "score": {
+                    "description": "0-100, the sum of the factors' points",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DifficultyFactor": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "steps, ingredients, total_time or temperature_steps",
+                    "type": "string"
+                },
+                "points": {
+                    "description": "Points added to the score",
+                    "type": "integer"
+                },
+                "value": {
+                    "description": "The measured amount, e.g. the number of steps or minutes",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Equipment": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Ingredient": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price_unit_id": {
+                    "description": "e.g. kilogram for a price per kg",
+                    "type": "string"
+                },
+                "seasons": {
+                    "description": "Months (1-12) the ingredient is in season; empty means year-round",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "unit_price": {
+                    "description": "Price of one PriceUnitID, or of one item without a unit",
+                    "type": "number"
+                }
+            }
+        },
+        "models.IngredientCost": {
+            "type": "object",
+            "properties": {
+                "cost": {
+                    "description": "Unset when excluded",
+                    "type": "number"
+                },
+                "excluded": {
+                    "description": "Left out of the total",
+                    "type": "boolean"
+                },
+                "ingredient_id": {
+                    "type": "string"
+                },
+                "ingredient_name": {
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Why it was excluded: no_price, no_quantity or unit_mismatch",
+                    "type": "string"
+                }
+            }
+        },
+        "models.IngredientCount": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.IngredientGroup": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "Null for uncategorised ingredients",
+                    "type": "string"
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RecipeIngredient"
+                    }
+                }
+            }
+        },
+        "models.IngredientOrderRequest": {
+            "type": "object",
+            "required": [
+                "ingredient_ids"
+            ],
+            "properties": {
+                "ingredient_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.IngredientPatchRequest": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "price_unit_id": {
+                    "type": "string"
+                },
+                "unit_price": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "models.IngredientPriceRequest": {
+            "type": "object",
+            "properties": {
+                "price_unit_id": {
+                    "type": "string"
+                },
+                "unit_price": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "models.IngredientRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                }
+            }
+        },
+        "models.IngredientSeasonsRequest": {
+            "type": "object",
+            "properties": {
+                "seasons": {
+                    "type": "array",
+                    "maxItems": 12,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "models.IngredientStats": {
+            "type": "object",
+            "properties": {
+                "ingredient_id": {
+                    "type": "string"
+                },
+                "ingredient_name": {
+                    "type": "string"
+                },
+                "recipe_count": {
+                    "type": "integer"
+                },
+                "units": {
+                    "description": "Most used first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IngredientUnitUsage"
+                    }
+                }
+            }
+        },
+        "models.IngredientUnitUsage": {
+            "type": "object",
+            "properties": {
+                "average_quantity": {
+                    "description": "Over the uses that give a quantity",
+                    "type": "number"
+                },
+                "recipe_count": {
+                    "description": "Recipes using the ingredient with this unit",
+                    "type": "integer"
+                },
+                "unit": {
+                    "description": "Nil for uses without a unit, e.g. \"2 eggs\"",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MeasurementUnit"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.MealieRecipe": {
+            "type": "object"
+        },
+        "models.MeasurementSystem": {
+            "type": "string",
+            "enum": [
+                "metric",
+                "imperial",
+                "other"
+            ],
+            "x-enum-varnames": [
+                "Metric",
+                "Imperial",
+                "Other"
+            ]
+        },
+        "models.MeasurementUnit": {
+            "type": "object",
+            "properties": {
+                "abbreviation": {
+                    "type": "string"
+                },
+                "base_unit_id": {
+                    "type": "string"
+                },
                 "conversion_factor": {
                     "type": "number"
                 },
                 "id": {
-                    "description": "Made pointer to handle NULL from LEFT JOIN",
+                    "description": "Made pointer to handle NULL from LEFT JOIN",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Changed to pointer to handle NULL",
+                    "type": "string"
+                },
+                "system": {
+                    "description": "From common.go; Changed to pointer",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MeasurementSystem"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.PantryItem": {
+            "type": "object",
+            "required": [
+                "ingredient_id"
+            ],
+            "properties": {
+                "ingredient_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "unit_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ParsedIngredient": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "Empty for a blank line",
+                    "type": "string"
+                },
+                "notes": {
+                    "description": "The text after the first comma",
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "text": {
+                    "description": "The line as submitted",
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Recipe": {
+            "type": "object",
+            "properties": {
+                "cook_time_minutes": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "difficulty": {
+                    "$ref": "#/definitions/models.Difficulty"
+                },
+                "equipment": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Equipment"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ingredient_count": {
+                    "description": "Computed; lets list views show a count without the full ingredient list",
+                    "type": "integer"
+                },
+                "ingredient_groups": {
+                    "description": "IngredientGroups replaces Ingredients when the client asks for ingredients grouped by category.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IngredientGroup"
+                    }
+                },
+                "ingredients": {
+                    "description": "Fields for related data, to be populated when fetching a full recipe",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RecipeIngredient"
+                    }
+                },
+                "is_owner": {
+                    "description": "Whether created_by is the authenticated user; always false when anonymous",
+                    "type": "boolean"
+                },
+                "lang": {
+                    "description": "Set when Title/Description come from a translation",
+                    "type": "string"
+                },
+                "notes": {
+                    "description": "General notes or chef's tips; only on the full recipe, not in lists",
+                    "type": "string"
+                },
+                "photo_filename": {
+                    "type": "string"
+                },
+                "prep_time_minutes": {
+                    "type": "integer"
+                },
+                "serves": {
+                    "type": "integer"
+                },
+                "slug": {
+                    "description": "URL-friendly unique identifier derived from the title",
+                    "type": "string"
+                },
+                "source_name": {
+                    "description": "Where the recipe came from, e.g. a cookbook or website",
+                    "type": "string"
+                },
+                "source_url": {
+                    "type": "string"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RecipeStep"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Tag"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "total_time_minutes": {
+                    "description": "Read-only from DB",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warnings": {
+                    "description": "Warnings lists likely data-entry mistakes that did not fail validation. Only set on create and update responses.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ValidationWarning"
+                    }
+                },
+                "yield_quantity": {
+                    "description": "e.g. 24 in \"makes 24 cookies\"; independent of Serves",
+                    "type": "number"
+                },
+                "yield_unit": {
+                    "description": "e.g. \"cookies\"",
+                    "type": "string"
+                }
+            }
+        },
+        "models.RecipeBulkDeleteRequest": {
+            "type": "object",
+            "required": [
+                "recipe_ids"
+            ],
+            "properties": {
+                "recipe_ids": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.RecipeBulkDeleteResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "integer"
+                },
+                "not_found": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.RecipeCost": {
+            "type": "object",
+            "properties": {
+                "complete": {
+                    "description": "Whether every ingredient was priced",
+                    "type": "boolean"
+                },
+                "cost_per_serving": {
+                    "description": "Set when the recipe's servings are",
+                    "type": "number"
+                },
+                "ingredients": {
+                    "description": "In the recipe's order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IngredientCost"
+                    }
+                },
+                "recipe_id": {
+                    "type": "string"
+                },
+                "serves": {
+                    "description": "The recipe's servings, if set",
+                    "type": "integer"
+                },
+                "total_cost": {
+                    "description": "Sum of the costs of the ingredients that could be priced",
+                    "type": "number"
+                }
+            }
+        },
+        "models.RecipeEquipmentRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                }
+            }
+        },
+        "models.RecipeExistsRequest": {
+            "type": "object",
+            "required": [
+                "recipe_ids"
+            ],
+            "properties": {
+                "recipe_ids": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.RecipeExistsResponse": {
+            "type": "object",
+            "properties": {
+                "existing": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "missing": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.RecipeImportResponse": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer"
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RecipeImportResult"
+                    }
+                }
+            }
+        },
+        "models.RecipeImportResult": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "description": "Validation or store errors; nothing was created",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "index": {
+                    "description": "Position in the uploaded export",
+                    "type": "integer"
+                },
+                "recipe": {
+                    "$ref": "#/definitions/models.Recipe"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "warnings": {
+                    "description": "Data that could not be mapped and was dropped",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.RecipeIngredient": {
+            "type": "object",
+            "properties": {
+                "converted": {
+                    "description": "With ?units=, whether the quantity is in the requested system",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ingredient_category": {
+                    "description": "From Ingredient table",
+                    "type": "string"
+                },
+                "ingredient_id": {
+                    "type": "string"
+                },
+                "ingredient_name": {
+                    "description": "Fields to populate from related tables for richer API responses",
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "preparation": {
+                    "description": "e.g. \"finely chopped\"",
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "quantity_max": {
+                    "type": "number"
+                },
+                "quantity_min": {
+                    "description": "Set with QuantityMax for ranges such as \"2-3\"",
+                    "type": "number"
+                },
+                "sort_order": {
+                    "type": "integer"
+                },
+                "unit": {
+                    "description": "Populated from MeasurementUnit table",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.MeasurementUnit"
+                        }
+                    ]
+                },
+                "unit_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RecipeIngredientRequest": {
+            "type": "object",
+            "properties": {
+                "ingredient_id": {
+                    "type": "string"
+                },
+                "ingredient_name": {
+                    "description": "ingredients.name is VARCHAR(255)",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "preparation": {
+                    "description": "e.g. \"finely chopped\", \"room temperature\"",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "quantity_max": {
+                    "type": "number"
+                },
+                "quantity_min": {
+                    "description": "required_with comes before omitempty so that it is still checked when this bound is missing.",
+                    "type": "number"
+                },
+                "quantity_text": {
+                    "description": "e.g. \"1 1/2\" or \"½\"; parsed into Quantity",
+                    "type": "string"
+                },
+                "sort_order": {
+                    "description": "Unique within a recipe; omitted lines are numbered after the highest given, in the order sent",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "unit_name": {
+                    "description": "e.g., \"grams\", \"ml\", \"cup\"; backend will find or create; VARCHAR(50)",
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "models.RecipeListResponse": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "description": "Pass as ?after= to fetch the next page; absent on the last page",
+                    "type": "string"
+                },
+                "page": {
+                    "description": "Only set in offset mode",
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "recipes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Recipe"
+                    }
+                }
+            }
+        },
+        "models.RecipePhotoResponse": {
+            "type": "object",
+            "properties": {
+                "photo_filename": {
+                    "type": "string"
+                },
+                "thumbnail_filename": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RecipeRequest": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "cook_time_minutes": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "created_by": {
+                    "description": "Optional, depends on auth context",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "difficulty": {
+                    "enum": [
+                        "easy",
+                        "medium",
+                        "hard"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Difficulty"
+                        }
+                    ]
+                },
+                "equipment": {
+                    "description": "Found or created by name, like tags",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RecipeEquipmentRequest"
+                    }
+                },
+                "ingredients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RecipeIngredientRequest"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 10000
+                },
+                "photo_filename": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "prep_time_minutes": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "serves": {
+                    "type": "integer"
+                },
+                "source_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "source_url": {
+                    "type": "string",
+                    "maxLength": 2048
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RecipeStepRequest"
+                    }
+                },
+                "tags": {
+                    "description": "For creating/associating tags by name",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RecipeTagRequest"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                },
+                "yield_quantity": {
+                    "type": "number"
+                },
+                "yield_unit": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 1
+                }
+            }
+        },
+        "models.RecipeSearchResult": {
+            "type": "object",
+            "properties": {
+                "cook_time_minutes": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "difficulty": {
+                    "$ref": "#/definitions/models.Difficulty"
+                },
+                "equipment": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Equipment"
+                    }
+                },
+                "fuzzy": {
+                    "description": "Found by the fuzzy title search because the full-text search found nothing",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ingredient_count": {
+                    "description": "Computed; lets list views show a count without the full ingredient list",
+                    "type": "integer"
+                },
+                "ingredient_groups": {
+                    "description": "IngredientGroups replaces Ingredients when the client asks for ingredients grouped by category.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IngredientGroup"
+                    }
+                },
+                "ingredients": {
+                    "description": "Fields for related data, to be populated when fetching a full recipe",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RecipeIngredient"
+                    }
+                },
+                "is_owner": {
+                    "description": "Whether created_by is the authenticated user; always false when anonymous",
+                    "type": "boolean"
+                },
+                "lang": {
+                    "description": "Set when Title/Description come from a translation",
+                    "type": "string"
+                },
+                "notes": {
+                    "description": "General notes or chef's tips; only on the full recipe, not in lists",
+                    "type": "string"
+                },
+                "photo_filename": {
+                    "type": "string"
+                },
+                "prep_time_minutes": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "description": "ts_rank of the match, or title similarity when fuzzy; higher is more relevant",
+                    "type": "number"
+                },
+                "serves": {
+                    "type": "integer"
+                },
+                "slug": {
+                    "description": "URL-friendly unique identifier derived from the title",
+                    "type": "string"
+                },
+                "source_name": {
+                    "description": "Where the recipe came from, e.g. a cookbook or website",
+                    "type": "string"
+                },
+                "source_url": {
+                    "type": "string"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RecipeStep"
+                    }
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Tag"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "total_time_minutes": {
+                    "description": "Read-only from DB",
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "warnings": {
+                    "description": "Warnings lists likely data-entry mistakes that did not fail validation. Only set on create and update responses.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ValidationWarning"
+                    }
+                },
+                "yield_quantity": {
+                    "description": "e.g. 24 in \"makes 24 cookies\"; independent of Serves",
+                    "type": "number"
+                },
+                "yield_unit": {
+                    "description": "e.g. \"cookies\"",
+                    "type": "string"
+                }
+            }
+        },
+        "models.RecipeStats": {
+            "type": "object",
+            "properties": {
+                "average_cook_time_minutes": {
+                    "type": "number"
+                },
+                "average_prep_time_minutes": {
+                    "description": "Over recipes that have one; null when none do",
+                    "type": "number"
+                },
+                "most_common_tag": {
+                    "description": "Null when no recipe has an unarchived tag",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TagCount"
+                        }
+                    ]
+                },
+                "total_ingredients": {
+                    "description": "Distinct ingredients used by at least one recipe",
+                    "type": "integer"
+                },
+                "total_recipes": {
+                    "type": "integer"
+                },
+                "total_tags": {
+                    "description": "Including unused and archived tags",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.RecipeStep": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "duration_minutes": {
+                    "type": "integer"
+                },
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "instruction": {
+                    "type": "string"
+                },
+                "step_number": {
+                    "type": "integer"
+                },
+                "step_type": {
+                    "$ref": "#/definitions/models.StepType"
+                },
+                "temperature": {
+                    "type": "string"
+                },
+                "total_duration_seconds": {
+                    "description": "TotalDurationSeconds combines duration_minutes and duration_seconds; computed, not stored.",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.RecipeStepRequest": {
+            "type": "object",
+            "required": [
+                "instruction",
+                "step_number"
+            ],
+            "properties": {
+                "duration_minutes": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "duration_seconds": {
+                    "description": "DurationSeconds is 0-59 when DurationMinutes is also given, otherwise the whole duration in seconds.",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "instruction": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "step_number": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "step_type": {
+                    "description": "StepType defaults to \"normal\" when omitted.",
+                    "enum": [
+                        "normal",
+                        "optional",
+                        "make_ahead"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.StepType"
+                        }
+                    ]
+                },
+                "temperature": {
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "models.RecipeTagRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                }
+            }
+        },
+        "models.RecipeTranslation": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
                     "type": "string"
                 },
-                "name": {
-                    "description": "Changed to pointer to handle NULL",
+                "lang": {
+                    "type": "string"
+                },
+                "recipe_id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RecipeTranslationRequest": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3
+                }
+            }
+        },
+        "models.SearchReindexReport": {
+            "type": "object",
+            "properties": {
+                "batches": {
+                    "type": "integer"
+                },
+                "generated": {
+                    "description": "Generated reports whether search_vector is a generated column in this database. Postgres keeps\na generated column in sync itself (from title and description only), so nothing is rewritten.",
+                    "type": "boolean"
+                },
+                "reindexed": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ShoppingListDiffRequest": {
+            "type": "object",
+            "required": [
+                "recipes"
+            ],
+            "properties": {
+                "pantry": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "items": {
+                        "$ref": "#/definitions/models.PantryItem"
+                    }
+                },
+                "recipes": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.ShoppingListRecipeRequest"
+                    }
+                }
+            }
+        },
+        "models.ShoppingListItem": {
+            "type": "object",
+            "properties": {
+                "ingredient_category": {
+                    "type": "string"
+                },
+                "ingredient_id": {
+                    "type": "string"
+                },
+                "ingredient_name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "description": "Null for unquantified lines such as \"salt to taste\"",
+                    "type": "number"
+                },
+                "recipe_ids": {
+                    "description": "Recipes that need this item",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "unit": {
+                    "$ref": "#/definitions/models.MeasurementUnit"
+                }
+            }
+        },
+        "models.ShoppingListRecipeRequest": {
+            "type": "object",
+            "required": [
+                "recipe_id"
+            ],
+            "properties": {
+                "recipe_id": {
                     "type": "string"
                 },
-                "system": {
-                    "description": "From common.go; Changed to pointer",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.MeasurementSystem"
-                        }
-                    ]
+                "servings": {
+                    "description": "Scales quantities by servings / serves; ignored when the recipe has no serves",
+                    "type": "integer"
                 }
             }
         },
-        "models.Recipe": {
+        "models.ShoppingListRequest": {
+            "type": "object",
+            "required": [
+                "recipes"
+            ],
+            "properties": {
+                "recipes": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.ShoppingListRecipeRequest"
+                    }
+                }
+            }
+        },
+        "models.ShoppingListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ShoppingListItem"
+                    }
+                }
+            }
+        },
+        "models.SimilarRecipe": {
             "type": "object",
             "properties": {
                 "cook_time_minutes": {
@@ -313,9 +4208,29 @@ const docTemplate = `{
                 "description": {
                     "type": "string"
                 },
+                "difficulty": {
+                    "$ref": "#/definitions/models.Difficulty"
+                },
+                "equipment": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Equipment"
+                    }
+                },
                 "id": {
                     "type": "string"
                 },
+                "ingredient_count": {
+                    "description": "Computed; lets list views show a count without the full ingredient list",
+                    "type": "integer"
+                },
+                "ingredient_groups": {
+                    "description": "IngredientGroups replaces Ingredients when the client asks for ingredients grouped by category.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IngredientGroup"
+                    }
+                },
                 "ingredients": {
                     "description": "Fields for related data, to be populated when fetching a full recipe",
                     "type": "array",
@@ -323,15 +4238,48 @@ const docTemplate = `{
                         "$ref": "#/definitions/models.RecipeIngredient"
                     }
                 },
+                "is_owner": {
+                    "description": "Whether created_by is the authenticated user; always false when anonymous",
+                    "type": "boolean"
+                },
+                "lang": {
+                    "description": "Set when Title/Description come from a translation",
+                    "type": "string"
+                },
+                "notes": {
+                    "description": "General notes or chef's tips; only on the full recipe, not in lists",
+                    "type": "string"
+                },
                 "photo_filename": {
                     "type": "string"
                 },
                 "prep_time_minutes": {
                     "type": "integer"
                 },
+                "score": {
+                    "description": "shared_tags + shared_ingredients",
+                    "type": "integer"
+                },
                 "serves": {
                     "type": "integer"
                 },
+                "shared_ingredients": {
+                    "type": "integer"
+                },
+                "shared_tags": {
+                    "type": "integer"
+                },
+                "slug": {
+                    "description": "URL-friendly unique identifier derived from the title",
+                    "type": "string"
+                },
+                "source_name": {
+                    "description": "Where the recipe came from, e.g. a cookbook or website",
+                    "type": "string"
+                },
+                "source_url": {
+                    "type": "string"
+                },
                 "steps": {
                     "type": "array",
                     "items": {
@@ -353,205 +4301,167 @@ const docTemplate = `{
                 },
                 "updated_at": {
                     "type": "string"
-                }
-            }
-        },
-        "models.RecipeIngredient": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "string"
-                },
-                "ingredient_description": {
-                    "description": "From Ingredient table",
-                    "type": "string"
                 },
-                "ingredient_id": {
-                    "type": "string"
-                },
-                "ingredient_name": {
-                    "description": "Fields to populate from related tables for richer API responses",
-                    "type": "string"
-                },
-                "notes": {
-                    "type": "string"
+                "warnings": {
+                    "description": "Warnings lists likely data-entry mistakes that did not fail validation. Only set on create and update responses.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ValidationWarning"
+                    }
                 },
-                "quantity": {
+                "yield_quantity": {
+                    "description": "e.g. 24 in \"makes 24 cookies\"; independent of Serves",
                     "type": "number"
                 },
-                "sort_order": {
-                    "type": "integer"
-                },
-                "unit": {
-                    "description": "Populated from MeasurementUnit table",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.MeasurementUnit"
-                        }
-                    ]
-                },
-                "unit_id": {
+                "yield_unit": {
+                    "description": "e.g. \"cookies\"",
                     "type": "string"
                 }
             }
         },
-        "models.RecipeIngredientRequest": {
-            "type": "object",
-            "required": [
-                "ingredient_name"
+        "models.StepType": {
+            "type": "string",
+            "enum": [
+                "normal",
+                "optional",
+                "make_ahead"
             ],
+            "x-enum-varnames": [
+                "StepTypeNormal",
+                "StepTypeOptional",
+                "StepTypeMakeAhead"
+            ]
+        },
+        "models.Tag": {
+            "type": "object",
             "properties": {
-                "ingredient_name": {
+                "archived": {
+                    "description": "Hidden from tag listings; still applies to recipes",
+                    "type": "boolean"
+                },
+                "color": {
+                    "description": "Hex color code",
                     "type": "string"
                 },
-                "notes": {
+                "created_at": {
                     "type": "string"
                 },
-                "quantity": {
-                    "type": "number"
+                "description": {
+                    "type": "string"
                 },
-                "sort_order": {
-                    "type": "integer",
-                    "minimum": 0
+                "id": {
+                    "type": "string"
                 },
-                "unit_name": {
-                    "description": "e.g., \"grams\", \"ml\", \"cup\"; backend will find or create",
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "models.RecipeRequest": {
+        "models.TagAssignmentRequest": {
             "type": "object",
             "required": [
-                "title"
+                "recipe_ids"
             ],
             "properties": {
-                "cook_time_minutes": {
-                    "type": "integer",
-                    "minimum": 0
-                },
-                "created_by": {
-                    "description": "Optional, depends on auth context",
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "ingredients": {
+                "recipe_ids": {
                     "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
                     "items": {
-                        "$ref": "#/definitions/models.RecipeIngredientRequest"
+                        "type": "string"
                     }
-                },
-                "photo_filename": {
-                    "type": "string",
-                    "maxLength": 255
-                },
-                "prep_time_minutes": {
-                    "type": "integer",
-                    "minimum": 0
-                },
-                "serves": {
+                }
+            }
+        },
+        "models.TagAssignmentResponse": {
+            "type": "object",
+            "properties": {
+                "added": {
                     "type": "integer"
                 },
-                "steps": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.RecipeStepRequest"
-                    }
-                },
-                "tags": {
-                    "description": "For creating/associating tags by name",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.RecipeTagRequest"
-                    }
-                },
-                "title": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 3
+                "skipped": {
+                    "type": "integer"
                 }
             }
         },
-        "models.RecipeStep": {
+        "models.TagCleanupResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "deleted": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TagCount": {
+            "type": "object",
+            "properties": {
+                "archived": {
+                    "type": "boolean"
                 },
-                "duration_minutes": {
+                "count": {
                     "type": "integer"
                 },
                 "id": {
                     "type": "string"
                 },
-                "instruction": {
-                    "type": "string"
-                },
-                "step_number": {
-                    "type": "integer"
-                },
-                "temperature": {
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "models.RecipeStepRequest": {
+        "models.TotalTimeCheckReport": {
             "type": "object",
-            "required": [
-                "instruction",
-                "step_number"
-            ],
             "properties": {
-                "duration_minutes": {
-                    "type": "integer",
-                    "minimum": 0
+                "checked": {
+                    "type": "integer"
                 },
-                "instruction": {
-                    "type": "string",
-                    "minLength": 1
+                "fixed": {
+                    "type": "integer"
                 },
-                "step_number": {
-                    "type": "integer",
-                    "minimum": 1
+                "generated": {
+                    "description": "Generated reports whether total_time_minutes is a generated column in this database.\nWhen it is not, mismatches are fixed in place and counted in Fixed.",
+                    "type": "boolean"
                 },
-                "temperature": {
-                    "type": "string",
-                    "maxLength": 50
-                }
-            }
-        },
-        "models.RecipeTagRequest": {
-            "type": "object",
-            "required": [
-                "name"
-            ],
-            "properties": {
-                "name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 1
+                "mismatches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TotalTimeMismatch"
+                    }
                 }
             }
         },
-        "models.Tag": {
+        "models.TotalTimeMismatch": {
             "type": "object",
             "properties": {
-                "color": {
-                    "description": "Hex color code",
-                    "type": "string"
+                "cook_time_minutes": {
+                    "type": "integer"
                 },
-                "created_at": {
+                "expected_minutes": {
+                    "description": "prep + cook; null when either is null",
+                    "type": "integer"
+                },
+                "prep_time_minutes": {
+                    "type": "integer"
+                },
+                "recipe_id": {
                     "type": "string"
                 },
-                "description": {
+                "title": {
                     "type": "string"
                 },
-                "id": {
+                "total_time_minutes": {
+                    "description": "As stored",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ValidationWarning": {
+            "type": "object",
+            "properties": {
+                "field": {
                     "type": "string"
                 },
-                "name": {
+                "message": {
                     "type": "string"
                 }
             }
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store"
+	"github.com/gin-gonic/gin"
+)
+
+// AdminHandler handles HTTP requests for maintenance operations.
+type AdminHandler struct {
+	store store.AdminStore
+}
+
+// NewAdminHandler creates a new AdminHandler.
+func NewAdminHandler(store store.AdminStore) *AdminHandler {
+	return &AdminHandler{store: store}
+}
+
+// RecomputeTotalTimes handles the total_time_minutes consistency check.
+// @Summary Check recipe total times
+// @Description Verify that every recipe's total_time_minutes equals prep_time_minutes + cook_time_minutes and report mismatches.
+// @Description If total_time_minutes is not a generated column in this database, mismatches are also fixed.
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.TotalTimeCheckReport
+// @Failure 401 {object} APIError "Missing or invalid admin API key"
+// @Failure 500 {object} APIError "Server error"
+// @Router /admin/recompute-times [post]
+func (h *AdminHandler) RecomputeTotalTimes(c *gin.Context) {
+	report, err := h.store.RecomputeTotalTimes(c.Request.Context())
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to check total times: "+err.Error())
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, report)
+}
+
+// CleanupTags handles deleting tags no recipe uses.
+// @Summary Delete unused tags
+// @Description Delete every tag that is not assigned to any recipe and return how many were removed.
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} models.TagCleanupResponse
+// @Failure 401 {object} APIError "Missing or invalid admin API key"
+// @Failure 500 {object} APIError "Server error"
+// @Router /admin/cleanup-tags [post]
+func (h *AdminHandler) CleanupTags(c *gin.Context) {
+	deleted, err := h.store.DeleteUnusedTags(c.Request.Context())
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to delete unused tags: "+err.Error())
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, models.TagCleanupResponse{Deleted: deleted})
+}
+
+// defaultReindexBatchSize is how many recipes ReindexSearch updates per statement when no batch_size is given.
+const defaultReindexBatchSize = 500
+
+// ReindexSearch handles recomputing every recipe's search_vector.
+// @Summary Re-index recipe search
+// @Description Recompute search_vector for all recipes from title, description and ingredient names, batch_size recipes per statement.
+// @Description If search_vector is a generated column in this database, Postgres already keeps it in sync (from title and description) and nothing is rewritten.
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param batch_size query int false "Recipes updated per statement (default 500)"
+// @Success 200 {object} models.SearchReindexReport
+// @Failure 400 {object} APIError "Invalid batch_size"
+// @Failure 401 {object} APIError "Missing or invalid admin API key"
+// @Failure 500 {object} APIError "Server error"
+// @Router /admin/reindex-search [post]
+func (h *AdminHandler) ReindexSearch(c *gin.Context) {
+	batchSize, err := parsePositiveIntQuery(c, "batch_size", defaultReindexBatchSize)
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: "+err.Error())
+		return
+	}
+	report, err := h.store.ReindexSearch(c.Request.Context(), batchSize)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to reindex search: "+err.Error())
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, report)
+}
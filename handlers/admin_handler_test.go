@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store/mocks"
+)
+
+// Helper function to create a new Gin engine for admin handler tests
+func setupAdminTestRouter(handler *AdminHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	api := router.Group("/api/v1")
+	{
+		api.POST("/admin/recompute-times", handler.RecomputeTotalTimes)
+		api.POST("/admin/cleanup-tags", handler.CleanupTags)
+		api.POST("/admin/reindex-search", handler.ReindexSearch)
+	}
+	return router
+}
+
+func TestAdminHandler_RecomputeTotalTimes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockAdminStore(ctrl)
+	router := setupAdminTestRouter(NewAdminHandler(mockStore))
+
+	report := &models.TotalTimeCheckReport{
+		Checked: 3,
+		Mismatches: []models.TotalTimeMismatch{
+			{RecipeID: uuid.New(), Title: "Stale", PrepTimeMinutes: intPtr(10), CookTimeMinutes: intPtr(20), TotalTimeMinutes: intPtr(25), ExpectedMinutes: intPtr(30)},
+		},
+		Fixed: 1,
+	}
+	gomock.InOrder(
+		mockStore.EXPECT().RecomputeTotalTimes(gomock.Any()).Return(report, nil),
+		mockStore.EXPECT().RecomputeTotalTimes(gomock.Any()).Return(nil, errors.New("connection refused")),
+	)
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/recompute-times", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.TotalTimeCheckReport
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, *report, response)
+
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/admin/recompute-times", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestAdminHandler_CleanupTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockAdminStore(ctrl)
+	router := setupAdminTestRouter(NewAdminHandler(mockStore))
+	mockStore.EXPECT().DeleteUnusedTags(gomock.Any()).Return(4, nil).Times(1)
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/cleanup-tags", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"deleted":4}`, w.Body.String())
+}
+
+func TestAdminHandler_ReindexSearch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockAdminStore(ctrl)
+	router := setupAdminTestRouter(NewAdminHandler(mockStore))
+	gomock.InOrder(
+		mockStore.EXPECT().ReindexSearch(gomock.Any(), defaultReindexBatchSize).Return(&models.SearchReindexReport{Reindexed: 1200, Batches: 3}, nil),
+		mockStore.EXPECT().ReindexSearch(gomock.Any(), 50).Return(&models.SearchReindexReport{Generated: true}, nil),
+	)
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/admin/reindex-search", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"generated":false,"reindexed":1200,"batches":3}`, w.Body.String())
+
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/admin/reindex-search?batch_size=50", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"generated":true,"reindexed":0,"batches":0}`, w.Body.String())
+
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/admin/reindex-search?batch_size=0", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
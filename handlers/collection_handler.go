@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CollectionHandler handles HTTP requests for recipe collections.
+type CollectionHandler struct {
+	store store.CollectionStore
+}
+
+// NewCollectionHandler creates a new CollectionHandler.
+func NewCollectionHandler(store store.CollectionStore) *CollectionHandler {
+	return &CollectionHandler{store: store}
+}
+
+// CreateCollection handles creating a new, empty collection.
+// @Summary Create a collection
+// @Description Create a named collection, such as a cookbook, to group recipes in a chosen order.
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param collection body models.CollectionRequest true "Collection to create"
+// @Success 201 {object} models.Collection
+// @Failure 400 {object} APIError "Invalid input"
+// @Failure 500 {object} APIError "Server error"
+// @Header 201 {string} Location "URL of the created collection"
+// @Router /collections [post]
+func (h *CollectionHandler) CreateCollection(c *gin.Context) {
+	var req models.CollectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPayload, "Invalid request payload: "+err.Error())
+		return
+	}
+	trimStrings(&req)
+	if err := validate.Struct(req); err != nil {
+		RespondWithDetailedError(c, http.StatusBadRequest, ErrCodeValidationFailed, "Validation failed", formatValidationErrors(err))
+		return
+	}
+
+	collection, err := h.store.CreateCollection(c.Request.Context(), &req)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to create collection: "+err.Error())
+		return
+	}
+	c.Header("Location", c.FullPath()+"/"+collection.ID.String())
+	RespondWithJSON(c, http.StatusCreated, collection)
+}
+
+// GetCollection handles fetching a collection.
+// @Summary Get a collection
+// @Description Get a collection with the number of recipes in it. Use /collections/{id}/recipes for the recipes themselves.
+// @Tags collections
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Success 200 {object} models.Collection
+// @Failure 400 {object} APIError "Invalid ID format"
+// @Failure 404 {object} APIError "Collection not found"
+// @Failure 500 {object} APIError "Server error"
+// @Router /collections/{id} [get]
+func (h *CollectionHandler) GetCollection(c *gin.Context) {
+	collectionID, ok := parseCollectionID(c)
+	if !ok {
+		return
+	}
+
+	collection, err := h.store.GetCollection(c.Request.Context(), collectionID)
+	if err != nil {
+		respondWithCollectionError(c, "Failed to get collection: ", err)
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, collection)
+}
+
+// ListCollectionRecipes handles listing the recipes of a collection.
+// @Summary List a collection's recipes
+// @Description List summaries of the recipes in a collection, in collection order.
+// @Tags collections
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Success 200 {array} models.CollectionRecipe
+// @Failure 400 {object} APIError "Invalid ID format"
+// @Failure 404 {object} APIError "Collection not found"
+// @Failure 500 {object} APIError "Server error"
+// @Router /collections/{id}/recipes [get]
+func (h *CollectionHandler) ListCollectionRecipes(c *gin.Context) {
+	collectionID, ok := parseCollectionID(c)
+	if !ok {
+		return
+	}
+
+	recipes, err := h.store.ListCollectionRecipes(c.Request.Context(), collectionID)
+	if err != nil {
+		respondWithCollectionError(c, "Failed to list collection recipes: ", err)
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, recipes)
+}
+
+// AddRecipeToCollection handles adding a recipe to a collection.
+// @Summary Add a recipe to a collection
+// @Description Add a recipe at sort_order, moving later recipes down, or at the end when sort_order is omitted.
+// @Description A recipe can be in many collections, but only once in each. The collection's recipes are returned.
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param recipe body models.CollectionRecipeRequest true "Recipe to add"
+// @Success 200 {array} models.CollectionRecipe
+// @Failure 400 {object} APIError "Invalid ID or input, or the recipe does not exist"
+// @Failure 404 {object} APIError "Collection not found"
+// @Failure 409 {object} APIError "The recipe is already in the collection"
+// @Failure 500 {object} APIError "Server error"
+// @Router /collections/{id}/recipes [post]
+func (h *CollectionHandler) AddRecipeToCollection(c *gin.Context) {
+	collectionID, ok := parseCollectionID(c)
+	if !ok {
+		return
+	}
+
+	var req models.CollectionRecipeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPayload, "Invalid request payload: "+err.Error())
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		RespondWithDetailedError(c, http.StatusBadRequest, ErrCodeValidationFailed, "Validation failed", formatValidationErrors(err))
+		return
+	}
+
+	recipes, err := h.store.AddRecipeToCollection(c.Request.Context(), collectionID, req.RecipeID, req.SortOrder)
+	if err != nil {
+		if errors.Is(err, store.ErrUnknownRecipe) {
+			RespondWithError(c, http.StatusBadRequest, ErrCodeUnknownRecipe, "Invalid recipe reference: "+err.Error())
+		} else if errors.Is(err, store.ErrConflict) {
+			RespondWithError(c, http.StatusConflict, ErrCodeConflict, "Failed to add recipe: "+err.Error())
+		} else {
+			respondWithCollectionError(c, "Failed to add recipe: ", err)
+		}
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, recipes)
+}
+
+// RemoveRecipeFromCollection handles removing a recipe from a collection.
+// @Summary Remove a recipe from a collection
+// @Description Remove a recipe from a collection; later recipes move up. The recipe itself is not deleted.
+// @Description Removing a recipe that is not in the collection succeeds, so the call is idempotent.
+// @Tags collections
+// @Param id path string true "Collection ID (UUID)"
+// @Param recipe_id path string true "Recipe ID (UUID)"
+// @Success 204 "No Content"
+// @Failure 400 {object} APIError "Invalid ID format"
+// @Failure 404 {object} APIError "Collection not found"
+// @Failure 500 {object} APIError "Server error"
+// @Router /collections/{id}/recipes/{recipe_id} [delete]
+func (h *CollectionHandler) RemoveRecipeFromCollection(c *gin.Context) {
+	collectionID, ok := parseCollectionID(c)
+	if !ok {
+		return
+	}
+	recipeID, err := uuid.Parse(c.Param("recipe_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid recipe ID format: "+err.Error())
+		return
+	}
+
+	if err := h.store.RemoveRecipeFromCollection(c.Request.Context(), collectionID, recipeID); err != nil {
+		respondWithCollectionError(c, "Failed to remove recipe: ", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ReorderCollectionRecipes handles changing the order of a collection's recipes.
+// @Summary Reorder a collection's recipes
+// @Description Set the order of a collection's recipes. recipe_ids must list every recipe of the collection exactly once.
+// @Tags collections
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID (UUID)"
+// @Param order body models.CollectionOrderRequest true "Recipe IDs in the desired order"
+// @Success 200 {array} models.CollectionRecipe
+// @Failure 400 {object} APIError "Invalid ID or input"
+// @Failure 404 {object} APIError "Collection not found"
+// @Failure 409 {object} APIError "The IDs do not match the collection's current recipes"
+// @Failure 500 {object} APIError "Server error"
+// @Router /collections/{id}/recipes/order [put]
+func (h *CollectionHandler) ReorderCollectionRecipes(c *gin.Context) {
+	collectionID, ok := parseCollectionID(c)
+	if !ok {
+		return
+	}
+
+	var req models.CollectionOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPayload, "Invalid request payload: "+err.Error())
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		RespondWithDetailedError(c, http.StatusBadRequest, ErrCodeValidationFailed, "Validation failed", formatValidationErrors(err))
+		return
+	}
+
+	recipes, err := h.store.ReorderCollectionRecipes(c.Request.Context(), collectionID, req.RecipeIDs)
+	if err != nil {
+		if errors.Is(err, store.ErrOrderMismatch) {
+			RespondWithError(c, http.StatusConflict, ErrCodeConflict, "Failed to reorder recipes: "+err.Error())
+		} else {
+			respondWithCollectionError(c, "Failed to reorder recipes: ", err)
+		}
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, recipes)
+}
+
+// parseCollectionID parses the :id path parameter, responding with 400 and returning false if it is not a UUID.
+func parseCollectionID(c *gin.Context) (uuid.UUID, bool) {
+	collectionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid collection ID format: "+err.Error())
+		return uuid.Nil, false
+	}
+	return collectionID, true
+}
+
+// respondWithCollectionError responds with 404 if the collection was not found, and 500 otherwise.
+func respondWithCollectionError(c *gin.Context, message string, err error) {
+	if strings.Contains(err.Error(), "not found") { // Basic check
+		RespondWithError(c, http.StatusNotFound, ErrCodeCollectionNotFound, "Collection not found: "+err.Error())
+	} else {
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, message+err.Error())
+	}
+}
@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store"
+	"github.com/gaanon/gorecipes_v2/store/mocks"
+)
+
+// Helper function to create a new Gin engine for collection handler tests
+func setupCollectionTestRouter(handler *CollectionHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	api := router.Group("/api/v1")
+	{
+		api.POST("/collections", handler.CreateCollection)
+		api.GET("/collections/:id", handler.GetCollection)
+		api.GET("/collections/:id/recipes", handler.ListCollectionRecipes)
+		api.POST("/collections/:id/recipes", handler.AddRecipeToCollection)
+		api.PUT("/collections/:id/recipes/order", handler.ReorderCollectionRecipes)
+		api.DELETE("/collections/:id/recipes/:recipe_id", handler.RemoveRecipeFromCollection)
+	}
+	return router
+}
+
+func serveCollectionRequest(router *gin.Engine, method, path string, body interface{}) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&buf).Encode(body)
+	}
+	req, _ := http.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCollectionHandler_CreateCollection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockCollectionStore(ctrl)
+	router := setupCollectionTestRouter(NewCollectionHandler(mockStore))
+
+	collectionID := uuid.New()
+	mockStore.EXPECT().CreateCollection(gomock.Any(), &models.CollectionRequest{Name: "Weeknight dinners", IsPublic: true}).
+		Return(&models.Collection{ID: collectionID, Name: "Weeknight dinners", IsPublic: true}, nil).Times(1)
+
+	w := serveCollectionRequest(router, http.MethodPost, "/api/v1/collections", models.CollectionRequest{Name: "  Weeknight dinners ", IsPublic: true})
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/api/v1/collections/"+collectionID.String(), w.Header().Get("Location"))
+	var collection models.Collection
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &collection))
+	assert.Equal(t, collectionID, collection.ID)
+
+	w = serveCollectionRequest(router, http.MethodPost, "/api/v1/collections", models.CollectionRequest{Name: "   "})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "Name")
+}
+
+func TestCollectionHandler_GetCollection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockCollectionStore(ctrl)
+	router := setupCollectionTestRouter(NewCollectionHandler(mockStore))
+
+	collectionID := uuid.New()
+	mockStore.EXPECT().GetCollection(gomock.Any(), collectionID).Return(&models.Collection{ID: collectionID, Name: "Baking", RecipeCount: 3}, nil)
+	w := serveCollectionRequest(router, http.MethodGet, "/api/v1/collections/"+collectionID.String(), nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"recipe_count":3`)
+
+	missingID := uuid.New()
+	mockStore.EXPECT().GetCollection(gomock.Any(), missingID).Return(nil, fmt.Errorf("collection with ID %s not found", missingID))
+	w = serveCollectionRequest(router, http.MethodGet, "/api/v1/collections/"+missingID.String(), nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), ErrCodeCollectionNotFound)
+
+	assert.Equal(t, http.StatusBadRequest, serveCollectionRequest(router, http.MethodGet, "/api/v1/collections/not-a-uuid", nil).Code)
+}
+
+func TestCollectionHandler_ListCollectionRecipes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockCollectionStore(ctrl)
+	router := setupCollectionTestRouter(NewCollectionHandler(mockStore))
+
+	collectionID := uuid.New()
+	recipes := []models.CollectionRecipe{
+		{Recipe: models.Recipe{ID: uuid.New(), Title: "Bread"}, SortOrder: 0},
+		{Recipe: models.Recipe{ID: uuid.New(), Title: "Scones"}, SortOrder: 1},
+	}
+	mockStore.EXPECT().ListCollectionRecipes(gomock.Any(), collectionID).Return(recipes, nil)
+
+	w := serveCollectionRequest(router, http.MethodGet, "/api/v1/collections/"+collectionID.String()+"/recipes", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var listed []models.CollectionRecipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &listed))
+	assert.Len(t, listed, 2)
+	assert.Equal(t, "Scones", listed[1].Title)
+	assert.Equal(t, 1, listed[1].SortOrder)
+}
+
+func TestCollectionHandler_AddRecipeToCollection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockCollectionStore(ctrl)
+	router := setupCollectionTestRouter(NewCollectionHandler(mockStore))
+	collectionID := uuid.New()
+	path := "/api/v1/collections/" + collectionID.String() + "/recipes"
+
+	recipeID := uuid.New()
+	position := 0
+	mockStore.EXPECT().AddRecipeToCollection(gomock.Any(), collectionID, recipeID, &position).
+		Return([]models.CollectionRecipe{{Recipe: models.Recipe{ID: recipeID}}}, nil)
+	w := serveCollectionRequest(router, http.MethodPost, path, models.CollectionRecipeRequest{RecipeID: recipeID, SortOrder: &position})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	unknownID := uuid.New()
+	mockStore.EXPECT().AddRecipeToCollection(gomock.Any(), collectionID, unknownID, nil).
+		Return(nil, fmt.Errorf("%w: recipe with ID %s does not exist", store.ErrUnknownRecipe, unknownID))
+	w = serveCollectionRequest(router, http.MethodPost, path, models.CollectionRecipeRequest{RecipeID: unknownID})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrCodeUnknownRecipe)
+
+	mockStore.EXPECT().AddRecipeToCollection(gomock.Any(), collectionID, recipeID, nil).
+		Return(nil, fmt.Errorf("%w: recipe %s is already in collection %s", store.ErrConflict, recipeID, collectionID))
+	assert.Equal(t, http.StatusConflict, serveCollectionRequest(router, http.MethodPost, path, models.CollectionRecipeRequest{RecipeID: recipeID}).Code)
+
+	mockStore.EXPECT().AddRecipeToCollection(gomock.Any(), collectionID, recipeID, nil).
+		Return(nil, fmt.Errorf("collection with ID %s not found", collectionID))
+	assert.Equal(t, http.StatusNotFound, serveCollectionRequest(router, http.MethodPost, path, models.CollectionRecipeRequest{RecipeID: recipeID}).Code)
+
+	negative := -1
+	assert.Equal(t, http.StatusBadRequest, serveCollectionRequest(router, http.MethodPost, path, models.CollectionRecipeRequest{RecipeID: recipeID, SortOrder: &negative}).Code)
+	assert.Equal(t, http.StatusBadRequest, serveCollectionRequest(router, http.MethodPost, path, models.CollectionRecipeRequest{}).Code)
+}
+
+func TestCollectionHandler_RemoveRecipeFromCollection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockCollectionStore(ctrl)
+	router := setupCollectionTestRouter(NewCollectionHandler(mockStore))
+
+	collectionID, recipeID := uuid.New(), uuid.New()
+	mockStore.EXPECT().RemoveRecipeFromCollection(gomock.Any(), collectionID, recipeID).Return(nil)
+	w := serveCollectionRequest(router, http.MethodDelete, "/api/v1/collections/"+collectionID.String()+"/recipes/"+recipeID.String(), nil)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	mockStore.EXPECT().RemoveRecipeFromCollection(gomock.Any(), collectionID, recipeID).Return(errors.New("connection refused"))
+	w = serveCollectionRequest(router, http.MethodDelete, "/api/v1/collections/"+collectionID.String()+"/recipes/"+recipeID.String(), nil)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	w = serveCollectionRequest(router, http.MethodDelete, "/api/v1/collections/"+collectionID.String()+"/recipes/not-a-uuid", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCollectionHandler_ReorderCollectionRecipes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockCollectionStore(ctrl)
+	router := setupCollectionTestRouter(NewCollectionHandler(mockStore))
+	collectionID := uuid.New()
+	path := "/api/v1/collections/" + collectionID.String() + "/recipes/order"
+
+	first, second := uuid.New(), uuid.New()
+	mockStore.EXPECT().ReorderCollectionRecipes(gomock.Any(), collectionID, []uuid.UUID{second, first}).
+		Return([]models.CollectionRecipe{{Recipe: models.Recipe{ID: second}}, {Recipe: models.Recipe{ID: first}, SortOrder: 1}}, nil)
+	w := serveCollectionRequest(router, http.MethodPut, path, models.CollectionOrderRequest{RecipeIDs: []uuid.UUID{second, first}})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	mockStore.EXPECT().ReorderCollectionRecipes(gomock.Any(), collectionID, []uuid.UUID{first}).
+		Return(nil, fmt.Errorf("%w: collection %s has 2 recipes, 1 of the 1 listed matched", store.ErrOrderMismatch, collectionID))
+	w = serveCollectionRequest(router, http.MethodPut, path, models.CollectionOrderRequest{RecipeIDs: []uuid.UUID{first}})
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = serveCollectionRequest(router, http.MethodPut, path, models.CollectionOrderRequest{RecipeIDs: []uuid.UUID{first, first}})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// checkNotModified sets the Last-Modified header and answers 304 Not Modified when the client's
+// If-Modified-Since is not older than lastModified. It returns true when the response has been written.
+// HTTP dates have one-second precision, so lastModified is truncated before comparing.
+func checkNotModified(c *gin.Context, lastModified time.Time) bool {
+	lastModified = lastModified.UTC().Truncate(time.Second)
+	c.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+
+	ims := c.GetHeader("If-Modified-Since")
+	if ims == "" {
+		return false
+	}
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		return false // Unparseable dates are ignored, as RFC 9110 requires
+	}
+	if lastModified.After(since) {
+		return false
+	}
+	c.Status(http.StatusNotModified)
+	return true
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gaanon/gorecipes_v2/config"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	corsAllowMethods = "GET, POST, PUT, PATCH, DELETE, OPTIONS"
+	corsAllowHeaders = "Accept, Accept-Language, Authorization, Content-Type, If-Match, If-Modified-Since, If-None-Match, X-Request-ID"
+)
+
+// APIContentSecurityPolicy is sent with every /api/v1 response. The API only returns data, never pages,
+// so nothing may be loaded from or frame a response.
+const APIContentSecurityPolicy = "default-src 'none'; frame-ancestors 'none'"
+
+// CORSMiddleware applies cfg to requests whose path starts with pathPrefix and passes other requests through.
+// Preflight (OPTIONS) requests are answered here: 204 with the allowed methods and headers, and the max age,
+// for an allowed origin, 403 otherwise. Other responses to an allowed origin list cfg.ExposedHeaders.
+// It must be registered on the router, not a group, because preflights match no route.
+func CORSMiddleware(pathPrefix string, cfg config.CORSConfig) gin.HandlerFunc {
+	allowAny := slices.Contains(cfg.AllowedOrigins, "*")
+	exposeHeaders := strings.Join(cfg.ExposedHeaders, ", ")
+	maxAge := ""
+	if seconds := int(cfg.MaxAge.Seconds()); seconds > 0 {
+		maxAge = strconv.Itoa(seconds)
+	}
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" || !strings.HasPrefix(c.Request.URL.Path, pathPrefix) {
+			c.Next()
+			return
+		}
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+
+		c.Writer.Header().Add("Vary", "Origin")
+		if !allowAny && !slices.Contains(cfg.AllowedOrigins, origin) {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next() // The browser blocks the response without Access-Control-Allow-Origin
+			return
+		}
+
+		if allowAny {
+			c.Header("Access-Control-Allow-Origin", "*")
+		} else {
+			c.Header("Access-Control-Allow-Origin", origin)
+		}
+		if preflight {
+			c.Header("Access-Control-Allow-Methods", corsAllowMethods)
+			c.Header("Access-Control-Allow-Headers", corsAllowHeaders)
+			if maxAge != "" {
+				c.Header("Access-Control-Max-Age", maxAge)
+			}
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		if exposeHeaders != "" {
+			c.Header("Access-Control-Expose-Headers", exposeHeaders)
+		}
+		c.Next()
+	}
+}
+
+// ContentSecurityPolicyMiddleware sets the Content-Security-Policy header on responses to paths under pathPrefix.
+func ContentSecurityPolicyMiddleware(pathPrefix, policy string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if strings.HasPrefix(c.Request.URL.Path, pathPrefix) {
+			c.Header("Content-Security-Policy", policy)
+		}
+		c.Next()
+	}
+}
+
+// SwaggerUIContentSecurityPolicy returns a policy under which the Swagger UI page works: its scripts and styles
+// are served from this origin but it also uses inline ones, its icons are data: URIs, and it fetches the spec
+// from this origin. frameAncestors lists who may embed the page; empty means nobody.
+func SwaggerUIContentSecurityPolicy(frameAncestors []string) string {
+	ancestors := "'none'"
+	if len(frameAncestors) > 0 {
+		ancestors = strings.Join(frameAncestors, " ")
+	}
+	return "default-src 'self'; script-src 'self' 'unsafe-inline'; style-src 'self' 'unsafe-inline'; " +
+		"img-src 'self' data:; connect-src 'self'; frame-ancestors " + ancestors
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gaanon/gorecipes_v2/config"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(
+		CORSMiddleware("/api/v1", config.CORSConfig{
+			AllowedOrigins: []string{"https://app.example.com"},
+			MaxAge:         10 * time.Minute,
+			ExposedHeaders: []string{"Location", "X-Request-ID"},
+		}),
+		CORSMiddleware("/swagger", config.CORSConfig{AllowedOrigins: []string{"*"}}),
+	)
+	router.GET("/api/v1/recipes", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/swagger/*any", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	serve := func(method, path, origin string, preflight bool) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, nil)
+		req.Header.Set("Origin", origin)
+		if preflight {
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve(http.MethodGet, "/api/v1/recipes", "https://app.example.com", false)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Origin", w.Header().Get("Vary"))
+	assert.Equal(t, "Location, X-Request-ID", w.Header().Get("Access-Control-Expose-Headers"))
+
+	// No OPTIONS route is registered; the middleware still answers the preflight.
+	w = serve(http.MethodOptions, "/api/v1/recipes", "https://app.example.com", true)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Contains(t, w.Header().Get("Access-Control-Allow-Methods"), "PUT")
+	assert.Contains(t, w.Header().Get("Access-Control-Allow-Headers"), "Authorization")
+	assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"))
+
+	w = serve(http.MethodGet, "/api/v1/recipes", "https://evil.example.com", false)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, w.Header().Get("Access-Control-Expose-Headers"))
+	w = serve(http.MethodOptions, "/api/v1/recipes", "https://evil.example.com", true)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	// The docs have their own, looser policy.
+	w = serve(http.MethodGet, "/swagger/index.html", "https://evil.example.com", false)
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, w.Header().Get("Access-Control-Expose-Headers"))
+}
+
+func TestContentSecurityPolicyMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(
+		ContentSecurityPolicyMiddleware("/api/v1", APIContentSecurityPolicy),
+		ContentSecurityPolicyMiddleware("/swagger", SwaggerUIContentSecurityPolicy([]string{"'self'", "https://wiki.example.com"})),
+	)
+	router.GET("/api/v1/recipes", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/swagger/*any", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/ping", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	csp := func(path string) string {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Header().Get("Content-Security-Policy")
+	}
+	assert.Equal(t, APIContentSecurityPolicy, csp("/api/v1/recipes"))
+	assert.Contains(t, csp("/swagger/index.html"), "script-src 'self' 'unsafe-inline'")
+	assert.Contains(t, csp("/swagger/index.html"), "frame-ancestors 'self' https://wiki.example.com")
+	assert.Empty(t, csp("/ping"))
+
+	assert.Contains(t, SwaggerUIContentSecurityPolicy(nil), "frame-ancestors 'none'")
+}
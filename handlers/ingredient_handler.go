@@ -0,0 +1,366 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gaanon/gorecipes_v2/config"
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// IngredientHandler handles HTTP requests for ingredients.
+type IngredientHandler struct {
+	store store.IngredientStore
+	cfg   config.APIConfig
+}
+
+// NewIngredientHandler creates a new IngredientHandler.
+func NewIngredientHandler(store store.IngredientStore, cfg config.APIConfig) *IngredientHandler {
+	return &IngredientHandler{store: store, cfg: cfg}
+}
+
+// UpdateIngredient handles renaming an ingredient globally.
+// @Summary Update an ingredient
+// @Description Rename an ingredient and/or change its category. The change applies to every recipe using it.
+// @Tags ingredients
+// @Accept json
+// @Produce json
+// @Param id path string true "Ingredient ID (UUID)"
+// @Param ingredient body models.IngredientRequest true "Ingredient data to update"
+// @Success 200 {object} models.Ingredient
+// @Failure 400 {object} APIError "Invalid input or ID format"
+// @Failure 404 {object} APIError "Ingredient not found"
+// @Failure 409 {object} APIError "Another ingredient already has this name"
+// @Failure 500 {object} APIError "Server error"
+// @Router /ingredients/{id} [put]
+func (h *IngredientHandler) UpdateIngredient(c *gin.Context) {
+	idStr := c.Param("id")
+	ingredientID, err := uuid.Parse(idStr)
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid ingredient ID format: "+err.Error())
+		return
+	}
+
+	var req models.IngredientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPayload, "Invalid request payload: "+err.Error())
+		return
+	}
+
+	validationErrors := map[string]string{}
+	if err := validate.Struct(req); err != nil {
+		validationErrors = formatValidationErrors(err)
+	}
+	if limit := h.cfg.MaxNameLength; limit > 0 && utf8.RuneCountInString(req.Name) > limit {
+		validationErrors["Name"] = fmt.Sprintf("must be at most %d characters", limit)
+	}
+	if len(validationErrors) > 0 {
+		RespondWithDetailedError(c, http.StatusBadRequest, ErrCodeValidationFailed, "Validation failed", validationErrors)
+		return
+	}
+
+	ingredient, err := h.store.UpdateIngredient(c.Request.Context(), ingredientID, &req)
+	if err != nil {
+		if errors.Is(err, store.ErrConflict) {
+			RespondWithError(c, http.StatusConflict, ErrCodeConflict, "Ingredient name already in use: "+err.Error())
+		} else if strings.Contains(err.Error(), "not found") { // Basic check
+			RespondWithError(c, http.StatusNotFound, ErrCodeIngredientNotFound, "Ingredient not found for update: "+err.Error())
+		} else {
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to update ingredient: "+err.Error())
+		}
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, ingredient)
+}
+
+// GetIngredientStats handles reporting how an ingredient is used across recipes.
+// @Summary Get ingredient usage statistics
+// @Description Get how many recipes use an ingredient and, for each unit it is used with, how often and the average quantity.
+// @Description Averages are per unit, since quantities in different units cannot be compared directly.
+// @Tags ingredients
+// @Produce json
+// @Param id path string true "Ingredient ID (UUID)"
+// @Success 200 {object} models.IngredientStats
+// @Failure 400 {object} APIError "Invalid ID format"
+// @Failure 404 {object} APIError "Ingredient not found"
+// @Failure 500 {object} APIError "Server error"
+// @Router /ingredients/{id}/stats [get]
+func (h *IngredientHandler) GetIngredientStats(c *gin.Context) {
+	ingredientID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid ingredient ID format: "+err.Error())
+		return
+	}
+
+	stats, err := h.store.IngredientStats(c.Request.Context(), ingredientID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") { // Basic check
+			RespondWithError(c, http.StatusNotFound, ErrCodeIngredientNotFound, "Ingredient not found: "+err.Error())
+		} else {
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to get ingredient stats: "+err.Error())
+		}
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, stats)
+}
+
+// SetIngredientSeasons handles setting the months an ingredient is in season.
+// @Summary Set an ingredient's seasons
+// @Description Set the months (1-12) an ingredient is in season, used by GET /recipes?in_season=true. An empty list marks it available year-round.
+// @Tags ingredients
+// @Accept json
+// @Produce json
+// @Param id path string true "Ingredient ID (UUID)"
+// @Param seasons body models.IngredientSeasonsRequest true "Months the ingredient is in season"
+// @Success 200 {object} models.Ingredient
+// @Failure 400 {object} APIError "Invalid input or ID format"
+// @Failure 404 {object} APIError "Ingredient not found"
+// @Failure 500 {object} APIError "Server error"
+// @Router /ingredients/{id}/seasons [put]
+func (h *IngredientHandler) SetIngredientSeasons(c *gin.Context) {
+	ingredientID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid ingredient ID format: "+err.Error())
+		return
+	}
+
+	var req models.IngredientSeasonsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPayload, "Invalid request payload: "+err.Error())
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		RespondWithDetailedError(c, http.StatusBadRequest, ErrCodeValidationFailed, "Validation failed", formatValidationErrors(err))
+		return
+	}
+
+	ingredient, err := h.store.SetIngredientSeasons(c.Request.Context(), ingredientID, req.Seasons)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") { // Basic check
+			RespondWithError(c, http.StatusNotFound, ErrCodeIngredientNotFound, "Ingredient not found: "+err.Error())
+		} else {
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to set ingredient seasons: "+err.Error())
+		}
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, ingredient)
+}
+
+// SetIngredientPrice handles setting the price used for recipe cost estimates.
+// @Summary Set an ingredient's price
+// @Description Set the price of one price_unit_id of an ingredient, e.g. 2.40 per kilogram, used by GET /recipes/{id}/cost.
+// @Description Omit price_unit_id to price per item (e.g. per egg); a null unit_price clears the price.
+// @Tags ingredients
+// @Accept json
+// @Produce json
+// @Param id path string true "Ingredient ID (UUID)"
+// @Param price body models.IngredientPriceRequest true "Price of the ingredient"
+// @Success 200 {object} models.Ingredient
+// @Failure 400 {object} APIError "Invalid input, ID format or unit"
+// @Failure 404 {object} APIError "Ingredient not found"
+// @Failure 500 {object} APIError "Server error"
+// @Router /ingredients/{id}/price [put]
+func (h *IngredientHandler) SetIngredientPrice(c *gin.Context) {
+	ingredientID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid ingredient ID format: "+err.Error())
+		return
+	}
+
+	var req models.IngredientPriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPayload, "Invalid request payload: "+err.Error())
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		RespondWithDetailedError(c, http.StatusBadRequest, ErrCodeValidationFailed, "Validation failed", formatValidationErrors(err))
+		return
+	}
+
+	ingredient, err := h.store.SetIngredientPrice(c.Request.Context(), ingredientID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrUnknownUnit):
+			RespondWithError(c, http.StatusBadRequest, ErrCodeUnknownUnit, err.Error())
+		case strings.Contains(err.Error(), "not found"): // Basic check
+			RespondWithError(c, http.StatusNotFound, ErrCodeIngredientNotFound, "Ingredient not found: "+err.Error())
+		default:
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to set ingredient price: "+err.Error())
+		}
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, ingredient)
+}
+
+// patchableIngredientFields are the JSON keys PATCH /ingredients/{id} accepts, in the order they are applied.
+var patchableIngredientFields = []string{"category", "unit_price", "price_unit_id"}
+
+// PatchIngredient handles filling in some of an ingredient's details.
+// @Summary Update some of an ingredient's details
+// @Description Change only the fields present in the body: category, unit_price and price_unit_id; null clears a field.
+// @Description Meant for enriching ingredients created on the fly by recipes (see GET /ingredients?uncategorized=true).
+// @Description Clearing unit_price also clears price_unit_id. Use PUT /ingredients/{id} to rename.
+// @Tags ingredients
+// @Accept json
+// @Produce json
+// @Param id path string true "Ingredient ID (UUID)"
+// @Param ingredient body models.IngredientPatchRequest true "Fields to change"
+// @Success 200 {object} models.Ingredient
+// @Failure 400 {object} APIError "Invalid input, ID format or unit"
+// @Failure 404 {object} APIError "Ingredient not found"
+// @Failure 500 {object} APIError "Server error"
+// @Router /ingredients/{id} [patch]
+func (h *IngredientHandler) PatchIngredient(c *gin.Context) {
+	ingredientID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid ingredient ID format: "+err.Error())
+		return
+	}
+
+	// The body is decoded twice: into the request, and into its keys to tell a missing field from a null one.
+	var req models.IngredientPatchRequest
+	var present map[string]json.RawMessage
+	body, err := c.GetRawData()
+	if err == nil {
+		err = json.Unmarshal(body, &req)
+	}
+	if err == nil {
+		err = json.Unmarshal(body, &present)
+	}
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPayload, "Invalid request payload: "+err.Error())
+		return
+	}
+	trimStrings(&req)
+	validationErrors := map[string]string{}
+	if err := validate.Struct(req); err != nil {
+		validationErrors = formatValidationErrors(err)
+	}
+	for key := range present {
+		if !slices.Contains(patchableIngredientFields, key) {
+			validationErrors[key] = "cannot be patched; use " + strings.Join(patchableIngredientFields, ", ")
+		}
+	}
+	if _, ok := present["unit_price"]; ok && req.UnitPrice == nil {
+		if req.PriceUnitID != nil {
+			validationErrors["PriceUnitID"] = "must be null when unit_price is cleared"
+		}
+		present["price_unit_id"] = nil // A unit without a price means nothing
+	}
+	for _, field := range patchableIngredientFields {
+		if _, ok := present[field]; ok {
+			req.Fields = append(req.Fields, field)
+		}
+	}
+	if len(req.Fields) == 0 && len(validationErrors) == 0 {
+		validationErrors["Fields"] = "at least one of " + strings.Join(patchableIngredientFields, ", ") + " is required"
+	}
+	if len(validationErrors) > 0 {
+		RespondWithDetailedError(c, http.StatusBadRequest, ErrCodeValidationFailed, "Validation failed", validationErrors)
+		return
+	}
+
+	ingredient, err := h.store.PatchIngredient(c.Request.Context(), ingredientID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrUnknownUnit):
+			RespondWithError(c, http.StatusBadRequest, ErrCodeUnknownUnit, err.Error())
+		case strings.Contains(err.Error(), "not found"): // Basic check
+			RespondWithError(c, http.StatusNotFound, ErrCodeIngredientNotFound, "Ingredient not found: "+err.Error())
+		default:
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to update ingredient: "+err.Error())
+		}
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, ingredient)
+}
+
+const (
+	defaultIngredientPageSize = 50
+	maxIngredientPageSize     = 200 // Larger page sizes are clamped
+)
+
+// ListIngredients handles listing ingredients.
+// @Summary List ingredients
+// @Description List ingredients by name, a page at a time. With uncategorized=true, only those without a category,
+// @Description such as ingredients created on the fly by recipes, so they can be filled in with PATCH /ingredients/{id}.
+// @Tags ingredients
+// @Produce json
+// @Param uncategorized query bool false "Only ingredients without a category"
+// @Param page query int false "Page number (default 1)"
+// @Param page_size query int false "Number of ingredients per page (default 50, at most 200)"
+// @Success 200 {array} models.Ingredient
+// @Failure 400 {object} APIError "Invalid query parameters"
+// @Failure 500 {object} APIError "Server error"
+// @Router /ingredients [get]
+func (h *IngredientHandler) ListIngredients(c *gin.Context) {
+	uncategorized, err := strconv.ParseBool(c.DefaultQuery("uncategorized", "false"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: uncategorized must be a boolean")
+		return
+	}
+	page, err := parsePositiveIntQuery(c, "page", 1)
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: "+err.Error())
+		return
+	}
+	pageSize, err := parsePositiveIntQuery(c, "page_size", defaultIngredientPageSize)
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: "+err.Error())
+		return
+	}
+	pageSize = min(pageSize, maxIngredientPageSize)
+
+	ingredients, err := h.store.ListIngredients(c.Request.Context(), models.IngredientListParams{
+		Limit:         pageSize,
+		Offset:        (page - 1) * pageSize,
+		Uncategorized: uncategorized,
+	})
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to list ingredients: "+err.Error())
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, ingredients)
+}
+
+const (
+	defaultPopularIngredientsLimit = 20
+	maxPopularIngredientsLimit     = 100 // Larger limits are clamped
+)
+
+// PopularIngredients handles listing the most used ingredients.
+// @Summary List popular ingredients
+// @Description List the ingredients used by the most recipes, with their recipe counts, most used first.
+// @Tags ingredients
+// @Produce json
+// @Param limit query int false "Number of ingredients to return (default 20, at most 100)"
+// @Success 200 {array} models.IngredientCount
+// @Failure 400 {object} APIError "Invalid limit"
+// @Failure 500 {object} APIError "Server error"
+// @Router /ingredients/popular [get]
+func (h *IngredientHandler) PopularIngredients(c *gin.Context) {
+	limit, err := parsePositiveIntQuery(c, "limit", defaultPopularIngredientsLimit)
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: "+err.Error())
+		return
+	}
+	if limit > maxPopularIngredientsLimit {
+		limit = maxPopularIngredientsLimit
+	}
+
+	ingredients, err := h.store.PopularIngredients(c.Request.Context(), limit)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to list popular ingredients: "+err.Error())
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, ingredients)
+}
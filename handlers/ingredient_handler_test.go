@@ -0,0 +1,374 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gaanon/gorecipes_v2/config"
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store"
+	"github.com/gaanon/gorecipes_v2/store/mocks"
+)
+
+// Helper function to create a new Gin engine for ingredient handler tests
+func setupIngredientTestRouter(handler *IngredientHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	api := router.Group("/api/v1")
+	{
+		api.GET("/ingredients", handler.ListIngredients)
+		api.PUT("/ingredients/:id", handler.UpdateIngredient)
+		api.PATCH("/ingredients/:id", handler.PatchIngredient)
+		api.GET("/ingredients/:id/stats", handler.GetIngredientStats)
+		api.GET("/ingredients/popular", handler.PopularIngredients)
+		api.POST("/ingredients/parse", handler.ParseIngredients)
+		api.PUT("/ingredients/:id/seasons", handler.SetIngredientSeasons)
+		api.PUT("/ingredients/:id/price", handler.SetIngredientPrice)
+	}
+	return router
+}
+
+func TestIngredientHandler_UpdateIngredient_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockIngredientStore(ctrl)
+	router := setupIngredientTestRouter(NewIngredientHandler(mockStore, config.APIConfig{}))
+
+	ingredientID := uuid.New()
+	ingredientReq := &models.IngredientRequest{Name: "tomato", Category: strPtr("vegetables")}
+	expected := &models.Ingredient{ID: ingredientID, Name: "tomato", Category: strPtr("vegetables")}
+
+	mockStore.EXPECT().UpdateIngredient(gomock.Any(), ingredientID, ingredientReq).Return(expected, nil).Times(1)
+
+	jsonBody, _ := json.Marshal(ingredientReq)
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/ingredients/"+ingredientID.String(), bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.Ingredient
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "tomato", response.Name)
+}
+
+func TestIngredientHandler_UpdateIngredient_Conflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockIngredientStore(ctrl)
+	router := setupIngredientTestRouter(NewIngredientHandler(mockStore, config.APIConfig{}))
+
+	ingredientID := uuid.New()
+	ingredientReq := &models.IngredientRequest{Name: "tomato"}
+
+	mockStore.EXPECT().UpdateIngredient(gomock.Any(), ingredientID, ingredientReq).
+		Return(nil, fmt.Errorf("%w: ingredient \"tomato\" already exists", store.ErrConflict)).Times(1)
+
+	jsonBody, _ := json.Marshal(ingredientReq)
+	req, _ := http.NewRequest(http.MethodPut, "/api/v1/ingredients/"+ingredientID.String(), bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var errorResponse APIError
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrCodeConflict, errorResponse.Code)
+}
+
+func TestIngredientHandler_UpdateIngredient_MaxNameLength(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockIngredientStore(ctrl)
+	router := setupIngredientTestRouter(NewIngredientHandler(mockStore, config.APIConfig{MaxNameLength: 5}))
+
+	ingredientID := uuid.New()
+	ingredientReq := &models.IngredientRequest{Name: "crème"} // 5 characters, 6 bytes
+	mockStore.EXPECT().UpdateIngredient(gomock.Any(), ingredientID, ingredientReq).
+		Return(&models.Ingredient{ID: ingredientID, Name: "crème"}, nil).Times(1)
+
+	put := func(req *models.IngredientRequest) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(req)
+		httpReq, _ := http.NewRequest(http.MethodPut, "/api/v1/ingredients/"+ingredientID.String(), bytes.NewBuffer(jsonBody))
+		httpReq.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httpReq)
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, put(ingredientReq).Code)
+
+	w := put(&models.IngredientRequest{Name: "tomato"})
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var errorResponse APIError
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+	assert.Equal(t, ErrCodeValidationFailed, errorResponse.Code)
+	assert.Equal(t, map[string]interface{}{"Name": "must be at most 5 characters"}, errorResponse.Details)
+}
+
+func TestIngredientHandler_GetIngredientStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockIngredientStore(ctrl)
+	router := setupIngredientTestRouter(NewIngredientHandler(mockStore, config.APIConfig{}))
+
+	ingredientID, missingID, gramID := uuid.New(), uuid.New(), uuid.New()
+	mockStore.EXPECT().IngredientStats(gomock.Any(), ingredientID).Return(&models.IngredientStats{
+		IngredientID: ingredientID, IngredientName: "flour", RecipeCount: 3,
+		Units: []models.IngredientUnitUsage{
+			{Unit: &models.MeasurementUnit{ID: &gramID, Name: strPtr("gram")}, RecipeCount: 2, AverageQuantity: float64Ptr(375)},
+			{RecipeCount: 1},
+		},
+	}, nil).Times(1)
+	mockStore.EXPECT().IngredientStats(gomock.Any(), missingID).Return(nil, fmt.Errorf("ingredient with ID %s not found", missingID)).Times(1)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/ingredients/"+ingredientID.String()+"/stats", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var stats models.IngredientStats
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	assert.Equal(t, 3, stats.RecipeCount)
+	if assert.Len(t, stats.Units, 2) {
+		assert.Equal(t, 375.0, *stats.Units[0].AverageQuantity)
+		assert.Nil(t, stats.Units[1].Unit)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/ingredients/"+missingID.String()+"/stats", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/ingredients/not-a-uuid/stats", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestIngredientHandler_PopularIngredients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockIngredientStore(ctrl)
+	router := setupIngredientTestRouter(NewIngredientHandler(mockStore, config.APIConfig{}))
+
+	ingredients := []models.IngredientCount{{ID: uuid.New(), Name: "salt", Count: 40}, {ID: uuid.New(), Name: "flour", Category: strPtr("baking"), Count: 18}}
+	gomock.InOrder(
+		mockStore.EXPECT().PopularIngredients(gomock.Any(), defaultPopularIngredientsLimit).Return(ingredients, nil),
+		mockStore.EXPECT().PopularIngredients(gomock.Any(), 5).Return(ingredients, nil),
+		mockStore.EXPECT().PopularIngredients(gomock.Any(), maxPopularIngredientsLimit).Return(ingredients, nil),
+	)
+
+	for _, query := range []string{"", "?limit=5", "?limit=1000"} {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/ingredients/popular"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code, query)
+		var response []models.IngredientCount
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, ingredients, response)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/ingredients/popular?limit=0", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestIngredientHandler_SetIngredientSeasons(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockIngredientStore(ctrl)
+	router := setupIngredientTestRouter(NewIngredientHandler(mockStore, config.APIConfig{}))
+
+	put := func(id uuid.UUID, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPut, "/api/v1/ingredients/"+id.String()+"/seasons", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	ingredientID, missingID := uuid.New(), uuid.New()
+	mockStore.EXPECT().SetIngredientSeasons(gomock.Any(), ingredientID, []int{7, 6, 8}).
+		Return(&models.Ingredient{ID: ingredientID, Name: "strawberries", Seasons: []int{6, 7, 8}}, nil).Times(1)
+	mockStore.EXPECT().SetIngredientSeasons(gomock.Any(), missingID, []int{}).
+		Return(nil, fmt.Errorf("ingredient with ID %s not found", missingID)).Times(1)
+
+	w := put(ingredientID, `{"seasons": [7, 6, 8]}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var ingredient models.Ingredient
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &ingredient))
+	assert.Equal(t, []int{6, 7, 8}, ingredient.Seasons)
+
+	assert.Equal(t, http.StatusNotFound, put(missingID, `{"seasons": []}`).Code)
+	assert.Equal(t, http.StatusBadRequest, put(ingredientID, `{"seasons": [0]}`).Code)
+	assert.Equal(t, http.StatusBadRequest, put(ingredientID, `{"seasons": [13]}`).Code)
+	assert.Equal(t, http.StatusBadRequest, put(ingredientID, `{"seasons": [5, 5]}`).Code)
+}
+
+func TestIngredientHandler_SetIngredientPrice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockIngredientStore(ctrl)
+	router := setupIngredientTestRouter(NewIngredientHandler(mockStore, config.APIConfig{}))
+
+	put := func(id uuid.UUID, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPut, "/api/v1/ingredients/"+id.String()+"/price", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	ingredientID, missingID, kilogramID, unknownUnitID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	mockStore.EXPECT().SetIngredientPrice(gomock.Any(), ingredientID, &models.IngredientPriceRequest{UnitPrice: float64Ptr(2.4), PriceUnitID: &kilogramID}).
+		Return(&models.Ingredient{ID: ingredientID, Name: "flour", UnitPrice: float64Ptr(2.4), PriceUnitID: &kilogramID}, nil).Times(1)
+	mockStore.EXPECT().SetIngredientPrice(gomock.Any(), ingredientID, &models.IngredientPriceRequest{UnitPrice: float64Ptr(1), PriceUnitID: &unknownUnitID}).
+		Return(nil, fmt.Errorf("%w: no measurement unit with ID %s", store.ErrUnknownUnit, unknownUnitID)).Times(1)
+	mockStore.EXPECT().SetIngredientPrice(gomock.Any(), missingID, &models.IngredientPriceRequest{}).
+		Return(nil, fmt.Errorf("ingredient with ID %s not found", missingID)).Times(1)
+
+	w := put(ingredientID, `{"unit_price": 2.4, "price_unit_id": "`+kilogramID.String()+`"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var ingredient models.Ingredient
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &ingredient))
+	assert.Equal(t, 2.4, *ingredient.UnitPrice)
+	assert.Equal(t, kilogramID, *ingredient.PriceUnitID)
+
+	w = put(ingredientID, `{"unit_price": 1, "price_unit_id": "`+unknownUnitID.String()+`"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrCodeUnknownUnit)
+
+	assert.Equal(t, http.StatusNotFound, put(missingID, `{"unit_price": null}`).Code)
+	assert.Equal(t, http.StatusBadRequest, put(ingredientID, `{"unit_price": -1}`).Code)
+	assert.Equal(t, http.StatusBadRequest, put(ingredientID, `{"price_unit_id": "`+kilogramID.String()+`"}`).Code) // A unit without a price
+}
+
+func TestIngredientHandler_ParseIngredients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The store is not used: parsing saves nothing.
+	router := setupIngredientTestRouter(NewIngredientHandler(mocks.NewMockIngredientStore(ctrl), config.APIConfig{}))
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/ingredients/parse", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(`["2 cups flour, sifted", "1 ½ tsp salt", "3 eggs", "  "]`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var parsed []models.ParsedIngredient
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &parsed))
+	assert.Equal(t, []models.ParsedIngredient{
+		{Text: "2 cups flour, sifted", Quantity: float64Ptr(2), Unit: strPtr("cups"), Name: "flour", Notes: strPtr("sifted")},
+		{Text: "1 ½ tsp salt", Quantity: float64Ptr(1.5), Unit: strPtr("tsp"), Name: "salt"},
+		{Text: "3 eggs", Quantity: float64Ptr(3), Name: "eggs"},
+		{Text: "  "},
+	}, parsed)
+
+	assert.Equal(t, http.StatusBadRequest, post(`[]`).Code)
+	assert.Equal(t, http.StatusBadRequest, post(`{"lines": ["1 egg"]}`).Code)
+}
+
+func TestIngredientHandler_PatchIngredient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockIngredientStore(ctrl)
+	router := setupIngredientTestRouter(NewIngredientHandler(mockStore, config.APIConfig{}))
+
+	patch := func(id uuid.UUID, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPatch, "/api/v1/ingredients/"+id.String(), bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	ingredientID, missingID := uuid.New(), uuid.New()
+	mockStore.EXPECT().PatchIngredient(gomock.Any(), ingredientID, &models.IngredientPatchRequest{
+		Category: strPtr("baking"), Fields: []string{"category"},
+	}).Return(&models.Ingredient{ID: ingredientID, Name: "flour", Category: strPtr("baking"), UnitPrice: float64Ptr(2)}, nil).Times(1)
+	mockStore.EXPECT().PatchIngredient(gomock.Any(), ingredientID, &models.IngredientPatchRequest{
+		Fields: []string{"category", "unit_price", "price_unit_id"},
+	}).Return(&models.Ingredient{ID: ingredientID, Name: "flour"}, nil).Times(1)
+	mockStore.EXPECT().PatchIngredient(gomock.Any(), missingID, gomock.Any()).
+		Return(nil, fmt.Errorf("ingredient with ID %s not found", missingID)).Times(1)
+
+	w := patch(ingredientID, `{"category": " baking "}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var ingredient models.Ingredient
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &ingredient))
+	assert.Equal(t, "baking", *ingredient.Category)
+
+	// Nulls clear fields; clearing the price clears its unit too.
+	assert.Equal(t, http.StatusOK, patch(ingredientID, `{"category": null, "unit_price": null}`).Code)
+	assert.Equal(t, http.StatusNotFound, patch(missingID, `{"category": "baking"}`).Code)
+
+	assert.Equal(t, http.StatusBadRequest, patch(ingredientID, `{}`).Code)
+	w = patch(ingredientID, `{"name": "plain flour"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "cannot be patched")
+	assert.Equal(t, http.StatusBadRequest, patch(ingredientID, `{"category": ""}`).Code)
+	assert.Equal(t, http.StatusBadRequest, patch(ingredientID, `{"unit_price": -1}`).Code)
+	assert.Equal(t, http.StatusBadRequest, patch(ingredientID, `{"unit_price": null, "price_unit_id": "`+uuid.NewString()+`"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, patch(ingredientID, `[]`).Code)
+}
+
+func TestIngredientHandler_ListIngredients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockIngredientStore(ctrl)
+	router := setupIngredientTestRouter(NewIngredientHandler(mockStore, config.APIConfig{}))
+
+	get := func(query string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/ingredients"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	saffron := models.Ingredient{ID: uuid.New(), Name: "saffron"}
+	mockStore.EXPECT().ListIngredients(gomock.Any(), models.IngredientListParams{Limit: 10, Offset: 20, Uncategorized: true}).
+		Return([]models.Ingredient{saffron}, nil).Times(1)
+	mockStore.EXPECT().ListIngredients(gomock.Any(), models.IngredientListParams{Limit: 200}).
+		Return([]models.Ingredient{}, nil).Times(1)
+
+	w := get("?uncategorized=true&page=3&page_size=10")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var ingredients []models.Ingredient
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &ingredients))
+	assert.Equal(t, []models.Ingredient{saffron}, ingredients)
+
+	w = get("?page_size=1000") // Clamped
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "[]", w.Body.String())
+
+	assert.Equal(t, http.StatusBadRequest, get("?uncategorized=maybe").Code)
+	assert.Equal(t, http.StatusBadRequest, get("?page=0").Code)
+}
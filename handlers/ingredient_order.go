@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ReorderRecipeIngredients handles changing the order of a recipe's ingredients without replacing the recipe.
+// @Summary Reorder recipe ingredients
+// @Description Set the order of a recipe's ingredients. ingredient_ids must list every ingredient of the recipe exactly once.
+// @Tags recipes
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID (UUID)"
+// @Param order body models.IngredientOrderRequest true "Ingredient IDs in the desired order"
+// @Success 200 {array} models.RecipeIngredient
+// @Failure 400 {object} APIError "Invalid ID or input"
+// @Failure 404 {object} APIError "Recipe not found"
+// @Failure 409 {object} APIError "The IDs do not match the recipe's current ingredients"
+// @Failure 500 {object} APIError "Server error"
+// @Router /recipes/{id}/ingredients/order [put]
+func (h *RecipeHandler) ReorderRecipeIngredients(c *gin.Context) {
+	recipeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid recipe ID format: "+err.Error())
+		return
+	}
+
+	var req models.IngredientOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPayload, "Invalid request payload: "+err.Error())
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		RespondWithDetailedError(c, http.StatusBadRequest, ErrCodeValidationFailed, "Validation failed", formatValidationErrors(err))
+		return
+	}
+
+	ingredients, err := h.store.ReorderIngredients(c.Request.Context(), recipeID, req.IngredientIDs)
+	if err != nil {
+		if errors.Is(err, store.ErrOrderMismatch) {
+			RespondWithError(c, http.StatusConflict, ErrCodeConflict, "Failed to reorder ingredients: "+err.Error())
+		} else if strings.Contains(err.Error(), "not found") { // Basic check
+			RespondWithError(c, http.StatusNotFound, ErrCodeRecipeNotFound, "Recipe not found: "+err.Error())
+		} else {
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to reorder ingredients: "+err.Error())
+		}
+		return
+	}
+	if decimals, ok := h.quantityDecimals(); ok {
+		for i := range ingredients {
+			ingredients[i].RoundQuantities(decimals)
+		}
+	}
+	RespondWithJSON(c, http.StatusOK, ingredients)
+}
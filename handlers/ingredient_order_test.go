@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gaanon/gorecipes_v2/config"
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store"
+	"github.com/gaanon/gorecipes_v2/store/mocks"
+)
+
+func TestRecipeHandler_ReorderRecipeIngredients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.PUT("/api/v1/recipes/:id/ingredients/order", NewRecipeHandler(mockStore, config.APIConfig{}).ReorderRecipeIngredients)
+
+	recipeID, missingID := uuid.New(), uuid.New()
+	salt, flour := uuid.New(), uuid.New()
+	mockStore.EXPECT().ReorderIngredients(gomock.Any(), recipeID, []uuid.UUID{salt, flour}).Return([]models.RecipeIngredient{
+		{IngredientID: salt, IngredientName: strPtr("salt"), SortOrder: 0},
+		{IngredientID: flour, IngredientName: strPtr("flour"), Quantity: float64Ptr(1.0 / 3), SortOrder: 1},
+	}, nil).Times(1)
+	mockStore.EXPECT().ReorderIngredients(gomock.Any(), recipeID, []uuid.UUID{salt}).
+		Return(nil, fmt.Errorf("%w: recipe %s has 2 ingredients, 1 of the 1 listed matched", store.ErrOrderMismatch, recipeID)).Times(1)
+	mockStore.EXPECT().ReorderIngredients(gomock.Any(), missingID, []uuid.UUID{salt}).
+		Return(nil, fmt.Errorf("recipe with ID %s not found", missingID)).Times(1)
+
+	put := func(id uuid.UUID, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPut, "/api/v1/recipes/"+id.String()+"/ingredients/order", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := put(recipeID, fmt.Sprintf(`{"ingredient_ids":[%q,%q]}`, salt, flour))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var ingredients []models.RecipeIngredient
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &ingredients))
+	if assert.Len(t, ingredients, 2) {
+		assert.Equal(t, salt, ingredients[0].IngredientID)
+		assert.Equal(t, 0.333, *ingredients[1].Quantity)
+	}
+
+	assert.Equal(t, http.StatusConflict, put(recipeID, fmt.Sprintf(`{"ingredient_ids":[%q]}`, salt)).Code)
+	assert.Equal(t, http.StatusNotFound, put(missingID, fmt.Sprintf(`{"ingredient_ids":[%q]}`, salt)).Code)
+	assert.Equal(t, http.StatusBadRequest, put(recipeID, `{"ingredient_ids":[]}`).Code)
+	assert.Equal(t, http.StatusBadRequest, put(recipeID, fmt.Sprintf(`{"ingredient_ids":[%q,%q]}`, salt, salt)).Code)
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gin-gonic/gin"
+)
+
+// maxParseLines caps how many lines one ParseIngredients call may submit.
+const maxParseLines = 500
+
+// ParseIngredients handles previewing how free-text ingredient lines would be parsed.
+// @Summary Preview ingredient parsing
+// @Description Parse raw ingredient lines such as "2 cups flour, sifted" into quantity, unit, name and notes, as imports do.
+// @Description Nothing is saved. Results are in the order of the submitted lines; a blank line gives an empty name.
+// @Tags ingredients
+// @Accept json
+// @Produce json
+// @Param lines body []string true "Raw ingredient lines"
+// @Success 200 {array} models.ParsedIngredient
+// @Failure 400 {object} APIError "Invalid input"
+// @Router /ingredients/parse [post]
+func (h *IngredientHandler) ParseIngredients(c *gin.Context) {
+	var lines []string
+	if err := c.ShouldBindJSON(&lines); err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPayload, "Invalid request payload: "+err.Error())
+		return
+	}
+	if len(lines) == 0 || len(lines) > maxParseLines {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeValidationFailed, fmt.Sprintf("Between 1 and %d ingredient lines must be given", maxParseLines))
+		return
+	}
+
+	parsed := make([]models.ParsedIngredient, len(lines))
+	for i, line := range lines {
+		parsed[i].Text = line
+		if ing := parseIngredientLine(line); ing != nil {
+			parsed[i].Quantity = ing.Quantity
+			parsed[i].Unit = ing.UnitName
+			parsed[i].Name = ing.IngredientName
+			parsed[i].Notes = ing.Preparation
+		}
+	}
+	RespondWithJSON(c, http.StatusOK, parsed)
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/gaanon/gorecipes_v2/models"
+)
+
+// validationMessages replaces the generic "failed on 'tag' validation" message for custom validation tags.
+var validationMessages = map[string]string{
+	"quantity_with_unit": "unit_name is set but there is no quantity, quantity_text or quantity range",
+}
+
+// newValidator returns the validator shared by all handlers, with the struct-level rules registered.
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterStructValidation(recipeIngredientRequestStructLevel, models.RecipeIngredientRequest{})
+	v.RegisterStructValidation(recipeRequestStructLevel, models.RecipeRequest{})
+	return v
+}
+
+// recipeRequestStructLevel checks that no two ingredient lines share a sort_order, which would leave
+// their display order up to the database. The error param is the index of the earlier line.
+func recipeRequestStructLevel(sl validator.StructLevel) {
+	req := sl.Current().Interface().(models.RecipeRequest)
+	first := make(map[int]int, len(req.Ingredients))
+	for i, ing := range req.Ingredients {
+		if ing.SortOrder == nil {
+			continue
+		}
+		if j, seen := first[*ing.SortOrder]; seen {
+			sl.ReportError(*ing.SortOrder, fmt.Sprintf("Ingredients[%d].SortOrder", i), "SortOrder", "unique_sort_order", strconv.Itoa(j))
+			continue
+		}
+		first[*ing.SortOrder] = i
+	}
+}
+
+// assignIngredientSortOrders numbers the ingredient lines sent without a sort_order, in the order they were
+// sent, after the highest sort_order given. Lines sent with one keep it.
+func assignIngredientSortOrders(ingredients []models.RecipeIngredientRequest) {
+	next := 0
+	for _, ing := range ingredients {
+		if ing.SortOrder != nil && *ing.SortOrder >= next {
+			next = *ing.SortOrder + 1
+		}
+	}
+	for i := range ingredients {
+		if ingredients[i].SortOrder == nil {
+			sortOrder := next
+			ingredients[i].SortOrder = &sortOrder
+			next++
+		}
+	}
+}
+
+// recipeIngredientRequestStructLevel checks that a unit is only given together with some quantity;
+// "2 cups" and "2 eggs" make sense, "cups of flour" does not.
+func recipeIngredientRequestStructLevel(sl validator.StructLevel) {
+	ing := sl.Current().Interface().(models.RecipeIngredientRequest)
+	if ing.UnitName != nil && *ing.UnitName != "" && !hasQuantity(ing) {
+		sl.ReportError(ing.UnitName, "UnitName", "unit_name", "quantity_with_unit", "")
+	}
+}
+
+// hasQuantity reports whether an ingredient line gives a quantity in any of its forms.
+func hasQuantity(ing models.RecipeIngredientRequest) bool {
+	return ing.Quantity != nil || ing.QuantityText != nil || ing.QuantityMin != nil || ing.QuantityMax != nil
+}
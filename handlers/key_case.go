@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JSON key casings a response can be sent in.
+const (
+	KeyCaseSnake = "snake" // As the models are tagged, e.g. total_time_minutes
+	KeyCaseCamel = "camel" // e.g. totalTimeMinutes
+)
+
+// ParseKeyCase checks a key casing, returning KeyCaseSnake for the empty string.
+func ParseKeyCase(s string) (string, error) {
+	switch s {
+	case "", KeyCaseSnake:
+		return KeyCaseSnake, nil
+	case KeyCaseCamel:
+		return KeyCaseCamel, nil
+	default:
+		return "", fmt.Errorf("case must be %s or %s", KeyCaseSnake, KeyCaseCamel)
+	}
+}
+
+// JSONKeyCaseMiddleware rewrites the object keys of JSON responses to camelCase when the request asks for it
+// with ?case=camel, or when defaultCase is KeyCaseCamel and the request does not ask for ?case=snake.
+// Every key is rewritten, not only struct field names: map keys are too, such as per-field validation details
+// and import errors. A response that carries client data in a map (none do today) would get its keys
+// rewritten as well. Key order is kept.
+// Request bodies are not rewritten and must still use snake_case. Non-JSON responses pass through.
+func JSONKeyCaseMiddleware(defaultCase string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		keyCase := defaultCase
+		if requested, ok := c.GetQuery("case"); ok {
+			var err error
+			if keyCase, err = ParseKeyCase(requested); err != nil {
+				RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: "+err.Error())
+				c.Abort()
+				return
+			}
+		}
+		if keyCase != KeyCaseCamel {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		writer := &keyCaseWriter{ResponseWriter: original, status: http.StatusOK}
+		c.Writer = writer
+		completed := false
+		// Deferred so that a panic reaches RecoveryMiddleware with the real writer. Whatever the handler had
+		// buffered before panicking is dropped, so the recovery response is sent on its own.
+		defer func() {
+			c.Writer = original
+			if completed {
+				writer.flush()
+			}
+		}()
+		c.Next()
+		completed = true
+	}
+}
+
+// keyCaseWriter holds back a JSON response body so its keys can be rewritten once the handler is done.
+// The choice is made at the first write, from the Content-Type set by then; other bodies are passed through.
+type keyCaseWriter struct {
+	gin.ResponseWriter
+	status      int
+	wroteHeader bool
+	buffered    bool
+	passthrough bool
+	body        bytes.Buffer
+}
+
+// start decides, at the first write, whether to buffer the body or pass it through.
+func (w *keyCaseWriter) start() {
+	if w.buffered || w.passthrough {
+		return
+	}
+	if strings.HasPrefix(w.ResponseWriter.Header().Get("Content-Type"), "application/json") {
+		w.buffered = true
+		return
+	}
+	w.passthrough = true
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+// flush sends the held-back response to the underlying writer, with its keys rewritten and its status.
+func (w *keyCaseWriter) flush() {
+	switch {
+	case w.buffered:
+		body, err := camelizeJSON(w.body.Bytes())
+		if err != nil {
+			body = w.body.Bytes() // Not valid JSON after all; send it as written
+		}
+		w.ResponseWriter.Header().Del("Content-Length")
+		w.ResponseWriter.WriteHeader(w.status)
+		w.ResponseWriter.Write(body)
+	case !w.passthrough && w.wroteHeader:
+		w.ResponseWriter.WriteHeader(w.status)
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *keyCaseWriter) WriteHeader(code int) {
+	if w.passthrough {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	if code > 0 {
+		w.status = code
+		w.wroteHeader = true
+	}
+}
+
+// WriteHeaderNow is how gin sends a response without a body, e.g. a 204 rendered with c.JSON, which has
+// already set the JSON Content-Type; so the held-back status is passed on before the header is sent.
+func (w *keyCaseWriter) WriteHeaderNow() {
+	if !w.buffered {
+		w.start()
+		if w.buffered {
+			w.ResponseWriter.WriteHeader(w.status)
+		}
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *keyCaseWriter) Write(data []byte) (int, error) {
+	w.start()
+	if w.buffered {
+		return w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *keyCaseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *keyCaseWriter) Status() int {
+	if w.passthrough {
+		return w.ResponseWriter.Status()
+	}
+	return w.status
+}
+
+func (w *keyCaseWriter) Written() bool {
+	return w.buffered || w.ResponseWriter.Written()
+}
+
+// camelizeJSON rewrites every object key in a JSON document from snake_case to camelCase.
+func camelizeJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber() // Keep numbers exactly as written
+	var out bytes.Buffer
+	if err := camelizeValue(dec, &out); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// camelizeValue copies the next JSON value from dec to out, rewriting object keys.
+func camelizeValue(dec *json.Decoder, out *bytes.Buffer) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		encoded, err := json.Marshal(tok)
+		if err != nil {
+			return err
+		}
+		out.Write(encoded)
+		return nil
+	}
+
+	out.WriteRune(rune(delim))
+	for i := 0; dec.More(); i++ {
+		if i > 0 {
+			out.WriteByte(',')
+		}
+		if delim == '{' {
+			key, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			encoded, _ := json.Marshal(snakeToCamel(key.(string)))
+			out.Write(encoded)
+			out.WriteByte(':')
+		}
+		if err := camelizeValue(dec, out); err != nil {
+			return err
+		}
+	}
+	closing, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	out.WriteRune(rune(closing.(json.Delim)))
+	return nil
+}
+
+// snakeToCamel converts a key such as "total_time_minutes" to "totalTimeMinutes".
+// Leading underscores and keys without underscores are left as they are.
+func snakeToCamel(key string) string {
+	trimmed := strings.TrimLeft(key, "_")
+	if !strings.Contains(trimmed, "_") {
+		return key
+	}
+	parts := strings.Split(trimmed, "_")
+	var b strings.Builder
+	b.WriteString(key[:len(key)-len(trimmed)])
+	b.WriteString(parts[0])
+	for _, part := range parts[1:] {
+		if part != "" {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
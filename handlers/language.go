@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// normalizeLang reduces a language tag such as "fr-CA" to its lowercased primary subtag ("fr").
+// It reports false if the tag does not start with a 2-3 letter language code.
+func normalizeLang(tag string) (string, bool) {
+	primary := strings.ToLower(strings.TrimSpace(strings.SplitN(tag, "-", 2)[0]))
+	if len(primary) < 2 || len(primary) > 3 {
+		return "", false
+	}
+	for _, r := range primary {
+		if r < 'a' || r > 'z' {
+			return "", false
+		}
+	}
+	return primary, true
+}
+
+// requestedLang returns the language the client asked for, preferring ?lang= over the
+// Accept-Language header (highest q-value wins). It returns "" when no usable language was given.
+func requestedLang(c *gin.Context) string {
+	if lang, ok := normalizeLang(c.Query("lang")); ok {
+		return lang
+	}
+
+	type weightedLang struct {
+		lang string
+		q    float64
+	}
+	var candidates []weightedLang
+	for _, part := range strings.Split(c.GetHeader("Accept-Language"), ",") {
+		fields := strings.Split(part, ";")
+		lang, ok := normalizeLang(fields[0])
+		if !ok {
+			continue // Also skips the "*" wildcard
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			if value, found := strings.CutPrefix(strings.TrimSpace(param), "q="); found {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q > 0 {
+			candidates = append(candidates, weightedLang{lang: lang, q: q})
+		}
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].lang
+}
@@ -0,0 +1,462 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store"
+)
+
+// maxImportRecipes caps how many recipes one import request may contain.
+const maxImportRecipes = 200
+
+// mealieMappedFields are the export fields mapRecipeFromMealie reads.
+var mealieMappedFields = map[string]bool{
+	"name": true, "description": true, "recipeServings": true, "recipeYield": true,
+	"prepTime": true, "cookTime": true, "performTime": true, "totalTime": true, "orgURL": true,
+	"recipeIngredient": true, "recipeInstructions": true, "tags": true, "recipeCategory": true,
+	"tools": true, "notes": true,
+}
+
+// mealieBookkeepingFields are export fields that describe the Mealie instance rather than the recipe;
+// they are dropped without a warning.
+var mealieBookkeepingFields = map[string]bool{
+	"id": true, "userId": true, "groupId": true, "householdId": true, "slug": true,
+	"dateAdded": true, "dateUpdated": true, "createdAt": true, "updatedAt": true, "update_at": true,
+	"lastMade": true, "settings": true, "recipeYieldQuantity": true,
+}
+
+// ImportMealieRecipes handles importing recipes from a Mealie JSON export.
+// @Summary Import recipes from Mealie
+// @Description Create recipes from Mealie's JSON export: a single recipe object or an array of them (at most 200).
+// @Description Ingredients may be structured or plain strings such as "1 1/2 cups flour, sifted"; instructions become steps, categories become tags and tools become equipment.
+// @Description Each recipe is validated and created on its own. Fields that cannot be mapped (e.g. nutrition, images, section titles) are dropped and listed in that recipe's warnings.
+// @Tags recipes
+// @Accept json
+// @Produce json
+// @Param export body []models.MealieRecipe true "Mealie recipe export (object or array)"
+// @Param created_by query string false "UUID to record as created_by on every imported recipe"
+// @Success 200 {object} models.RecipeImportResponse
+// @Failure 400 {object} APIError "Invalid payload or query parameters"
+// @Router /recipes/import/mealie [post]
+func (h *RecipeHandler) ImportMealieRecipes(c *gin.Context) {
+	var createdBy *uuid.UUID
+	if createdByStr := c.Query("created_by"); createdByStr != "" {
+		id, err := uuid.Parse(createdByStr)
+		if err != nil {
+			RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid created_by: "+err.Error())
+			return
+		}
+		createdBy = &id
+	}
+
+	raw, err := c.GetRawData()
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPayload, "Invalid request payload: "+err.Error())
+		return
+	}
+	exports, err := decodeMealieExport(raw)
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPayload, "Invalid request payload: "+err.Error())
+		return
+	}
+	if len(exports) == 0 || len(exports) > maxImportRecipes {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPayload,
+			fmt.Sprintf("Invalid request payload: expected between 1 and %d recipes", maxImportRecipes))
+		return
+	}
+
+	response := models.RecipeImportResponse{Results: make([]models.RecipeImportResult, 0, len(exports))}
+	for i, fields := range exports {
+		result := h.importMealieRecipe(c, fields, createdBy)
+		result.Index = i
+		if result.Recipe != nil {
+			response.Imported++
+		} else {
+			response.Failed++
+		}
+		response.Results = append(response.Results, result)
+	}
+	RespondWithJSON(c, http.StatusOK, response)
+}
+
+// importMealieRecipe maps, validates and creates one exported recipe.
+func (h *RecipeHandler) importMealieRecipe(c *gin.Context, fields map[string]json.RawMessage, createdBy *uuid.UUID) models.RecipeImportResult {
+	req, warnings, err := mapRecipeFromMealie(fields)
+	result := models.RecipeImportResult{Title: req.Title, Warnings: warnings}
+	if err != nil {
+		result.Errors = map[string]string{"Recipe": err.Error()}
+		return result
+	}
+	req.CreatedBy = createdBy
+
+	if validationErrors := h.validateRecipeRequest(req, false); validationErrors != nil {
+		result.Errors = validationErrors
+		return result
+	}
+	if h.cfg.RequireCreatedBy && createdBy == nil {
+		result.Errors = map[string]string{"CreatedBy": "is required on this server; pass created_by"}
+		return result
+	}
+	if createdBy == nil {
+		req.CreatedBy = h.defaultCreatedBy(c)
+	}
+
+	recipe, err := h.store.CreateRecipe(c.Request.Context(), req)
+	if err != nil {
+		key := "Recipe"
+		var relErr *store.RelationError
+		if errors.As(err, &relErr) {
+			key = fmt.Sprintf("%s%s[%d]", strings.ToUpper(relErr.Relation[:1]), relErr.Relation[1:], relErr.Index)
+		}
+		result.Errors = map[string]string{key: "failed to create recipe: " + err.Error()}
+		return result
+	}
+	h.roundQuantities(recipe)
+	recipe.Warnings = collectWarnings(req)
+	result.Recipe = recipe
+	return result
+}
+
+// decodeMealieExport accepts a single exported recipe or an array of them and returns each as its raw fields.
+func decodeMealieExport(raw []byte) ([]map[string]json.RawMessage, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) > 0 && raw[0] == '{' {
+		var single map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &single); err != nil {
+			return nil, err
+		}
+		return []map[string]json.RawMessage{single}, nil
+	}
+	var many []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &many); err != nil {
+		return nil, err
+	}
+	return many, nil
+}
+
+// mapRecipeFromMealie converts one exported recipe into a RecipeRequest. Anything that cannot be
+// represented is dropped and described in the returned warnings; an error means the export itself is malformed.
+func mapRecipeFromMealie(fields map[string]json.RawMessage) (*models.RecipeRequest, []string, error) {
+	req := &models.RecipeRequest{}
+	warnings := []string{}
+	warn := func(format string, args ...any) { warnings = append(warnings, fmt.Sprintf(format, args...)) }
+
+	encoded, _ := json.Marshal(fields)
+	var mr models.MealieRecipe
+	if err := json.Unmarshal(encoded, &mr); err != nil {
+		return req, warnings, fmt.Errorf("unexpected Mealie recipe shape: %w", err)
+	}
+	req.Title = strings.TrimSpace(mr.Name)
+
+	unmapped := []string{}
+	for name, value := range fields {
+		if !mealieMappedFields[name] && !mealieBookkeepingFields[name] && !isEmptyJSON(value) {
+			unmapped = append(unmapped, name)
+		}
+	}
+	sort.Strings(unmapped)
+	for _, name := range unmapped {
+		warn("field %q has no equivalent and was dropped", name)
+	}
+
+	if description := strings.TrimSpace(mr.Description); description != "" {
+		req.Description = &description
+	}
+	if url := strings.TrimSpace(mr.OrgURL); url != "" {
+		if strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://") {
+			req.SourceURL = &url
+		} else {
+			warn("orgURL %q is not an http(s) URL and was dropped", url)
+		}
+	}
+
+	// Servings and yield
+	if mr.RecipeServings > 0 {
+		serves := int(math.Round(mr.RecipeServings))
+		req.Serves = &serves
+	}
+	if yield := strings.TrimSpace(mr.RecipeYield); yield != "" {
+		quantity, unit, ok := parseMealieYield(yield)
+		switch {
+		case !ok:
+			warn("recipeYield %q has no leading number and was dropped", yield)
+		case isServingsUnit(unit):
+			if req.Serves == nil {
+				serves := int(math.Round(quantity))
+				req.Serves = &serves
+			}
+		default:
+			req.YieldQuantity, req.YieldUnit = &quantity, &unit
+		}
+	}
+
+	// Times
+	req.PrepTimeMinutes = mealieMinutes(mr.PrepTime, "prepTime", warn)
+	cookTime, cookField := mr.CookTime, "cookTime"
+	if strings.TrimSpace(cookTime) == "" {
+		cookTime, cookField = mr.PerformTime, "performTime"
+	}
+	req.CookTimeMinutes = mealieMinutes(cookTime, cookField, warn)
+	if strings.TrimSpace(mr.TotalTime) != "" && req.PrepTimeMinutes == nil && req.CookTimeMinutes == nil {
+		warn("totalTime %q was dropped; the total is derived from prep and cook times", mr.TotalTime)
+	}
+
+	// Ingredients; the same ingredient may only appear once per recipe.
+	seenIngredients := map[string]bool{}
+	for i, rawIngredient := range mr.RecipeIngredient {
+		ing, title, err := mapMealieIngredient(rawIngredient)
+		if err != nil {
+			warn("recipeIngredient[%d] could not be read and was dropped: %v", i, err)
+			continue
+		}
+		if title != "" {
+			warn("ingredient section title %q was dropped", title)
+		}
+		if ing == nil {
+			continue
+		}
+		if ing.UnitName != nil && ing.Quantity == nil {
+			// Mealie allows "a pinch of salt" without an amount; here a unit needs a quantity.
+			warn("recipeIngredient[%d] has unit %q but no quantity; the unit was dropped", i, *ing.UnitName)
+			ing.UnitName = nil
+		}
+		key := strings.ToLower(ing.IngredientName)
+		if seenIngredients[key] {
+			warn("ingredient %q appears more than once; recipeIngredient[%d] was dropped", ing.IngredientName, i)
+			continue
+		}
+		seenIngredients[key] = true
+		sortOrder := len(req.Ingredients)
+		ing.SortOrder = &sortOrder
+		req.Ingredients = append(req.Ingredients, *ing)
+	}
+
+	// Steps
+	for _, instruction := range mr.RecipeInstructions {
+		if title := strings.TrimSpace(instruction.Title); title != "" {
+			warn("instruction section title %q was dropped", title)
+		}
+		if text := strings.TrimSpace(instruction.Text); text != "" {
+			req.Steps = append(req.Steps, models.RecipeStepRequest{StepNumber: len(req.Steps) + 1, Instruction: text})
+		}
+	}
+
+	// Tags and categories both become tags; tools become equipment.
+	seenTags := map[string]bool{}
+	for _, named := range append(mr.Tags, mr.RecipeCategory...) {
+		name := strings.TrimSpace(named.Name)
+		if name != "" && !seenTags[strings.ToLower(name)] {
+			seenTags[strings.ToLower(name)] = true
+			req.Tags = append(req.Tags, models.RecipeTagRequest{Name: name})
+		}
+	}
+	for _, tool := range mr.Tools {
+		if name := strings.TrimSpace(tool.Name); name != "" {
+			req.Equipment = append(req.Equipment, models.RecipeEquipmentRequest{Name: name})
+		}
+	}
+
+	// Notes are joined into the recipe notes, each under its title.
+	var notes []string
+	for _, note := range mr.Notes {
+		title, text := strings.TrimSpace(note.Title), strings.TrimSpace(note.Text)
+		switch {
+		case title != "" && text != "":
+			notes = append(notes, title+": "+text)
+		case text != "":
+			notes = append(notes, text)
+		case title != "":
+			notes = append(notes, title)
+		}
+	}
+	if len(notes) > 0 {
+		joined := strings.Join(notes, "\n\n")
+		req.Notes = &joined
+	}
+
+	if len(warnings) == 0 {
+		warnings = nil
+	}
+	return req, warnings, nil
+}
+
+// mapMealieIngredient converts one recipeIngredient entry, a structured object or a plain string, into an
+// ingredient line and the section title above it. A nil line means the entry was empty.
+func mapMealieIngredient(raw json.RawMessage) (*models.RecipeIngredientRequest, string, error) {
+	var line string
+	if err := json.Unmarshal(raw, &line); err == nil {
+		return parseIngredientLine(line), "", nil
+	}
+	var mi models.MealieIngredient
+	if err := json.Unmarshal(raw, &mi); err != nil {
+		return nil, "", err
+	}
+	title := strings.TrimSpace(mi.Title)
+
+	if mi.DisableAmount || mi.Food == nil || strings.TrimSpace(mi.Food.Name) == "" {
+		// Unparsed in Mealie: the whole line is in note (or originalText).
+		text := mi.Note
+		if strings.TrimSpace(text) == "" {
+			text = mi.OriginalText
+		}
+		return parseIngredientLine(text), title, nil
+	}
+
+	ing := &models.RecipeIngredientRequest{IngredientName: strings.TrimSpace(mi.Food.Name)}
+	if mi.Quantity > 0 { // Mealie stores 0 for "no quantity"
+		quantity := mi.Quantity
+		ing.Quantity = &quantity
+	}
+	if mi.Unit != nil && strings.TrimSpace(mi.Unit.Name) != "" {
+		unit := strings.TrimSpace(mi.Unit.Name)
+		ing.UnitName = &unit
+	}
+	if note := strings.TrimSpace(mi.Note); note != "" {
+		ing.Notes = &note
+	}
+	return ing, title, nil
+}
+
+// importUnits are the unit words parseIngredientLine recognises after a quantity. Anything else is
+// taken to be part of the ingredient name, so "2 eggs" is two of the ingredient "eggs".
+var importUnits = map[string]bool{
+	"c": true, "cup": true, "cups": true,
+	"tbsp": true, "tbs": true, "tablespoon": true, "tablespoons": true,
+	"tsp": true, "teaspoon": true, "teaspoons": true,
+	"g": true, "gram": true, "grams": true, "kg": true, "kilogram": true, "kilograms": true, "mg": true,
+	"ml": true, "milliliter": true, "milliliters": true, "millilitre": true, "millilitres": true,
+	"l": true, "liter": true, "liters": true, "litre": true, "litres": true,
+	"oz": true, "ounce": true, "ounces": true, "lb": true, "lbs": true, "pound": true, "pounds": true,
+	"pint": true, "pints": true, "quart": true, "quarts": true,
+	"pinch": true, "pinches": true, "dash": true, "dashes": true, "clove": true, "cloves": true,
+	"can": true, "cans": true, "slice": true, "slices": true, "stick": true, "sticks": true,
+}
+
+// parseIngredientLine splits a free-text ingredient line such as "1 1/2 cups flour, sifted" into a quantity,
+// a unit, the ingredient name and a preparation (the text after the first comma). It returns nil for blank lines.
+func parseIngredientLine(line string) *models.RecipeIngredientRequest {
+	tokens := strings.Fields(line)
+	if len(tokens) == 0 {
+		return nil
+	}
+	ing := &models.RecipeIngredientRequest{}
+
+	// Prefer the two-token reading so "1 1/2" is not read as 1.
+	for n := 2; n >= 1; n-- {
+		if len(tokens) > n {
+			if quantity, err := models.ParseQuantity(strings.Join(tokens[:n], " ")); err == nil && quantity > 0 {
+				ing.Quantity = &quantity
+				tokens = tokens[n:]
+				break
+			}
+		}
+	}
+	if ing.Quantity != nil && len(tokens) > 1 {
+		if unit := strings.TrimSuffix(strings.ToLower(tokens[0]), "."); importUnits[unit] {
+			ing.UnitName = &unit
+			tokens = tokens[1:]
+			if len(tokens) > 1 && strings.EqualFold(tokens[0], "of") {
+				tokens = tokens[1:]
+			}
+		}
+	}
+
+	name, preparation, _ := strings.Cut(strings.Join(tokens, " "), ",")
+	ing.IngredientName = strings.TrimSpace(name)
+	if preparation = strings.TrimSpace(preparation); preparation != "" {
+		ing.Preparation = &preparation
+	}
+	return ing
+}
+
+var mealieYieldPattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*(.*)$`)
+
+// parseMealieYield splits a yield such as "24 cookies" into its number and unit.
+func parseMealieYield(yield string) (float64, string, bool) {
+	m := mealieYieldPattern.FindStringSubmatch(yield)
+	if m == nil {
+		return 0, "", false
+	}
+	quantity, err := strconv.ParseFloat(m[1], 64)
+	if err != nil || quantity <= 0 {
+		return 0, "", false
+	}
+	return quantity, strings.TrimSpace(m[2]), true
+}
+
+// isServingsUnit reports whether a yield unit counts people, so the yield is really a serving count.
+func isServingsUnit(unit string) bool {
+	switch strings.ToLower(unit) {
+	case "", "serving", "servings", "portion", "portions", "people", "persons":
+		return true
+	}
+	return false
+}
+
+var (
+	isoDurationPattern   = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+	humanDurationPattern = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*(hours?|hrs?|h|minutes?|mins?|m)\b`)
+)
+
+// parseMealieDuration reads a Mealie time, either ISO 8601 ("PT1H15M") or free text ("1 hour 15 minutes",
+// "75 min", or a bare number of minutes), as whole minutes.
+func parseMealieDuration(s string) (int, bool) {
+	text := strings.ToLower(strings.TrimSpace(s))
+	if m := isoDurationPattern.FindStringSubmatch(strings.ToUpper(text)); m != nil && strings.ContainsAny(text, "dhms") {
+		days, _ := strconv.Atoi(m[1])
+		hours, _ := strconv.Atoi(m[2])
+		minutes, _ := strconv.Atoi(m[3]) // Seconds are ignored
+		return days*24*60 + hours*60 + minutes, true
+	}
+	if n, err := strconv.Atoi(text); err == nil && n >= 0 {
+		return n, true
+	}
+	matches := humanDurationPattern.FindAllStringSubmatch(text, -1)
+	if len(matches) == 0 {
+		return 0, false
+	}
+	var minutes float64
+	for _, m := range matches {
+		n, _ := strconv.ParseFloat(m[1], 64)
+		if strings.HasPrefix(m[2], "h") {
+			n *= 60
+		}
+		minutes += n
+	}
+	return int(math.Round(minutes)), true
+}
+
+// mealieMinutes converts a Mealie time field to minutes, warning and returning nil when it cannot be read.
+func mealieMinutes(value, field string, warn func(string, ...any)) *int {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	minutes, ok := parseMealieDuration(value)
+	if !ok {
+		warn("%s %q is not a recognisable duration and was dropped", field, value)
+		return nil
+	}
+	return &minutes
+}
+
+// isEmptyJSON reports whether a raw JSON value carries no data: null, "", 0, false, [] or {}.
+func isEmptyJSON(value json.RawMessage) bool {
+	switch string(bytes.TrimSpace(value)) {
+	case "", "null", `""`, "0", "false", "[]", "{}":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gaanon/gorecipes_v2/config"
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store/mocks"
+)
+
+func TestParseIngredientLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want *models.RecipeIngredientRequest
+	}{
+		{"1 1/2 cups of flour, sifted", &models.RecipeIngredientRequest{IngredientName: "flour", Quantity: float64Ptr(1.5), UnitName: strPtr("cups"), Preparation: strPtr("sifted")}},
+		{"2 eggs", &models.RecipeIngredientRequest{IngredientName: "eggs", Quantity: float64Ptr(2)}},
+		{"½ tsp. salt", &models.RecipeIngredientRequest{IngredientName: "salt", Quantity: float64Ptr(0.5), UnitName: strPtr("tsp")}},
+		{"salt and pepper to taste", &models.RecipeIngredientRequest{IngredientName: "salt and pepper to taste"}},
+		{"3", &models.RecipeIngredientRequest{IngredientName: "3"}},
+		{"Inf eggs", &models.RecipeIngredientRequest{IngredientName: "Inf eggs"}},
+		{"NaN cups flour", &models.RecipeIngredientRequest{IngredientName: "NaN cups flour"}},
+		{"   ", nil},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, parseIngredientLine(tt.line), tt.line)
+	}
+}
+
+func TestParseMealieDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+		ok   bool
+	}{
+		{"PT1H15M", 75, true},
+		{"PT45M", 45, true},
+		{"P1D", 1440, true},
+		{"1 hour 15 minutes", 75, true},
+		{"1.5 hrs", 90, true},
+		{"20 mins", 20, true},
+		{"30", 30, true},
+		{"PT", 0, false},
+		{"overnight", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseMealieDuration(tt.in)
+		assert.Equal(t, tt.ok, ok, tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+	}
+}
+
+func TestMapRecipeFromMealie(t *testing.T) {
+	export := `{
+		"id": "5f0c", "slug": "pancakes", "name": "Pancakes", "description": "Fluffy.",
+		"recipeYield": "12 pancakes", "recipeServings": 4,
+		"prepTime": "10 minutes", "performTime": "PT20M", "totalTime": "30 minutes",
+		"orgURL": "https://example.com/pancakes",
+		"recipeIngredient": [
+			{"title": "Batter", "quantity": 200, "unit": {"name": "gram"}, "food": {"name": "flour"}, "note": "plain"},
+			{"quantity": 0, "unit": null, "food": null, "disableAmount": true, "note": "2 eggs"},
+			"1 cup milk",
+			{"quantity": 50, "unit": {"name": "gram"}, "food": {"name": "Flour"}},
+			{"quantity": 0, "unit": {"name": "pinch"}, "food": {"name": "salt"}}
+		],
+		"recipeInstructions": [{"title": "", "text": "Mix."}, {"text": "  "}, {"title": "Cook", "text": "Fry."}],
+		"tags": [{"name": "breakfast"}], "recipeCategory": [{"name": "Breakfast"}, {"name": "sweet"}],
+		"tools": [{"name": "frying pan"}],
+		"notes": [{"title": "Tip", "text": "Rest the batter."}],
+		"nutrition": {"calories": "200"}, "image": "", "rating": null
+	}`
+	var fields map[string]json.RawMessage
+	assert.NoError(t, json.Unmarshal([]byte(export), &fields))
+
+	req, warnings, err := mapRecipeFromMealie(fields)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.RecipeRequest{
+		Title:           "Pancakes",
+		Description:     strPtr("Fluffy."),
+		Serves:          intPtr(4),
+		YieldQuantity:   float64Ptr(12),
+		YieldUnit:       strPtr("pancakes"),
+		SourceURL:       strPtr("https://example.com/pancakes"),
+		Notes:           strPtr("Tip: Rest the batter."),
+		PrepTimeMinutes: intPtr(10),
+		CookTimeMinutes: intPtr(20),
+		Ingredients: []models.RecipeIngredientRequest{
+			{IngredientName: "flour", Quantity: float64Ptr(200), UnitName: strPtr("gram"), Notes: strPtr("plain"), SortOrder: intPtr(0)},
+			{IngredientName: "eggs", Quantity: float64Ptr(2), SortOrder: intPtr(1)},
+			{IngredientName: "milk", Quantity: float64Ptr(1), UnitName: strPtr("cup"), SortOrder: intPtr(2)},
+			{IngredientName: "salt", SortOrder: intPtr(3)},
+		},
+		Steps:     []models.RecipeStepRequest{{StepNumber: 1, Instruction: "Mix."}, {StepNumber: 2, Instruction: "Fry."}},
+		Tags:      []models.RecipeTagRequest{{Name: "breakfast"}, {Name: "sweet"}},
+		Equipment: []models.RecipeEquipmentRequest{{Name: "frying pan"}},
+	}, req)
+	assert.Equal(t, []string{
+		`field "nutrition" has no equivalent and was dropped`,
+		`ingredient section title "Batter" was dropped`,
+		`ingredient "Flour" appears more than once; recipeIngredient[3] was dropped`,
+		`recipeIngredient[4] has unit "pinch" but no quantity; the unit was dropped`,
+		`instruction section title "Cook" was dropped`,
+	}, warnings)
+}
+
+func TestRecipeHandler_ImportMealieRecipes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+	router.POST("/api/v1/recipes/import/mealie", recipeHandler.ImportMealieRecipes)
+
+	post := func(query, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes/import/mealie"+query, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// One recipe is created, one fails validation (title too short) and one fails in the store.
+	createdBy := uuid.New()
+	gomock.InOrder(
+		mockStore.EXPECT().CreateRecipe(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ interface{}, req *models.RecipeRequest) (*models.Recipe, error) {
+				assert.Equal(t, &createdBy, req.CreatedBy)
+				return &models.Recipe{ID: uuid.New(), Title: req.Title}, nil
+			}),
+		mockStore.EXPECT().CreateRecipe(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused")),
+	)
+	w := post("?created_by="+createdBy.String(), `[
+		{"name": "Pancakes", "recipeIngredient": ["2 eggs"], "recipeInstructions": [{"text": "Mix."}]},
+		{"name": "Pb"},
+		{"name": "Waffles"}]`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.RecipeImportResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Imported)
+	assert.Equal(t, 2, response.Failed)
+	assert.Len(t, response.Results, 3)
+	assert.NotNil(t, response.Results[0].Recipe)
+	assert.Equal(t, []models.ValidationWarning{
+		{Field: "Ingredients[0].UnitName", Message: "quantity has no unit; ignore this for countable ingredients such as eggs"},
+	}, response.Results[0].Recipe.Warnings)
+	assert.Contains(t, response.Results[1].Errors, "Title")
+	assert.Equal(t, 2, response.Results[2].Index)
+	assert.Contains(t, response.Results[2].Errors, "Recipe")
+
+	// A single object is accepted too; malformed payloads and IDs are rejected up front.
+	mockStore.EXPECT().CreateRecipe(gomock.Any(), gomock.Any()).Return(&models.Recipe{ID: uuid.New(), Title: "Crêpes"}, nil)
+	assert.Equal(t, http.StatusOK, post("", `{"name": "Crêpes"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, post("", `[]`).Code)
+	assert.Equal(t, http.StatusBadRequest, post("", `"Pancakes"`).Code)
+	assert.Equal(t, http.StatusBadRequest, post("?created_by=nobody", `{"name": "Crêpes"}`).Code)
+}
@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/gaanon/gorecipes_v2/store"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// requestIDHeader carries the correlation ID of a request, from the client or a proxy, and back in the response.
+const requestIDHeader = "X-Request-ID"
+
+// requestIDKey is the gin context key RequestIDMiddleware stores the request ID under.
+const requestIDKey = "request_id"
+
+// maxRequestIDLength caps incoming request IDs; longer ones are replaced rather than logged and echoed.
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware gives every request a correlation ID: the X-Request-ID sent by the client or an upstream
+// proxy when it is usable, or a new UUID otherwise. The ID is echoed in the X-Request-ID response header,
+// included in error bodies and logged with panics, so a request can be followed across services.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+		c.Set(requestIDKey, id)
+		c.Header(requestIDHeader, id)
+		c.Next()
+	}
+}
+
+// validRequestID reports whether an incoming request ID can be reused: non-empty, at most
+// maxRequestIDLength bytes and printable ASCII, so it is safe to log and to send back in a header.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// requestID returns the request's correlation ID, or "" if RequestIDMiddleware did not run.
+func requestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
+
+// AccessLogMiddleware logs one line per request to out, in gin's default layout plus the request ID
+// (see RequestIDMiddleware), so access log lines can be matched with panic logs and client reports.
+func AccessLogMiddleware(out io.Writer) gin.HandlerFunc {
+	return gin.LoggerWithConfig(gin.LoggerConfig{Output: out, Formatter: accessLogLine})
+}
+
+// accessLogLine formats an access log line, e.g.
+// "[GIN] 2024/05/01 - 12:00:00 | 200 |   1.2ms |   10.0.0.1 | GET     "/api/v1/recipes" | request_id=abc".
+func accessLogLine(p gin.LogFormatterParams) string {
+	id, _ := p.Keys[requestIDKey].(string)
+	if p.Latency > time.Minute {
+		p.Latency = p.Latency.Truncate(time.Second)
+	}
+	return fmt.Sprintf("[GIN] %v | %3d | %13v | %15s | %-7s %#v | request_id=%s\n%s",
+		p.TimeStamp.Format("2006/01/02 - 15:04:05"), p.StatusCode, p.Latency, p.ClientIP, p.Method, p.Path, id, p.ErrorMessage)
+}
+
+// TracingMiddleware starts a server span per request, continuing any trace context sent by the caller.
+// The span is stored in the request context, so store calls made with c.Request.Context() become its children.
+func TracingMiddleware() gin.HandlerFunc {
+	tracer := otel.Tracer("github.com/gaanon/gorecipes_v2/handlers")
+	return func(c *gin.Context) {
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		// Name spans by route template, not raw path, to keep span names low-cardinality.
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		ctx, span := tracer.Start(ctx, c.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", c.Request.Method),
+				attribute.String("http.route", route),
+				attribute.String("url.path", c.Request.URL.Path),
+			),
+		)
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
+
+// AdminAuthMiddleware only lets through requests carrying "Authorization: Bearer <apiKey>".
+// An empty apiKey rejects every request, so admin endpoints are closed unless a key is configured.
+func AdminAuthMiddleware(apiKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if apiKey == "" || !ok || subtle.ConstantTimeCompare([]byte(token), []byte(apiKey)) != 1 {
+			RespondWithError(c, http.StatusUnauthorized, ErrCodeUnauthorized, "A valid admin API key is required")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// PoolExhaustionMiddleware tracks, per request, whether a store call gave up waiting for a pooled database
+// connection (DB_ACQUIRE_TIMEOUT), so that the resulting error is sent as 503 service_overloaded, not 500.
+func PoolExhaustionMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(store.TrackPoolExhaustion(c.Request.Context()))
+		c.Next()
+	}
+}
+
+// RecoveryMiddleware turns a panic in a later handler into a JSON APIError with status 500,
+// instead of gin's default plain-text response. The panic is logged with its stack and the request ID
+// (see RequestIDMiddleware).
+func RecoveryMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if r := recover(); r != nil {
+				slog.Error("panic while handling request",
+					"panic", r,
+					"request_id", requestID(c),
+					"method", c.Request.Method,
+					"path", c.Request.URL.Path,
+					"stack", string(debug.Stack()),
+				)
+				RespondWithError(c, http.StatusInternalServerError, ErrCodeInternalPanic, "Internal server error")
+				c.Abort()
+			}
+		}()
+		c.Next()
+	}
+}
+
+// RequestTimeoutMiddleware gives each request a deadline of timeout on its context, which store calls observe.
+// If the handler has not started its response by the deadline, whatever it writes is dropped and a JSON 408
+// is sent instead. Handlers are not interrupted, so one that ignores its context still runs to completion.
+// Headers set by earlier middleware (X-Request-ID, CORS, CSP) are kept on the 408. A zero timeout disables the middleware.
+func RequestTimeoutMiddleware(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		original := c.Writer
+		earlierHeaders := original.Header().Clone()
+		writer := &deadlineWriter{ResponseWriter: original, ctx: ctx}
+		c.Request = c.Request.WithContext(ctx)
+		c.Writer = writer
+		// Deferred so that a panic reaches RecoveryMiddleware with the real writer.
+		defer func() { c.Writer = original }()
+		c.Next()
+		c.Writer = original
+
+		if writer.timedOut {
+			// Drop headers the handler set for the response that was discarded (Content-Disposition etc.).
+			for key := range original.Header() {
+				original.Header().Del(key)
+			}
+			for key, values := range earlierHeaders {
+				original.Header()[key] = values
+			}
+			RespondWithError(c, http.StatusRequestTimeout, ErrCodeRequestTimeout, "Request took too long to process")
+			c.Abort()
+		}
+	}
+}
+
+// deadlineWriter passes writes through until ctx is done; after that, unless the response has already
+// been started, it discards them and records that the request timed out.
+type deadlineWriter struct {
+	gin.ResponseWriter
+	ctx      context.Context
+	timedOut bool
+}
+
+// expired reports whether output must be dropped because the deadline passed before the response started.
+// Only the deadline counts: the context is also cancelled when the middleware returns, including while a
+// panic unwinds, and the recovery response written then must get through.
+func (w *deadlineWriter) expired() bool {
+	if !w.timedOut && !w.ResponseWriter.Written() && errors.Is(w.ctx.Err(), context.DeadlineExceeded) {
+		w.timedOut = true
+	}
+	return w.timedOut
+}
+
+func (w *deadlineWriter) WriteHeader(code int) {
+	if !w.expired() {
+		w.ResponseWriter.WriteHeader(code)
+	}
+}
+
+func (w *deadlineWriter) WriteHeaderNow() {
+	if !w.expired() {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *deadlineWriter) Write(data []byte) (int, error) {
+	if w.expired() {
+		return len(data), nil
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *deadlineWriter) WriteString(s string) (int, error) {
+	if w.expired() {
+		return len(s), nil
+	}
+	return w.ResponseWriter.WriteString(s)
+}
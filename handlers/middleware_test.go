@@ -0,0 +1,318 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gaanon/gorecipes_v2/store"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	}()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(TracingMiddleware())
+	var handlerSpan trace.SpanContext
+	router.GET("/api/v1/recipes/:id", func(c *gin.Context) {
+		handlerSpan = trace.SpanContextFromContext(c.Request.Context())
+		c.Status(http.StatusInternalServerError)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes/123", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 1) {
+		span := spans[0]
+		assert.Equal(t, "GET /api/v1/recipes/:id", span.Name())
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+		assert.Equal(t, codes.Error, span.Status().Code)
+		assert.Equal(t, span.SpanContext().SpanID(), handlerSpan.SpanID())
+	}
+}
+
+func TestAdminAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		apiKey        string
+		authorization string
+		wantStatus    int
+	}{
+		{"s3cret", "Bearer s3cret", http.StatusOK},
+		{"s3cret", "Bearer wrong", http.StatusUnauthorized},
+		{"s3cret", "s3cret", http.StatusUnauthorized}, // Missing the Bearer scheme
+		{"s3cret", "", http.StatusUnauthorized},
+		{"", "Bearer ", http.StatusUnauthorized}, // No key configured: admin endpoints are closed
+	}
+	for _, tt := range tests {
+		router := gin.New()
+		router.POST("/admin", AdminAuthMiddleware(tt.apiKey), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		req, _ := http.NewRequest(http.MethodPost, "/admin", nil)
+		if tt.authorization != "" {
+			req.Header.Set("Authorization", tt.authorization)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, tt.wantStatus, w.Code, "key=%q authorization=%q", tt.apiKey, tt.authorization)
+	}
+}
+
+func TestRecoveryMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RecoveryMiddleware())
+	router.GET("/boom", func(c *gin.Context) { panic("boom") })
+
+	req, _ := http.NewRequest(http.MethodGet, "/boom", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+	var errorResponse APIError
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+	assert.Equal(t, ErrCodeInternalPanic, errorResponse.Code)
+	assert.Equal(t, http.StatusInternalServerError, errorResponse.Status)
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), RecoveryMiddleware())
+	router.GET("/ok", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	router.GET("/missing", func(c *gin.Context) {
+		RespondWithError(c, http.StatusNotFound, ErrCodeRecipeNotFound, "Recipe not found")
+	})
+	router.GET("/boom", func(c *gin.Context) { panic("boom") })
+
+	serve := func(path, id string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		if id != "" {
+			req.Header.Set("X-Request-ID", id)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// A usable incoming ID is passed through to the header and error bodies.
+	for _, path := range []string{"/missing", "/boom"} {
+		w := serve(path, "gateway-7f3a")
+		assert.Equal(t, "gateway-7f3a", w.Header().Get("X-Request-ID"), path)
+		var errorResponse APIError
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse), path)
+		assert.Equal(t, "gateway-7f3a", errorResponse.RequestID, path)
+	}
+
+	// Otherwise one is generated.
+	w := serve("/ok", "")
+	_, err := uuid.Parse(w.Header().Get("X-Request-ID"))
+	assert.NoError(t, err)
+	for _, unusable := range []string{"has space", strings.Repeat("a", maxRequestIDLength+1), "caf\u00e9"} {
+		w = serve("/ok", unusable)
+		assert.NotEqual(t, unusable, w.Header().Get("X-Request-ID"))
+		assert.NotEmpty(t, w.Header().Get("X-Request-ID"))
+	}
+}
+
+func TestAccessLogMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var out bytes.Buffer
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), AccessLogMiddleware(&out))
+	router.GET("/ok", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	req, _ := http.NewRequest(http.MethodGet, "/ok", nil)
+	req.Header.Set("X-Request-ID", "trace-123")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	line := out.String()
+	assert.Contains(t, line, "| 204 |")
+	assert.Contains(t, line, `GET     "/ok"`)
+	assert.Contains(t, line, "request_id=trace-123")
+}
+
+func TestRequestTimeoutMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), RequestTimeoutMiddleware(20*time.Millisecond))
+	router.GET("/fast", func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"ok": true}) })
+	// Behaves like a store call: waits on the request context and then reports the failure.
+	router.GET("/slow", func(c *gin.Context) {
+		<-c.Request.Context().Done()
+		c.Header("Content-Disposition", "inline")
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, c.Request.Context().Err().Error())
+	})
+	// Ignores its context but has not responded by the deadline either.
+	router.GET("/busy", func(c *gin.Context) {
+		time.Sleep(40 * time.Millisecond)
+		c.Status(http.StatusNoContent)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/fast", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	for _, path := range []string{"/slow", "/busy"} {
+		req, _ = http.NewRequest(http.MethodGet, path, nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestTimeout, w.Code, path)
+		assert.Empty(t, w.Header().Get("Content-Disposition"), path)
+		assert.NotEmpty(t, w.Header().Get("X-Request-ID"), path)
+		var errorResponse APIError
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse), path)
+		assert.Equal(t, ErrCodeRequestTimeout, errorResponse.Code, path)
+	}
+}
+
+func TestRequestTimeoutMiddleware_Panic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RecoveryMiddleware(), RequestTimeoutMiddleware(time.Minute))
+	router.GET("/panic", func(c *gin.Context) { panic("boom") })
+
+	req, _ := http.NewRequest(http.MethodGet, "/panic", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	var errorResponse APIError
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+	assert.Equal(t, ErrCodeInternalPanic, errorResponse.Code)
+}
+
+func TestPoolExhaustionMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(PoolExhaustionMiddleware())
+	// Simulates a store call whose connection acquire timed out, as the pool's tracer would see it.
+	router.GET("/exhausted", func(c *gin.Context) {
+		tracer := &store.AcquireTimeoutTracer{Timeout: time.Millisecond}
+		ctx := tracer.TraceAcquireStart(c.Request.Context(), nil, pgxpool.TraceAcquireStartData{})
+		<-ctx.Done()
+		tracer.TraceAcquireEnd(ctx, nil, pgxpool.TraceAcquireEndData{Err: ctx.Err()})
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to get recipe: context deadline exceeded")
+	})
+	router.GET("/failed", func(c *gin.Context) {
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to get recipe: connection refused")
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/exhausted", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+	var apiErr APIError
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &apiErr))
+	assert.Equal(t, ErrCodeServiceOverloaded, apiErr.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/failed", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), ErrCodeDBError)
+}
+
+func TestJSONKeyCaseMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	newRouter := func(defaultCase string) *gin.Engine {
+		router := gin.New()
+		router.Use(JSONKeyCaseMiddleware(defaultCase))
+		router.GET("/recipe", func(c *gin.Context) {
+			c.JSON(http.StatusCreated, gin.H{"total_time_minutes": 45, "ingredients": []gin.H{{"ingredient_name": "flour", "quantity": 1.25}}})
+		})
+		router.GET("/markdown", func(c *gin.Context) { c.Data(http.StatusOK, markdownContentType, []byte("# snake_case stays")) })
+		router.DELETE("/recipe", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+		return router
+	}
+	serve := func(router *gin.Engine, method, path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	snake, camel := newRouter(KeyCaseSnake), newRouter(KeyCaseCamel)
+	camelBody := `{"ingredients":[{"ingredientName":"flour","quantity":1.25}],"totalTimeMinutes":45}`
+
+	w := serve(snake, http.MethodGet, "/recipe")
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"total_time_minutes":45`)
+
+	w = serve(snake, http.MethodGet, "/recipe?case=camel")
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, camelBody, w.Body.String())
+
+	assert.Equal(t, camelBody, serve(camel, http.MethodGet, "/recipe").Body.String())
+	assert.Contains(t, serve(camel, http.MethodGet, "/recipe?case=snake").Body.String(), `"ingredient_name":"flour"`)
+	assert.Equal(t, "# snake_case stays", serve(camel, http.MethodGet, "/markdown").Body.String())
+	assert.Equal(t, http.StatusNoContent, serve(camel, http.MethodDelete, "/recipe").Code)
+
+	w = serve(snake, http.MethodGet, "/recipe?case=kebab")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrCodeInvalidQuery)
+}
+
+func TestJSONKeyCaseMiddleware_Panic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RecoveryMiddleware(), JSONKeyCaseMiddleware(KeyCaseSnake))
+	router.GET("/panic", func(c *gin.Context) { panic("boom") })
+
+	req, _ := http.NewRequest(http.MethodGet, "/panic?case=camel", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	var errorResponse APIError
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+	assert.Equal(t, ErrCodeInternalPanic, errorResponse.Code)
+}
+
+func TestSnakeToCamel(t *testing.T) {
+	tests := map[string]string{
+		"id":                 "id",
+		"total_time_minutes": "totalTimeMinutes",
+		"next_cursor":        "nextCursor",
+		"_links":             "_links",
+		"trailing_":          "trailing",
+		"Title":              "Title",
+	}
+	for key, want := range tests {
+		assert.Equal(t, want, snakeToCamel(key), key)
+	}
+}
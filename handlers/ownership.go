@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/gaanon/gorecipes_v2/models"
+)
+
+// currentUserKey is the gin context key holding the authenticated user's ID.
+const currentUserKey = "current_user_id"
+
+// SetCurrentUser records the authenticated user for the rest of the request. It is meant to be called
+// by authentication middleware; none is installed yet, so every request is currently anonymous.
+func SetCurrentUser(c *gin.Context, userID uuid.UUID) {
+	c.Set(currentUserKey, userID)
+}
+
+// currentUser returns the authenticated user's ID, or false for anonymous requests.
+func currentUser(c *gin.Context) (uuid.UUID, bool) {
+	userID, ok := c.Get(currentUserKey)
+	if !ok {
+		return uuid.Nil, false
+	}
+	id, ok := userID.(uuid.UUID)
+	return id, ok && id != uuid.Nil
+}
+
+// defaultCreatedBy returns the user to record as created_by on a recipe created without one:
+// the configured anonymous user for anonymous requests, or nil.
+func (h *RecipeHandler) defaultCreatedBy(c *gin.Context) *uuid.UUID {
+	if _, ok := currentUser(c); ok || h.cfg.AnonymousUserID == "" {
+		return nil
+	}
+	id, err := uuid.Parse(h.cfg.AnonymousUserID) // Checked at startup
+	if err != nil || id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
+
+// markOwnership sets IsOwner on every recipe created by the current user. Anonymous requests own nothing.
+func markOwnership(c *gin.Context, recipes ...*models.Recipe) {
+	userID, ok := currentUser(c)
+	for _, recipe := range recipes {
+		recipe.IsOwner = ok && recipe.CreatedBy != nil && *recipe.CreatedBy == userID
+	}
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gaanon/gorecipes_v2/config"
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store/mocks"
+)
+
+func TestRecipeHandler_IsOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+
+	owner, other := uuid.New(), uuid.New()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	// Stands in for authentication middleware: the X-Test-User header names the signed-in user.
+	router.Use(func(c *gin.Context) {
+		if userID, err := uuid.Parse(c.GetHeader("X-Test-User")); err == nil {
+			SetCurrentUser(c, userID)
+		}
+	})
+	router.GET("/recipes", recipeHandler.ListRecipes)
+	router.GET("/recipes/:id", recipeHandler.GetRecipe)
+
+	owned := models.Recipe{ID: uuid.New(), Title: "Mine", CreatedBy: &owner}
+	unowned := models.Recipe{ID: uuid.New(), Title: "Anonymous"}
+	mockStore.EXPECT().GetRecipeByID(gomock.Any(), owned.ID).DoAndReturn(func(_ context.Context, _ uuid.UUID) (*models.Recipe, error) {
+		recipe := owned
+		return &recipe, nil
+	}).AnyTimes()
+	mockStore.EXPECT().ListRecipes(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ models.RecipeListParams) ([]*models.Recipe, error) {
+		first, second := owned, unowned
+		return []*models.Recipe{&first, &second}, nil
+	}).AnyTimes()
+
+	get := func(path string, user *uuid.UUID) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		if user != nil {
+			req.Header.Set("X-Test-User", user.String())
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	for _, tt := range []struct {
+		name string
+		user *uuid.UUID
+		want bool
+	}{
+		{"owner", &owner, true},
+		{"other user", &other, false},
+		{"anonymous", nil, false},
+	} {
+		var recipe models.Recipe
+		w := get("/recipes/"+owned.ID.String(), tt.user)
+		assert.Equal(t, http.StatusOK, w.Code, tt.name)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &recipe))
+		assert.Equal(t, tt.want, recipe.IsOwner, tt.name)
+
+		var list models.RecipeListResponse
+		w = get("/recipes", tt.user)
+		assert.Equal(t, http.StatusOK, w.Code, tt.name)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+		if assert.Len(t, list.Recipes, 2, tt.name) {
+			assert.Equal(t, tt.want, list.Recipes[0].IsOwner, tt.name)
+			assert.False(t, list.Recipes[1].IsOwner, tt.name)
+		}
+	}
+
+	// Anonymous responses still carry the field, so clients need not special-case its absence.
+	assert.Contains(t, get("/recipes/"+owned.ID.String(), nil).Body.String(), `"is_owner":false`)
+}
+
+func TestRecipeHandler_CreateRecipe_AnonymousUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	anonymous, author, signedIn := uuid.New(), uuid.New(), uuid.New()
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{AnonymousUserID: anonymous.String()})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if userID, err := uuid.Parse(c.GetHeader("X-Test-User")); err == nil {
+			SetCurrentUser(c, userID)
+		}
+	})
+	router.POST("/recipes", recipeHandler.CreateRecipe)
+
+	var recorded *uuid.UUID
+	mockStore.EXPECT().CreateRecipe(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, req *models.RecipeRequest) (*models.Recipe, error) {
+		recorded = req.CreatedBy
+		return &models.Recipe{ID: uuid.New(), Title: req.Title, CreatedBy: req.CreatedBy}, nil
+	}).AnyTimes()
+
+	create := func(body string, user *uuid.UUID) *uuid.UUID {
+		recorded = nil
+		req, _ := http.NewRequest(http.MethodPost, "/recipes", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		if user != nil {
+			req.Header.Set("X-Test-User", user.String())
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		return recorded
+	}
+
+	assert.Equal(t, &anonymous, create(`{"title": "Soup"}`, nil))
+	assert.Equal(t, &author, create(`{"title": "Soup", "created_by": "`+author.String()+`"}`, nil))
+	assert.Nil(t, create(`{"title": "Soup"}`, &signedIn)) // Only anonymous requests get the default
+
+	// Without the setting, created_by stays NULL.
+	router = gin.New()
+	router.POST("/recipes", NewRecipeHandler(mockStore, config.APIConfig{}).CreateRecipe)
+	assert.Nil(t, create(`{"title": "Soup"}`, nil))
+}
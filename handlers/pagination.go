@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultPageSize    = 20
+	defaultMaxPageSize = 100 // Used when config.APIConfig.MaxPageSize is unset
+)
+
+// maxPageSize returns the largest page a client may request.
+func (h *RecipeHandler) maxPageSize() int {
+	if h.cfg.MaxPageSize > 0 {
+		return h.cfg.MaxPageSize
+	}
+	return defaultMaxPageSize
+}
+
+// parsePageSizeQuery reads a page size from the query parameter key. Missing or non-positive values
+// fall back to the default and values above the maximum are clamped rather than rejected;
+// only non-integers are an error.
+func (h *RecipeHandler) parsePageSizeQuery(c *gin.Context, key string) (int, error) {
+	pageSize := defaultPageSize
+	if valueStr := c.Query(key); valueStr != "" {
+		value, err := strconv.Atoi(valueStr)
+		if err != nil {
+			return 0, fmt.Errorf("%s must be an integer", key)
+		}
+		if value > 0 {
+			pageSize = value
+		}
+	}
+	return min(pageSize, h.maxPageSize()), nil
+}
+
+// encodeRecipeCursor turns a cursor into the opaque string handed to clients as next_cursor.
+func encodeRecipeCursor(cursor models.RecipeCursor) string {
+	raw := cursor.UpdatedAt.UTC().Format(time.RFC3339Nano) + "|" + cursor.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeRecipeCursor parses an opaque cursor previously produced by encodeRecipeCursor.
+func decodeRecipeCursor(s string) (*models.RecipeCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("cursor is not valid base64")
+	}
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 {
+		return nil, errors.New("cursor is malformed")
+	}
+	updatedAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return nil, errors.New("cursor has an invalid timestamp")
+	}
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return nil, errors.New("cursor has an invalid ID")
+	}
+	return &models.RecipeCursor{UpdatedAt: updatedAt, ID: id}, nil
+}
+
+// parsePositiveIntQuery reads an optional positive integer query parameter.
+func parsePositiveIntQuery(c *gin.Context, key string, fallback int) (int, error) {
+	valueStr := c.Query(key)
+	if valueStr == "" {
+		return fallback, nil
+	}
+	value, err := strconv.Atoi(valueStr)
+	if err != nil || value < 1 {
+		return 0, fmt.Errorf("%s must be a positive integer", key)
+	}
+	return value, nil
+}
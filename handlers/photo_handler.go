@@ -0,0 +1,316 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // register GIF decoding for image.Decode
+	"image/jpeg"
+	_ "image/png" // register PNG decoding for image.Decode
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gaanon/gorecipes_v2/config"
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PhotoHandler handles HTTP requests for recipe photos.
+type PhotoHandler struct {
+	store store.RecipeStore
+	cfg   config.PhotoConfig
+}
+
+// NewPhotoHandler creates a new PhotoHandler.
+func NewPhotoHandler(store store.RecipeStore, cfg config.PhotoConfig) *PhotoHandler {
+	return &PhotoHandler{store: store, cfg: cfg}
+}
+
+// photoExtensions maps the formats reported by image.Decode to the extension used on disk.
+var photoExtensions = map[string]string{
+	"jpeg": ".jpg",
+	"png":  ".png",
+	"gif":  ".gif",
+}
+
+// photoContentTypes are the sniffed content types of the formats in photoExtensions.
+var photoContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// maxPhotoDimension is the largest width or height of an uploaded photo, checked before it is decoded.
+const maxPhotoDimension = 10000
+
+// photoMultipartOverhead is the allowance on top of PhotoConfig.MaxBytes for the multipart
+// boundaries and part headers wrapped around the photo.
+const photoMultipartOverhead = 64 << 10
+
+// IsSupportedPhotoType reports whether contentType is a photo format the upload handler can store.
+func IsSupportedPhotoType(contentType string) bool {
+	return photoContentTypes[contentType]
+}
+
+// photoTypeAllowed reports whether the sniffed contentType may be uploaded under the configured AllowedTypes.
+func (h *PhotoHandler) photoTypeAllowed(contentType string) bool {
+	if len(h.cfg.AllowedTypes) == 0 {
+		return true
+	}
+	for _, t := range h.cfg.AllowedTypes {
+		if t == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// thumbnailFilename derives the thumbnail filename from the original photo filename.
+// Thumbnails are always JPEG, e.g. "abc.png" -> "abc_thumb.jpg".
+func thumbnailFilename(filename string) string {
+	return strings.TrimSuffix(filename, filepath.Ext(filename)) + "_thumb.jpg"
+}
+
+// resizeToWidth scales img down to the given width, keeping the aspect ratio.
+// Each destination pixel is the average of the source pixels it covers. Images that are
+// already narrow enough are returned unchanged.
+func resizeToWidth(img image.Image, width int) image.Image {
+	b := img.Bounds()
+	if width <= 0 || b.Dx() <= width {
+		return img
+	}
+	height := b.Dy() * width / b.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		sy0 := b.Min.Y + y*b.Dy()/height
+		sy1 := b.Min.Y + (y+1)*b.Dy()/height
+		for x := 0; x < width; x++ {
+			sx0 := b.Min.X + x*b.Dx()/width
+			sx1 := b.Min.X + (x+1)*b.Dx()/width
+			var r, g, bl, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					pr, pg, pb, pa := img.At(sx, sy).RGBA()
+					r, g, bl, a = r+uint64(pr), g+uint64(pg), bl+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(bl / n), A: uint16(a / n)})
+		}
+	}
+	return dst
+}
+
+// photoTooLargeMessage describes the configured upload limit for 413 responses.
+func (h *PhotoHandler) photoTooLargeMessage() string {
+	return "Photo exceeds the maximum size of " + strconv.FormatInt(h.cfg.MaxBytes, 10) + " bytes"
+}
+
+// UploadRecipePhoto handles uploading a photo for a recipe.
+// @Summary Upload a recipe photo
+// @Description Upload a JPEG, PNG or GIF photo for a recipe. A JPEG thumbnail is generated alongside it and any previous photo is replaced.
+// @Tags recipes
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Recipe ID (UUID)"
+// @Param photo formData file true "Photo file"
+// @Success 200 {object} models.RecipePhotoResponse
+// @Failure 400 {object} APIError "Invalid ID or photo, or photo larger than MAX_PHOTO_PIXELS"
+// @Failure 404 {object} APIError "Recipe not found"
+// @Failure 413 {object} APIError "Photo larger than MAX_PHOTO_BYTES"
+// @Failure 415 {object} APIError "Photo type not allowed or not matching its declared type"
+// @Failure 500 {object} APIError "Server error"
+// @Router /recipes/{id}/photo [post]
+func (h *PhotoHandler) UploadRecipePhoto(c *gin.Context) {
+	idStr := c.Param("id")
+	recipeID, err := uuid.Parse(idStr)
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid recipe ID format: "+err.Error())
+		return
+	}
+
+	// Cap the body before the multipart form is parsed, so oversized uploads never reach the temp files it spills to disk.
+	if h.cfg.MaxBytes > 0 {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.cfg.MaxBytes+photoMultipartOverhead)
+	}
+	fileHeader, err := c.FormFile("photo")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			RespondWithError(c, http.StatusRequestEntityTooLarge, ErrCodePhotoTooLarge, h.photoTooLargeMessage())
+			return
+		}
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPayload, "Missing photo file: "+err.Error())
+		return
+	}
+	if h.cfg.MaxBytes > 0 && fileHeader.Size > h.cfg.MaxBytes {
+		RespondWithError(c, http.StatusRequestEntityTooLarge, ErrCodePhotoTooLarge, h.photoTooLargeMessage())
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPayload, "Failed to read photo file: "+err.Error())
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPayload, "Failed to read photo file: "+err.Error())
+		return
+	}
+
+	sniffed := http.DetectContentType(data)
+	if !h.photoTypeAllowed(sniffed) {
+		RespondWithError(c, http.StatusUnsupportedMediaType, ErrCodeUnsupportedPhoto, "Photo type not allowed: "+sniffed)
+		return
+	}
+	// A declared type other than the generic octet-stream must match the content, so a renamed file can't pass as another format.
+	if declared, _, err := mime.ParseMediaType(fileHeader.Header.Get("Content-Type")); err == nil &&
+		declared != "application/octet-stream" && declared != sniffed {
+		RespondWithError(c, http.StatusUnsupportedMediaType, ErrCodeUnsupportedPhoto,
+			"Photo declared as "+declared+" but its content is "+sniffed)
+		return
+	}
+
+	// The header is read first: a small, highly compressed file can still decode to gigabytes of pixels.
+	imgCfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPhoto, "Photo is not a supported image: "+err.Error())
+		return
+	}
+	if imgCfg.Width > maxPhotoDimension || imgCfg.Height > maxPhotoDimension {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPhoto,
+			fmt.Sprintf("Photo is %dx%d pixels; at most %d pixels per side are accepted", imgCfg.Width, imgCfg.Height, maxPhotoDimension))
+		return
+	}
+	if h.cfg.MaxPixels > 0 && int64(imgCfg.Width)*int64(imgCfg.Height) > h.cfg.MaxPixels {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPhoto,
+			fmt.Sprintf("Photo is %dx%d pixels; at most %d pixels in total are accepted", imgCfg.Width, imgCfg.Height, h.cfg.MaxPixels))
+		return
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPhoto, "Photo is not a supported image: "+err.Error())
+		return
+	}
+	ext, ok := photoExtensions[format]
+	if !ok {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPhoto, "Unsupported photo format: "+format)
+		return
+	}
+
+	previous, err := h.store.GetRecipePhoto(c.Request.Context(), recipeID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") { // Basic check
+			RespondWithError(c, http.StatusNotFound, ErrCodeRecipeNotFound, "Recipe not found: "+err.Error())
+		} else {
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to get recipe photo: "+err.Error())
+		}
+		return
+	}
+
+	filename := recipeID.String() + ext
+	thumbName := thumbnailFilename(filename)
+	if err := os.MkdirAll(h.cfg.Dir, 0o755); err != nil {
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeStorageError, "Failed to prepare photo directory: "+err.Error())
+		return
+	}
+	if err := os.WriteFile(filepath.Join(h.cfg.Dir, filename), data, 0o644); err != nil {
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeStorageError, "Failed to store photo: "+err.Error())
+		return
+	}
+	var thumb bytes.Buffer
+	if err := jpeg.Encode(&thumb, resizeToWidth(img, h.cfg.ThumbnailWidth), &jpeg.Options{Quality: 80}); err != nil {
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeStorageError, "Failed to generate thumbnail: "+err.Error())
+		return
+	}
+	if err := os.WriteFile(filepath.Join(h.cfg.Dir, thumbName), thumb.Bytes(), 0o644); err != nil {
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeStorageError, "Failed to store thumbnail: "+err.Error())
+		return
+	}
+
+	if err := h.store.SetRecipePhoto(c.Request.Context(), recipeID, filename); err != nil {
+		if strings.Contains(err.Error(), "not found") { // Basic check
+			RespondWithError(c, http.StatusNotFound, ErrCodeRecipeNotFound, "Recipe not found: "+err.Error())
+		} else {
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to save recipe photo: "+err.Error())
+		}
+		return
+	}
+
+	// A replaced photo with a different extension would otherwise be left behind.
+	if previous != nil && filepath.Base(*previous) != filename {
+		old := filepath.Base(*previous)
+		os.Remove(filepath.Join(h.cfg.Dir, old))
+		os.Remove(filepath.Join(h.cfg.Dir, thumbnailFilename(old)))
+	}
+
+	RespondWithJSON(c, http.StatusOK, models.RecipePhotoResponse{PhotoFilename: filename, ThumbnailFilename: thumbName})
+}
+
+// GetRecipePhoto handles serving the photo of a recipe.
+// @Summary Get a recipe photo
+// @Description Serve the photo of a recipe, or its thumbnail with size=thumb.
+// @Tags recipes
+// @Produce image/jpeg,image/png,image/gif
+// @Param id path string true "Recipe ID (UUID)"
+// @Param size query string false "Photo size" Enums(original, thumb)
+// @Success 200 {file} file
+// @Failure 400 {object} APIError "Invalid ID or size"
+// @Failure 404 {object} APIError "Recipe or photo not found"
+// @Failure 500 {object} APIError "Server error"
+// @Router /recipes/{id}/photo [get]
+func (h *PhotoHandler) GetRecipePhoto(c *gin.Context) {
+	idStr := c.Param("id")
+	recipeID, err := uuid.Parse(idStr)
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid recipe ID format: "+err.Error())
+		return
+	}
+
+	size := c.DefaultQuery("size", "original")
+	if size != "original" && size != "thumb" {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid size: must be one of original, thumb")
+		return
+	}
+
+	photo, err := h.store.GetRecipePhoto(c.Request.Context(), recipeID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") { // Basic check
+			RespondWithError(c, http.StatusNotFound, ErrCodeRecipeNotFound, "Recipe not found: "+err.Error())
+		} else {
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to get recipe photo: "+err.Error())
+		}
+		return
+	}
+	if photo == nil {
+		RespondWithError(c, http.StatusNotFound, ErrCodePhotoNotFound, "Recipe has no photo")
+		return
+	}
+
+	// photo_filename can also be set through the recipe payload, so never let it escape the photo directory.
+	filename := filepath.Base(*photo)
+	if size == "thumb" {
+		filename = thumbnailFilename(filename)
+	}
+	path := filepath.Join(h.cfg.Dir, filename)
+	if _, err := os.Stat(path); err != nil {
+		RespondWithError(c, http.StatusNotFound, ErrCodePhotoNotFound, "Photo file not found")
+		return
+	}
+	c.File(path)
+}
@@ -0,0 +1,288 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gaanon/gorecipes_v2/config"
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store/mocks"
+)
+
+// Helper function to create a new Gin engine for photo handler tests
+func setupPhotoTestRouter(handler *PhotoHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	api := router.Group("/api/v1")
+	{
+		api.POST("/recipes/:id/photo", handler.UploadRecipePhoto)
+		api.GET("/recipes/:id/photo", handler.GetRecipePhoto)
+	}
+	return router
+}
+
+func newPhotoUploadRequest(t *testing.T, recipeID uuid.UUID, data []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("photo", "photo.png")
+	assert.NoError(t, err)
+	_, err = part.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes/"+recipeID.String()+"/photo", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestPhotoHandler_UploadRecipePhoto_GeneratesThumbnail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := t.TempDir()
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	router := setupPhotoTestRouter(NewPhotoHandler(mockStore, config.PhotoConfig{Dir: dir, ThumbnailWidth: 30}))
+
+	src := image.NewRGBA(image.Rect(0, 0, 120, 60))
+	for y := 0; y < 60; y++ {
+		for x := 0; x < 120; x++ {
+			src.Set(x, y, color.RGBA{R: 200, G: 100, B: 50, A: 255})
+		}
+	}
+	var pngData bytes.Buffer
+	assert.NoError(t, png.Encode(&pngData, src))
+
+	recipeID := uuid.New()
+	filename := recipeID.String() + ".png"
+	mockStore.EXPECT().GetRecipePhoto(gomock.Any(), recipeID).Return(nil, nil).Times(1)
+	mockStore.EXPECT().SetRecipePhoto(gomock.Any(), recipeID, filename).Return(nil).Times(1)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newPhotoUploadRequest(t, recipeID, pngData.Bytes()))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp models.RecipePhotoResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, filename, resp.PhotoFilename)
+	assert.Equal(t, recipeID.String()+"_thumb.jpg", resp.ThumbnailFilename)
+
+	original, err := os.ReadFile(filepath.Join(dir, filename))
+	assert.NoError(t, err)
+	assert.Equal(t, pngData.Bytes(), original)
+
+	thumbFile, err := os.Open(filepath.Join(dir, resp.ThumbnailFilename))
+	assert.NoError(t, err)
+	defer thumbFile.Close()
+	thumb, err := jpeg.Decode(thumbFile)
+	assert.NoError(t, err)
+	assert.Equal(t, 30, thumb.Bounds().Dx())
+	assert.Equal(t, 15, thumb.Bounds().Dy())
+}
+
+func TestPhotoHandler_UploadRecipePhoto_RejectsNonImage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	router := setupPhotoTestRouter(NewPhotoHandler(mockStore, config.PhotoConfig{Dir: t.TempDir(), ThumbnailWidth: 30}))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newPhotoUploadRequest(t, uuid.New(), []byte("not an image")))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var errorResponse APIError
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+	assert.Equal(t, ErrCodeInvalidPhoto, errorResponse.Code)
+}
+
+func newTypedPhotoUploadRequest(t *testing.T, recipeID uuid.UUID, contentType string, data []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="photo"; filename="photo.jpg"`)
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	assert.NoError(t, err)
+	_, err = part.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes/"+recipeID.String()+"/photo", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func testPNG(t *testing.T) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 4))))
+	return buf.Bytes()
+}
+
+func TestPhotoHandler_UploadRecipePhoto_Limits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name           string
+		cfg            config.PhotoConfig
+		request        func(uuid.UUID) *http.Request
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name: "file over MaxBytes",
+			cfg:  config.PhotoConfig{MaxBytes: 16},
+			request: func(id uuid.UUID) *http.Request {
+				return newPhotoUploadRequest(t, id, testPNG(t))
+			},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedCode:   ErrCodePhotoTooLarge,
+		},
+		{
+			name: "body far over MaxBytes",
+			cfg:  config.PhotoConfig{MaxBytes: 16},
+			request: func(id uuid.UUID) *http.Request {
+				return newPhotoUploadRequest(t, id, bytes.Repeat([]byte{0}, photoMultipartOverhead*2))
+			},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedCode:   ErrCodePhotoTooLarge,
+		},
+		{
+			name: "pixels over MaxPixels",
+			cfg:  config.PhotoConfig{MaxPixels: 15},
+			request: func(id uuid.UUID) *http.Request {
+				return newPhotoUploadRequest(t, id, testPNG(t)) // 4x4
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   ErrCodeInvalidPhoto,
+		},
+		{
+			name: "type not allowed",
+			cfg:  config.PhotoConfig{AllowedTypes: []string{"image/jpeg"}},
+			request: func(id uuid.UUID) *http.Request {
+				return newPhotoUploadRequest(t, id, testPNG(t))
+			},
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedCode:   ErrCodeUnsupportedPhoto,
+		},
+		{
+			name: "declared type does not match content",
+			cfg:  config.PhotoConfig{},
+			request: func(id uuid.UUID) *http.Request {
+				return newTypedPhotoUploadRequest(t, id, "image/jpeg", testPNG(t))
+			},
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedCode:   ErrCodeUnsupportedPhoto,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := mocks.NewMockRecipeStore(ctrl)
+			tt.cfg.Dir = t.TempDir()
+			router := setupPhotoTestRouter(NewPhotoHandler(mockStore, tt.cfg))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, tt.request(uuid.New()))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var errorResponse APIError
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+			assert.Equal(t, tt.expectedCode, errorResponse.Code)
+			entries, err := os.ReadDir(tt.cfg.Dir)
+			assert.NoError(t, err)
+			assert.Empty(t, entries)
+		})
+	}
+}
+
+func TestPhotoHandler_UploadRecipePhoto_MatchingDeclaredType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	router := setupPhotoTestRouter(NewPhotoHandler(mockStore, config.PhotoConfig{
+		Dir: t.TempDir(), ThumbnailWidth: 30, MaxBytes: 1 << 20, AllowedTypes: []string{"image/png"},
+	}))
+
+	recipeID := uuid.New()
+	mockStore.EXPECT().GetRecipePhoto(gomock.Any(), recipeID).Return(nil, nil).Times(1)
+	mockStore.EXPECT().SetRecipePhoto(gomock.Any(), recipeID, recipeID.String()+".png").Return(nil).Times(1)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newTypedPhotoUploadRequest(t, recipeID, "image/png", testPNG(t)))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestPhotoHandler_UploadRecipePhoto_RejectsOversizedDimensions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	dir := t.TempDir()
+	router := setupPhotoTestRouter(NewPhotoHandler(mockStore, config.PhotoConfig{Dir: dir, ThumbnailWidth: 30}))
+
+	// One pixel too wide; it compresses to a few hundred bytes.
+	var pngData bytes.Buffer
+	assert.NoError(t, png.Encode(&pngData, image.NewGray(image.Rect(0, 0, maxPhotoDimension+1, 1))))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newPhotoUploadRequest(t, uuid.New(), pngData.Bytes()))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var errorResponse APIError
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+	assert.Equal(t, ErrCodeInvalidPhoto, errorResponse.Code)
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestPhotoHandler_GetRecipePhoto(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir := t.TempDir()
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	router := setupPhotoTestRouter(NewPhotoHandler(mockStore, config.PhotoConfig{Dir: dir, ThumbnailWidth: 30}))
+
+	recipeID := uuid.New()
+	filename := recipeID.String() + ".jpg"
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, filename), []byte("original"), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, thumbnailFilename(filename)), []byte("thumb"), 0o644))
+	mockStore.EXPECT().GetRecipePhoto(gomock.Any(), recipeID).Return(&filename, nil).Times(2)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes/"+recipeID.String()+"/photo?size=thumb", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "thumb", w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/recipes/"+recipeID.String()+"/photo", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "original", w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/recipes/"+recipeID.String()+"/photo?size=huge", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
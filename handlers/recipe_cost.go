@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/gaanon/gorecipes_v2/models"
+)
+
+// GetRecipeCost handles estimating what a recipe costs to make.
+// @Summary Estimate a recipe's cost
+// @Description Multiply each ingredient's quantity by its unit price (see PUT /ingredients/{id}/price), converting between
+// @Description units that share a base unit, e.g. 500 g priced per kg. A quantity range is costed at its midpoint.
+// @Description Ingredients without a price, without a quantity, or in a unit that cannot be converted are left out of
+// @Description the total and flagged with a reason; complete tells whether any were. Costs are rounded to 2 decimals.
+// @Tags recipes
+// @Produce json
+// @Param id path string true "Recipe ID (UUID)"
+// @Success 200 {object} models.RecipeCost
+// @Failure 400 {object} APIError "Invalid ID format"
+// @Failure 404 {object} APIError "Recipe not found"
+// @Failure 500 {object} APIError "Server error"
+// @Router /recipes/{id}/cost [get]
+func (h *RecipeHandler) GetRecipeCost(c *gin.Context) {
+	recipeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid recipe ID format: "+err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	recipe, err := h.store.GetRecipeByID(ctx, recipeID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") { // Basic check
+			RespondWithError(c, http.StatusNotFound, ErrCodeRecipeNotFound, "Recipe not found: "+err.Error())
+		} else {
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to get recipe: "+err.Error())
+		}
+		return
+	}
+	prices, err := h.store.IngredientPrices(ctx, recipeID)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to get ingredient prices: "+err.Error())
+		return
+	}
+	units, err := h.store.ListConversionUnits(ctx)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to get measurement units: "+err.Error())
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, computeRecipeCost(recipe, prices, units))
+}
+
+// computeRecipeCost prices each of the recipe's ingredients. A quantity converts to the price's unit when
+// both units share a base unit (see unitBase); a quantity and a price without units are counts of items.
+func computeRecipeCost(recipe *models.Recipe, prices []models.IngredientPrice, units []*models.MeasurementUnit) models.RecipeCost {
+	priceByIngredient := make(map[uuid.UUID]models.IngredientPrice, len(prices))
+	for _, price := range prices {
+		priceByIngredient[price.IngredientID] = price
+	}
+	unitByID := make(map[uuid.UUID]*models.MeasurementUnit, len(units))
+	for _, unit := range units {
+		unitByID[*unit.ID] = unit
+	}
+
+	cost := models.RecipeCost{
+		RecipeID:    recipe.ID,
+		Serves:      recipe.Serves,
+		Complete:    true,
+		Ingredients: make([]models.IngredientCost, 0, len(recipe.Ingredients)),
+	}
+	var total float64
+	for _, ing := range recipe.Ingredients {
+		line := models.IngredientCost{IngredientID: ing.IngredientID, IngredientName: ing.IngredientName}
+		amount, reason := ingredientCost(ing, priceByIngredient, unitByID)
+		if reason != "" {
+			line.Excluded, line.Reason = true, reason
+			cost.Complete = false
+		} else {
+			total += amount
+			rounded := roundMoney(amount)
+			line.Cost = &rounded
+		}
+		cost.Ingredients = append(cost.Ingredients, line)
+	}
+
+	cost.TotalCost = roundMoney(total)
+	if recipe.Serves != nil && *recipe.Serves > 0 {
+		perServing := roundMoney(total / float64(*recipe.Serves))
+		cost.CostPerServing = &perServing
+	}
+	return cost
+}
+
+// ingredientCost returns the cost of one recipe ingredient, or the reason it cannot be priced.
+func ingredientCost(ing models.RecipeIngredient, prices map[uuid.UUID]models.IngredientPrice, units map[uuid.UUID]*models.MeasurementUnit) (float64, string) {
+	price, ok := prices[ing.IngredientID]
+	if !ok {
+		return 0, models.CostExcludedNoPrice
+	}
+	var quantity float64
+	switch {
+	case ing.Quantity != nil:
+		quantity = *ing.Quantity
+	case ing.QuantityMin != nil && ing.QuantityMax != nil:
+		quantity = (*ing.QuantityMin + *ing.QuantityMax) / 2
+	case ing.QuantityMin != nil:
+		quantity = *ing.QuantityMin
+	default:
+		return 0, models.CostExcludedNoQuantity
+	}
+
+	if ing.UnitID == nil && price.PriceUnitID == nil {
+		return quantity * price.UnitPrice, ""
+	}
+	if ing.UnitID == nil || price.PriceUnitID == nil {
+		return 0, models.CostExcludedUnitMismatch
+	}
+	quantityUnit, ok := units[*ing.UnitID]
+	if !ok {
+		return 0, models.CostExcludedUnitMismatch
+	}
+	priceUnit, ok := units[*price.PriceUnitID]
+	if !ok {
+		return 0, models.CostExcludedUnitMismatch
+	}
+	quantityBase, quantityFactor := unitBase(quantityUnit)
+	priceBase, priceFactor := unitBase(priceUnit)
+	if quantityBase != priceBase {
+		return 0, models.CostExcludedUnitMismatch
+	}
+	return quantity * quantityFactor / priceFactor * price.UnitPrice, ""
+}
+
+// roundMoney rounds an amount of money to 2 decimals.
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gaanon/gorecipes_v2/config"
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store/mocks"
+)
+
+func TestComputeRecipeCost(t *testing.T) {
+	gram := testUnit("gram", nil, 0)
+	kilogram := testUnit("kilogram", gram.ID, 1000)
+	millilitre := testUnit("millilitre", nil, 0)
+	units := []*models.MeasurementUnit{gram, kilogram, millilitre}
+
+	flour, eggs, milk, butter, salt, sugar := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	recipe := &models.Recipe{ID: uuid.New(), Serves: intPtr(4), Ingredients: []models.RecipeIngredient{
+		{IngredientID: flour, IngredientName: strPtr("flour"), Quantity: float64Ptr(500), UnitID: gram.ID},
+		{IngredientID: eggs, IngredientName: strPtr("eggs"), QuantityMin: float64Ptr(2), QuantityMax: float64Ptr(3)},
+		{IngredientID: milk, IngredientName: strPtr("milk"), Quantity: float64Ptr(250), UnitID: millilitre.ID},
+		{IngredientID: butter, IngredientName: strPtr("butter"), Quantity: float64Ptr(50), UnitID: gram.ID},
+		{IngredientID: salt, IngredientName: strPtr("salt")},
+		{IngredientID: sugar, IngredientName: strPtr("sugar"), Quantity: float64Ptr(100), UnitID: gram.ID},
+	}}
+	prices := []models.IngredientPrice{
+		{IngredientID: flour, UnitPrice: 2.4, PriceUnitID: kilogram.ID}, // 500 g at 2.40/kg
+		{IngredientID: eggs, UnitPrice: 0.3},                            // Per egg
+		{IngredientID: milk, UnitPrice: 1.2, PriceUnitID: kilogram.ID},  // Millilitres cannot become kilograms
+		{IngredientID: salt, UnitPrice: 0.5, PriceUnitID: gram.ID},
+		{IngredientID: sugar, UnitPrice: 0.01, PriceUnitID: gram.ID},
+	}
+
+	cost := computeRecipeCost(recipe, prices, units)
+	assert.Equal(t, recipe.ID, cost.RecipeID)
+	assert.False(t, cost.Complete)
+	assert.Equal(t, 2.95, cost.TotalCost) // 1.20 + 0.75 + 1.00
+	assert.Equal(t, 0.74, *cost.CostPerServing)
+
+	ings := cost.Ingredients
+	assert.Len(t, ings, 6)
+	assert.Equal(t, 1.2, *ings[0].Cost)
+	assert.Equal(t, 0.75, *ings[1].Cost) // Midpoint of 2-3 eggs
+	assert.Equal(t, models.CostExcludedUnitMismatch, ings[2].Reason)
+	assert.Equal(t, models.CostExcludedNoPrice, ings[3].Reason)
+	assert.Equal(t, models.CostExcludedNoQuantity, ings[4].Reason)
+	assert.Equal(t, 1.0, *ings[5].Cost)
+	for i, want := range []bool{false, false, true, true, true, false} {
+		assert.Equal(t, want, ings[i].Excluded, *ings[i].IngredientName)
+	}
+
+	recipe.Serves = nil
+	recipe.Ingredients = recipe.Ingredients[:1]
+	cost = computeRecipeCost(recipe, prices, units)
+	assert.True(t, cost.Complete)
+	assert.Nil(t, cost.CostPerServing)
+}
+
+func TestRecipeHandler_GetRecipeCost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	router := setupTestRouter(NewRecipeHandler(mockStore, config.APIConfig{}))
+
+	recipeID, eggs := uuid.New(), uuid.New()
+	mockStore.EXPECT().GetRecipeByID(gomock.Any(), recipeID).Return(&models.Recipe{ID: recipeID, Serves: intPtr(2), Ingredients: []models.RecipeIngredient{
+		{IngredientID: eggs, IngredientName: strPtr("eggs"), Quantity: float64Ptr(3)},
+	}}, nil).Times(1)
+	mockStore.EXPECT().IngredientPrices(gomock.Any(), recipeID).Return([]models.IngredientPrice{{IngredientID: eggs, UnitPrice: 0.25}}, nil).Times(1)
+	mockStore.EXPECT().ListConversionUnits(gomock.Any()).Return(nil, nil).Times(1)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes/"+recipeID.String()+"/cost", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var cost models.RecipeCost
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &cost))
+	assert.Equal(t, 0.75, cost.TotalCost)
+	assert.Equal(t, 0.38, *cost.CostPerServing)
+	assert.True(t, cost.Complete)
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/recipes/not-a-uuid/cost", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
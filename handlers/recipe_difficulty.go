@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/gaanon/gorecipes_v2/models"
+)
+
+// GetDifficultyEstimate handles estimating how hard a recipe is to make.
+// @Summary Estimate a recipe's difficulty
+// @Description Score a recipe from 0 to 100 from its number of steps and ingredients, its total time and whether any step
+// @Description needs a temperature, with each factor's contribution. Scores below 35 are easy, from 65 hard, medium in between.
+// @Description The estimate is computed on each request and never stored; the recipe's own difficulty, if set, is returned as manual.
+// @Tags recipes
+// @Produce json
+// @Param id path string true "Recipe ID (UUID)"
+// @Success 200 {object} models.DifficultyEstimate
+// @Failure 400 {object} APIError "Invalid ID format"
+// @Failure 404 {object} APIError "Recipe not found"
+// @Failure 500 {object} APIError "Server error"
+// @Router /recipes/{id}/difficulty-estimate [get]
+func (h *RecipeHandler) GetDifficultyEstimate(c *gin.Context) {
+	recipeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid recipe ID format: "+err.Error())
+		return
+	}
+
+	recipe, err := h.store.GetRecipeByID(c.Request.Context(), recipeID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") { // Basic check
+			RespondWithError(c, http.StatusNotFound, ErrCodeRecipeNotFound, "Recipe not found: "+err.Error())
+		} else {
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to get recipe: "+err.Error())
+		}
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, models.EstimateDifficulty(recipe))
+}
@@ -1,124 +1,556 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/gaanon/gorecipes_v2/config"
 	"github.com/gaanon/gorecipes_v2/models"
 	"github.com/gaanon/gorecipes_v2/store"
 )
 
 // Global validator instance
-var validate = validator.New()
+var validate = newValidator()
 
 // RecipeHandler handles HTTP requests for recipes.
 type RecipeHandler struct {
 	store store.RecipeStore
+	cfg   config.APIConfig
 }
 
 // NewRecipeHandler creates a new RecipeHandler.
-func NewRecipeHandler(store store.RecipeStore) *RecipeHandler {
-	return &RecipeHandler{store: store}
+func NewRecipeHandler(store store.RecipeStore, cfg config.APIConfig) *RecipeHandler {
+	return &RecipeHandler{store: store, cfg: cfg}
+}
+
+// validateRecipeRequest runs the struct validators plus the deployment-configurable rules
+// shared by create and update. It returns nil when the request is valid.
+// Ingredient quantity_text is parsed into Quantity here, so the store only ever sees numbers.
+// All strings are trimmed first, so "  Soup  " is validated and stored as "Soup".
+// In strict mode, ingredient lines that exactly repeat an earlier line are rejected as copy-paste mistakes.
+func (h *RecipeHandler) validateRecipeRequest(req *models.RecipeRequest, strict bool) map[string]string {
+	trimStrings(req)
+	assignIngredientSortOrders(req.Ingredients)
+	validationErrors := map[string]string{}
+	if err := validate.Struct(req); err != nil {
+		validationErrors = formatValidationErrors(err)
+	}
+	if h.cfg.RequireIngredientsAndSteps {
+		if len(req.Ingredients) == 0 {
+			validationErrors["Ingredients"] = "at least one ingredient is required"
+		}
+		if len(req.Steps) == 0 {
+			validationErrors["Steps"] = "at least one step is required"
+		}
+	}
+	for i := range req.Ingredients {
+		h.checkIngredientRequest(&req.Ingredients[i], fmt.Sprintf("Ingredients[%d].", i), validationErrors)
+	}
+	if limit := h.cfg.MaxNameLength; limit > 0 {
+		for i, tag := range req.Tags {
+			if utf8.RuneCountInString(tag.Name) > limit {
+				validationErrors[fmt.Sprintf("Tags[%d].Name", i)] = fmt.Sprintf("must be at most %d characters", limit)
+			}
+		}
+	}
+	for i, step := range req.Steps {
+		if step.DurationMinutes != nil && step.DurationSeconds != nil && *step.DurationSeconds > 59 {
+			validationErrors[fmt.Sprintf("Steps[%d].DurationSeconds", i)] = "must be between 0 and 59 when duration_minutes is also given"
+		}
+	}
+	if strict {
+		for field, msg := range duplicateIngredientLines(req.Ingredients) {
+			validationErrors[field] = msg
+		}
+	}
+	if len(validationErrors) == 0 {
+		return nil
+	}
+	return validationErrors
+}
+
+// duplicateIngredientLines reports each ingredient line with the same ingredient, quantity (or range) and unit
+// as an earlier line, keyed like validation errors, e.g. "Ingredients[3]". sort_order is not compared, since
+// it differs between any two lines.
+func duplicateIngredientLines(ingredients []models.RecipeIngredientRequest) map[string]string {
+	type lineKey struct {
+		name, unit  string
+		id          uuid.UUID
+		quantity    float64
+		hasQuantity bool
+		rangeMin    float64
+		rangeMax    float64
+	}
+	first := make(map[lineKey]int, len(ingredients))
+	duplicates := map[string]string{}
+	for i, ing := range ingredients {
+		key := lineKey{name: ing.IngredientName}
+		if ing.IngredientID != nil {
+			key.id = *ing.IngredientID
+		}
+		if ing.UnitName != nil {
+			key.unit = *ing.UnitName
+		}
+		if ing.Quantity != nil {
+			key.quantity, key.hasQuantity = *ing.Quantity, true
+		}
+		if ing.QuantityMin != nil && ing.QuantityMax != nil {
+			key.rangeMin, key.rangeMax = *ing.QuantityMin, *ing.QuantityMax
+		}
+		if j, seen := first[key]; seen {
+			duplicates[fmt.Sprintf("Ingredients[%d]", i)] = fmt.Sprintf("duplicates ingredients[%d] (same ingredient, quantity and unit)", j)
+			continue
+		}
+		first[key] = i
+	}
+	return duplicates
+}
+
+// checkIngredientRequest runs the checks on an ingredient line that its validate tags cannot express,
+// recording failures in validationErrors under field names starting with prefix. A valid quantity_text
+// is parsed into Quantity.
+func (h *RecipeHandler) checkIngredientRequest(ing *models.RecipeIngredientRequest, prefix string, validationErrors map[string]string) {
+	if ing.QuantityMin != nil && ing.QuantityMax != nil && *ing.QuantityMin > *ing.QuantityMax {
+		validationErrors[prefix+"QuantityMax"] = "must not be less than quantity_min"
+	}
+	if ing.QuantityText != nil && ing.Quantity == nil {
+		quantity, err := models.ParseQuantity(*ing.QuantityText)
+		switch {
+		case err != nil:
+			validationErrors[prefix+"QuantityText"] = err.Error()
+		case quantity <= 0:
+			validationErrors[prefix+"QuantityText"] = "must be greater than zero"
+		default:
+			ing.Quantity = &quantity
+		}
+	}
+	if limit := h.cfg.MaxNameLength; limit > 0 {
+		if utf8.RuneCountInString(ing.IngredientName) > limit {
+			validationErrors[prefix+"IngredientName"] = fmt.Sprintf("must be at most %d characters", limit)
+		}
+		if ing.UnitName != nil && utf8.RuneCountInString(*ing.UnitName) > limit {
+			validationErrors[prefix+"UnitName"] = fmt.Sprintf("must be at most %d characters", limit)
+		}
+	}
+}
+
+// respondWithStoreError writes a recipe write failure. When the store reports which ingredient,
+// step, tag or equipment failed, that is returned in details as {"relation", "index", "name"}.
+func respondWithStoreError(c *gin.Context, code int, errCode string, prefix string, err error) {
+	var relErr *store.RelationError
+	if errors.As(err, &relErr) {
+		RespondWithDetailedError(c, code, errCode, prefix+err.Error(), relErr)
+		return
+	}
+	RespondWithError(c, code, errCode, prefix+err.Error())
 }
 
 // CreateRecipe handles the creation of a new recipe.
 // @Summary Create a new recipe
 // @Description Create a new recipe with ingredients, steps, and tags.
+// @Description An ingredient unit_name needs a quantity. Suspicious but valid input (a cook time of 0, no steps, a quantity without a unit) is reported in warnings, also when validation fails.
 // @Tags recipes
 // @Accept json
 // @Produce json
 // @Param recipe body models.RecipeRequest true "Recipe to create"
+// @Param strict query bool false "Reject ingredient lines that exactly repeat an earlier one"
 // @Success 201 {object} models.Recipe
-// @Failure 400 {object} APIError "Invalid input"
+// @Failure 400 {object} APIError "Invalid input or unknown ingredient ID"
+// @Failure 409 {object} APIError "An ingredient listed twice (names match case-insensitively), or a concurrent slug clash"
+// @Failure 422 {object} APIError "created_by is missing and the server requires it"
 // @Failure 500 {object} APIError "Server error"
+// @Header 201 {string} Location "URL of the created recipe"
 // @Router /recipes [post]
 func (h *RecipeHandler) CreateRecipe(c *gin.Context) {
+	strict, err := strconv.ParseBool(c.DefaultQuery("strict", "false"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid strict: must be a boolean")
+		return
+	}
+
 	var req models.RecipeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondWithError(c, http.StatusBadRequest, "Invalid request payload: "+err.Error())
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPayload, "Invalid request payload: "+err.Error())
 		return
 	}
 
 	// Validate the request
-	if err := validate.Struct(req); err != nil {
-		validationErrors := formatValidationErrors(err)
-		RespondWithDetailedError(c, http.StatusBadRequest, "Validation failed", validationErrors)
+	if validationErrors := h.validateRecipeRequest(&req, strict); validationErrors != nil {
+		RespondWithValidationErrors(c, http.StatusBadRequest, validationErrors, collectWarnings(&req))
+		return
+	}
+	if h.cfg.RequireCreatedBy && (req.CreatedBy == nil || *req.CreatedBy == uuid.Nil) {
+		RespondWithDetailedError(c, http.StatusUnprocessableEntity, ErrCodeValidationFailed, "Validation failed",
+			map[string]string{"CreatedBy": "is required on this server"})
 		return
 	}
+	if req.CreatedBy == nil || *req.CreatedBy == uuid.Nil {
+		req.CreatedBy = h.defaultCreatedBy(c)
+	}
 
 	recipe, err := h.store.CreateRecipe(c.Request.Context(), &req)
 	if err != nil {
+		if errors.Is(err, store.ErrUnknownIngredient) {
+			respondWithStoreError(c, http.StatusBadRequest, ErrCodeUnknownIngredient, "Invalid ingredient reference: ", err)
+			return
+		}
+		if errors.Is(err, store.ErrConflict) {
+			respondWithStoreError(c, http.StatusConflict, ErrCodeConflict, "Failed to create recipe: ", err)
+			return
+		}
 		// More specific error handling can be added here based on error types from store
-		RespondWithError(c, http.StatusInternalServerError, "Failed to create recipe: "+err.Error())
+		respondWithStoreError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to create recipe: ", err)
 		return
 	}
+	h.roundQuantities(recipe)
+	recipe.Warnings = collectWarnings(&req)
+	markOwnership(c, recipe)
+	// FullPath is the matched route, so the URL carries whatever prefix the API is mounted under.
+	c.Header("Location", c.FullPath()+"/"+recipe.ID.String())
 	RespondWithJSON(c, http.StatusCreated, recipe)
 }
 
 // GetRecipe handles fetching a single recipe by its ID.
 // @Summary Get a recipe by ID
 // @Description Get a single recipe by its UUID, including ingredients, steps, and tags.
+// @Description The title and description are translated when a translation exists for ?lang= or Accept-Language.
 // @Tags recipes
 // @Produce json
 // @Param id path string true "Recipe ID (UUID)"
+// @Param lang query string false "Preferred language (e.g. fr); overrides Accept-Language"
+// @Param group_ingredients query bool false "Return ingredients grouped by category in ingredient_groups instead of ingredients"
+// @Param units query string false "Convert ingredient quantities to this system where conversion data allows; each ingredient's converted tells whether it was" Enums(metric, imperial)
+// @Param If-Modified-Since header string false "HTTP date; 304 is returned if the recipe has not changed since"
 // @Success 200 {object} models.Recipe
-// @Failure 400 {object} APIError "Invalid ID format"
+// @Success 304 "Not modified since If-Modified-Since"
+// @Failure 400 {object} APIError "Invalid ID format or query parameters"
 // @Failure 404 {object} APIError "Recipe not found"
 // @Failure 500 {object} APIError "Server error"
+// @Header 200 {string} Last-Modified "When the recipe (or its translation) last changed"
 // @Router /recipes/{id} [get]
 func (h *RecipeHandler) GetRecipe(c *gin.Context) {
 	idStr := c.Param("id")
 	recipeID, err := uuid.Parse(idStr)
 	if err != nil {
-		RespondWithError(c, http.StatusBadRequest, "Invalid recipe ID format: "+err.Error())
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid recipe ID format: "+err.Error())
+		return
+	}
+	groupIngredients, err := strconv.ParseBool(c.DefaultQuery("group_ingredients", "false"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid group_ingredients: must be a boolean")
+		return
+	}
+	units := models.MeasurementSystem(c.Query("units"))
+	if units != "" && units != models.Metric && units != models.Imperial {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid units: must be metric or imperial")
 		return
 	}
 
 	recipe, err := h.store.GetRecipeByID(c.Request.Context(), recipeID)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") { // Basic check, improve with custom errors
-			RespondWithError(c, http.StatusNotFound, "Recipe not found: "+err.Error())
+			RespondWithError(c, http.StatusNotFound, ErrCodeRecipeNotFound, "Recipe not found: "+err.Error())
 		} else {
-			RespondWithError(c, http.StatusInternalServerError, "Failed to get recipe: "+err.Error())
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to get recipe: "+err.Error())
 		}
 		return
 	}
+	if units != "" {
+		conversionUnits, err := h.store.ListConversionUnits(c.Request.Context())
+		if err != nil {
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to get measurement units: "+err.Error())
+			return
+		}
+		convertIngredientUnits(recipe, conversionUnits, units)
+	}
+	if groupIngredients {
+		recipe.IngredientGroups = groupIngredientsByCategory(recipe.Ingredients)
+		recipe.Ingredients = nil
+	}
+	h.respondWithRecipe(c, recipe)
+}
+
+// groupIngredientsByCategory groups ingredients by category, keeping their order within each group.
+// Groups appear in the order their category is first seen; uncategorised ingredients come last.
+func groupIngredientsByCategory(ingredients []models.RecipeIngredient) []models.IngredientGroup {
+	groups := []models.IngredientGroup{}
+	index := make(map[string]int)
+	var uncategorised []models.RecipeIngredient
+	for _, ing := range ingredients {
+		if ing.IngredientCategory == nil {
+			uncategorised = append(uncategorised, ing)
+			continue
+		}
+		i, ok := index[*ing.IngredientCategory]
+		if !ok {
+			i = len(groups)
+			index[*ing.IngredientCategory] = i
+			groups = append(groups, models.IngredientGroup{Category: ing.IngredientCategory})
+		}
+		groups[i].Ingredients = append(groups[i].Ingredients, ing)
+	}
+	if len(uncategorised) > 0 {
+		groups = append(groups, models.IngredientGroup{Ingredients: uncategorised})
+	}
+	return groups
+}
+
+// GetRecipeBySlug handles fetching a single recipe by its slug.
+// @Summary Get a recipe by slug
+// @Description Get a single recipe by its URL-friendly slug, including ingredients, steps, and tags.
+// @Description The title and description are translated when a translation exists for ?lang= or Accept-Language.
+// @Tags recipes
+// @Produce json
+// @Param slug path string true "Recipe slug, e.g. apple-pie"
+// @Param lang query string false "Preferred language (e.g. fr); overrides Accept-Language"
+// @Param If-Modified-Since header string false "HTTP date; 304 is returned if the recipe has not changed since"
+// @Success 200 {object} models.Recipe
+// @Success 304 "Not modified since If-Modified-Since"
+// @Failure 404 {object} APIError "Recipe not found"
+// @Failure 500 {object} APIError "Server error"
+// @Header 200 {string} Last-Modified "When the recipe (or its translation) last changed"
+// @Router /recipes/slug/{slug} [get]
+func (h *RecipeHandler) GetRecipeBySlug(c *gin.Context) {
+	recipe, err := h.store.GetRecipeBySlug(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") { // Basic check
+			RespondWithError(c, http.StatusNotFound, ErrCodeRecipeNotFound, "Recipe not found: "+err.Error())
+		} else {
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to get recipe: "+err.Error())
+		}
+		return
+	}
+	h.respondWithRecipe(c, recipe)
+}
+
+// respondWithRecipe writes a full recipe, overlaying the translation for the requested language if one exists.
+// Conditional requests are honoured: Last-Modified is the later of the recipe's and the translation's updated_at.
+func (h *RecipeHandler) respondWithRecipe(c *gin.Context, recipe *models.Recipe) {
+	lastModified := recipe.UpdatedAt
+	if lang := requestedLang(c); lang != "" {
+		translation, err := h.store.GetRecipeTranslation(c.Request.Context(), recipe.ID, lang)
+		if err != nil {
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to get recipe translation: "+err.Error())
+			return
+		}
+		if translation != nil {
+			recipe.Title = translation.Title
+			if translation.Description != nil {
+				recipe.Description = translation.Description
+			}
+			recipe.Lang = &translation.Lang
+			c.Header("Content-Language", translation.Lang)
+			if translation.UpdatedAt.After(lastModified) {
+				lastModified = translation.UpdatedAt
+			}
+		}
+	}
+	if checkNotModified(c, lastModified) {
+		return
+	}
+	h.roundQuantities(recipe)
+	markOwnership(c, recipe)
 	RespondWithJSON(c, http.StatusOK, recipe)
 }
 
-// ListRecipes handles fetching a list of recipes.
+// defaultQuantityDecimals is used when config.APIConfig.QuantityDecimals is unset.
+const defaultQuantityDecimals = 3
+
+// quantityDecimals returns how many decimals to round response quantities to; ok is false when rounding is disabled.
+func (h *RecipeHandler) quantityDecimals() (decimals int, ok bool) {
+	switch {
+	case h.cfg.QuantityDecimals < 0:
+		return 0, false
+	case h.cfg.QuantityDecimals == 0:
+		return defaultQuantityDecimals, true
+	default:
+		return h.cfg.QuantityDecimals, true
+	}
+}
+
+// roundQuantities rounds the recipe's ingredient quantities for display, as configured.
+func (h *RecipeHandler) roundQuantities(recipe *models.Recipe) {
+	if decimals, ok := h.quantityDecimals(); ok {
+		recipe.RoundQuantities(decimals)
+	}
+}
+
+// ListRecipes handles fetching a page of recipes.
 // @Summary List recipes
-// @Description Get a list of all recipes (basic details).
+// @Description Get a paginated list of recipes (basic details), most recently updated first unless sort or DEFAULT_RECIPE_SORT says otherwise.
+// @Description Use page/page_size for offset pagination, or pass the returned next_cursor as after for keyset pagination.
+// @Description With updated_since, only recipes changed since then are returned, oldest change first; page through large deltas with after.
 // @Tags recipes
 // @Produce json
-// @Success 200 {array} models.Recipe
+// @Param page query int false "Page number for offset pagination (default 1)"
+// @Param page_size query int false "Number of recipes per page (default 20; larger values are clamped to MAX_PAGE_SIZE, default 100)"
+// @Param after query string false "Opaque cursor from a previous response's next_cursor (keyset pagination)"
+// @Param lang query string false "Preferred language (e.g. fr); overrides Accept-Language"
+// @Param difficulty query string false "Only recipes with this difficulty" Enums(easy, medium, hard)
+// @Param min_serves query int false "Only recipes serving at least this many"
+// @Param max_serves query int false "Only recipes serving at most this many"
+// @Param equipment query string false "Only recipes needing this equipment (case-insensitive name)"
+// @Param exclude_tag query []string false "Skip recipes carrying this tag (case-insensitive); repeatable" collectionFormat(multi)
+// @Param ingredient_contains query string false "Only recipes with an ingredient whose name contains this text (case-insensitive), e.g. choc"
+// @Param untagged query bool false "Only recipes without any tag, e.g. to find recipes still to be tagged"
+// @Param in_season query bool false "Only recipes whose ingredients are all in season this month (server time); ingredients without seasons count as year-round"
+// @Param updated_since query string false "RFC 3339 timestamp; only recipes updated at or after it, oldest change first (incremental sync)"
+// @Param sort query string false "Order by title, created_at, updated_at, prep_time_minutes, cook_time_minutes or total_time_minutes; prefix - for descending. Defaults to DEFAULT_RECIPE_SORT (-updated_at). next_cursor is only returned for updated_at orderings"
+// @Success 200 {object} models.RecipeListResponse
+// @Failure 400 {object} APIError "Invalid query parameters"
 // @Failure 500 {object} APIError "Server error"
 // @Router /recipes [get]
 func (h *RecipeHandler) ListRecipes(c *gin.Context) {
-	// TODO: Add pagination and filtering query parameters
-	recipes, err := h.store.ListRecipes(c.Request.Context())
+	pageSize, err := h.parsePageSizeQuery(c, "page_size")
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: "+err.Error())
+		return
+	}
+
+	// Fetch one extra row to know whether another page follows.
+	params := models.RecipeListParams{Limit: pageSize + 1, Lang: requestedLang(c)}
+	if difficultyStr := c.Query("difficulty"); difficultyStr != "" {
+		difficulty := models.Difficulty(difficultyStr)
+		if !difficulty.IsValid() {
+			RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: difficulty must be one of easy, medium, hard")
+			return
+		}
+		params.Difficulty = &difficulty
+	}
+	minServes, err := parsePositiveIntQuery(c, "min_serves", 0)
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: "+err.Error())
+		return
+	}
+	maxServes, err := parsePositiveIntQuery(c, "max_serves", 0)
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: "+err.Error())
+		return
+	}
+	if minServes > 0 && maxServes > 0 && minServes > maxServes {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: min_serves must not exceed max_serves")
+		return
+	}
+	if minServes > 0 {
+		params.MinServes = &minServes
+	}
+	if maxServes > 0 {
+		params.MaxServes = &maxServes
+	}
+	params.Equipment = strings.TrimSpace(c.Query("equipment"))
+	params.IngredientContains = strings.TrimSpace(c.Query("ingredient_contains"))
+	for _, name := range c.QueryArray("exclude_tag") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: exclude_tag must not be empty")
+			return
+		}
+		params.ExcludeTags = append(params.ExcludeTags, name)
+	}
+	params.Untagged, err = strconv.ParseBool(c.DefaultQuery("untagged", "false"))
 	if err != nil {
-		RespondWithError(c, http.StatusInternalServerError, "Failed to list recipes: "+err.Error())
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: untagged must be a boolean")
 		return
 	}
-	RespondWithJSON(c, http.StatusOK, recipes)
+	inSeason, err := strconv.ParseBool(c.DefaultQuery("in_season", "false"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: in_season must be a boolean")
+		return
+	}
+	if inSeason {
+		params.InSeasonMonth = int(time.Now().Month())
+	}
+	if updatedSinceStr := c.Query("updated_since"); updatedSinceStr != "" {
+		updatedSince, err := time.Parse(time.RFC3339Nano, updatedSinceStr)
+		if err != nil {
+			RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: updated_since must be an RFC 3339 timestamp")
+			return
+		}
+		params.UpdatedSince = &updatedSince
+	}
+	// The zero sort (updated_at descending) is used when DEFAULT_RECIPE_SORT is unset; main rejects invalid values.
+	params.Sort, _ = models.ParseRecipeSort(h.cfg.DefaultRecipeSort)
+	if sortStr := c.Query("sort"); sortStr != "" {
+		if params.UpdatedSince != nil {
+			RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: sort cannot be combined with updated_since")
+			return
+		}
+		params.Sort, err = models.ParseRecipeSort(sortStr)
+		if err != nil {
+			RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: "+err.Error())
+			return
+		}
+	}
+	// Cursors hold an updated_at, so other orderings page by offset only.
+	keyset := params.UpdatedSince != nil || params.Sort.Field == "" || params.Sort.Field == "updated_at"
+	response := models.RecipeListResponse{PageSize: pageSize}
+
+	if after := c.Query("after"); after != "" {
+		if !keyset {
+			RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: after requires sorting by updated_at; use page")
+			return
+		}
+		cursor, err := decodeRecipeCursor(after)
+		if err != nil {
+			RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: after "+err.Error())
+			return
+		}
+		params.After = cursor
+	} else {
+		page, err := parsePositiveIntQuery(c, "page", 1)
+		if err != nil {
+			RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: "+err.Error())
+			return
+		}
+		params.Offset = (page - 1) * pageSize
+		response.Page = page
+	}
+
+	recipes, err := h.store.ListRecipes(c.Request.Context(), params)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to list recipes: "+err.Error())
+		return
+	}
+
+	if len(recipes) > pageSize {
+		recipes = recipes[:pageSize]
+		if keyset {
+			last := recipes[len(recipes)-1]
+			nextCursor := encodeRecipeCursor(models.RecipeCursor{UpdatedAt: last.UpdatedAt, ID: last.ID})
+			response.NextCursor = &nextCursor
+		}
+	}
+	if recipes == nil {
+		recipes = []*models.Recipe{}
+	}
+	markOwnership(c, recipes...)
+	response.Recipes = recipes
+	RespondWithJSON(c, http.StatusOK, response)
 }
 
 // UpdateRecipe handles updating an existing recipe.
 // @Summary Update an existing recipe
 // @Description Update an existing recipe by its UUID. All fields are replaced.
+// @Description Input is validated and warnings are reported as on create.
 // @Tags recipes
 // @Accept json
 // @Produce json
 // @Param id path string true "Recipe ID (UUID)"
 // @Param recipe body models.RecipeRequest true "Recipe data to update"
+// @Param strict query bool false "Reject ingredient lines that exactly repeat an earlier one"
 // @Success 200 {object} models.Recipe
-// @Failure 400 {object} APIError "Invalid input or ID format"
+// @Failure 400 {object} APIError "Invalid input, ID format or unknown ingredient ID"
 // @Failure 404 {object} APIError "Recipe not found"
+// @Failure 409 {object} APIError "An ingredient listed twice (names match case-insensitively), or a concurrent slug clash"
 // @Failure 500 {object} APIError "Server error"
 // @Router /recipes/{id} [put]
 // formatValidationErrors converts validator.ValidationErrors into a map for a structured JSON response.
@@ -134,47 +566,66 @@ func formatValidationErrors(err error) map[string]string {
 			if len(parts) > 1 {
 				fieldName = parts[1]
 			}
+			if msg, ok := validationMessages[fieldErr.Tag()]; ok {
+				errors[fieldName] = msg
+				continue
+			}
+			if fieldErr.Tag() == "unique_sort_order" {
+				errors[fieldName] = fmt.Sprintf("sort_order %v is already used by ingredients[%s]", fieldErr.Value(), fieldErr.Param())
+				continue
+			}
+			if fieldErr.Tag() == "max" && fieldErr.Kind() == reflect.String {
+				// Echoing an over-long value back would not help, so only the limit is reported.
+				errors[fieldName] = fmt.Sprintf("must be at most %s characters", fieldErr.Param())
+				continue
+			}
 			errors[fieldName] = fmt.Sprintf("failed on '%s' validation (value: '%v')", fieldErr.Tag(), fieldErr.Value())
 		}
 	}
 	return errors
 }
 
-// RespondWithDetailedError sends a JSON error response with additional details.
-func RespondWithDetailedError(c *gin.Context, code int, message string, details interface{}) {
-	c.JSON(code, gin.H{"status": code, "error": message, "details": details})
-}
-
 func (h *RecipeHandler) UpdateRecipe(c *gin.Context) {
 	idStr := c.Param("id")
 	recipeID, err := uuid.Parse(idStr)
 	if err != nil {
-		RespondWithError(c, http.StatusBadRequest, "Invalid recipe ID format: "+err.Error())
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid recipe ID format: "+err.Error())
+		return
+	}
+	strict, err := strconv.ParseBool(c.DefaultQuery("strict", "false"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid strict: must be a boolean")
 		return
 	}
 
 	var req models.RecipeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		RespondWithError(c, http.StatusBadRequest, "Invalid request payload: "+err.Error())
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPayload, "Invalid request payload: "+err.Error())
 		return
 	}
 
 	// Validate the request
-	if err := validate.Struct(req); err != nil {
-		validationErrors := formatValidationErrors(err)
-		RespondWithDetailedError(c, http.StatusBadRequest, "Validation failed", validationErrors)
+	if validationErrors := h.validateRecipeRequest(&req, strict); validationErrors != nil {
+		RespondWithValidationErrors(c, http.StatusBadRequest, validationErrors, collectWarnings(&req))
 		return
 	}
 
 	recipe, err := h.store.UpdateRecipe(c.Request.Context(), recipeID, &req)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") { // Basic check
-			RespondWithError(c, http.StatusNotFound, "Recipe not found for update: "+err.Error())
+		if errors.Is(err, store.ErrUnknownIngredient) {
+			respondWithStoreError(c, http.StatusBadRequest, ErrCodeUnknownIngredient, "Invalid ingredient reference: ", err)
+		} else if errors.Is(err, store.ErrConflict) {
+			respondWithStoreError(c, http.StatusConflict, ErrCodeConflict, "Failed to update recipe: ", err)
+		} else if strings.Contains(err.Error(), "not found") { // Basic check
+			RespondWithError(c, http.StatusNotFound, ErrCodeRecipeNotFound, "Recipe not found for update: "+err.Error())
 		} else {
-			RespondWithError(c, http.StatusInternalServerError, "Failed to update recipe: "+err.Error())
+			respondWithStoreError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to update recipe: ", err)
 		}
 		return
 	}
+	h.roundQuantities(recipe)
+	recipe.Warnings = collectWarnings(&req)
+	markOwnership(c, recipe)
 	RespondWithJSON(c, http.StatusOK, recipe)
 }
 
@@ -193,18 +644,164 @@ func (h *RecipeHandler) DeleteRecipe(c *gin.Context) {
 	idStr := c.Param("id")
 	recipeID, err := uuid.Parse(idStr)
 	if err != nil {
-		RespondWithError(c, http.StatusBadRequest, "Invalid recipe ID format: "+err.Error())
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid recipe ID format: "+err.Error())
 		return
 	}
 
 	err = h.store.DeleteRecipe(c.Request.Context(), recipeID)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") { // Basic check
-			RespondWithError(c, http.StatusNotFound, "Recipe not found for deletion: "+err.Error())
+			RespondWithError(c, http.StatusNotFound, ErrCodeRecipeNotFound, "Recipe not found for deletion: "+err.Error())
 		} else {
-			RespondWithError(c, http.StatusInternalServerError, "Failed to delete recipe: "+err.Error())
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to delete recipe: "+err.Error())
 		}
 		return
 	}
 	RespondWithJSON(c, http.StatusNoContent, nil) // Or c.Status(http.StatusNoContent)
 }
+
+// DeleteRecipes handles deleting many recipes at once.
+// @Summary Delete recipes in bulk
+// @Description Delete every listed recipe in a single transaction. IDs that match no recipe are counted as not_found rather than failing the request.
+// @Tags recipes
+// @Accept json
+// @Produce json
+// @Param recipes body models.RecipeBulkDeleteRequest true "Recipes to delete"
+// @Success 200 {object} models.RecipeBulkDeleteResponse
+// @Failure 400 {object} APIError "Invalid input"
+// @Failure 500 {object} APIError "Server error"
+// @Router /recipes [delete]
+func (h *RecipeHandler) DeleteRecipes(c *gin.Context) {
+	var req models.RecipeBulkDeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPayload, "Invalid request payload: "+err.Error())
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		validationErrors := formatValidationErrors(err)
+		RespondWithDetailedError(c, http.StatusBadRequest, ErrCodeValidationFailed, "Validation failed", validationErrors)
+		return
+	}
+
+	// Count each recipe once, so a repeated ID is not reported as not found.
+	seen := make(map[uuid.UUID]bool, len(req.RecipeIDs))
+	ids := make([]uuid.UUID, 0, len(req.RecipeIDs))
+	for _, id := range req.RecipeIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	deleted, err := h.store.DeleteRecipes(c.Request.Context(), ids)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to delete recipes: "+err.Error())
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, models.RecipeBulkDeleteResponse{Deleted: deleted, NotFound: len(ids) - deleted})
+}
+
+// RecipesExist handles checking which of a batch of recipe IDs exist.
+// @Summary Check that recipes exist
+// @Description Report which of the listed recipe IDs belong to a recipe and which do not, e.g. to prune stale references
+// @Description from a meal plan. Each ID is listed once, in request order.
+// @Tags recipes
+// @Accept json
+// @Produce json
+// @Param recipes body models.RecipeExistsRequest true "Recipe IDs to check"
+// @Success 200 {object} models.RecipeExistsResponse
+// @Failure 400 {object} APIError "Invalid input"
+// @Failure 500 {object} APIError "Server error"
+// @Router /recipes/exists [post]
+func (h *RecipeHandler) RecipesExist(c *gin.Context) {
+	var req models.RecipeExistsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPayload, "Invalid request payload: "+err.Error())
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		RespondWithDetailedError(c, http.StatusBadRequest, ErrCodeValidationFailed, "Validation failed", formatValidationErrors(err))
+		return
+	}
+
+	seen := make(map[uuid.UUID]bool, len(req.RecipeIDs))
+	ids := make([]uuid.UUID, 0, len(req.RecipeIDs))
+	for _, id := range req.RecipeIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	existing, err := h.store.ExistingRecipeIDs(c.Request.Context(), ids)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to check recipes: "+err.Error())
+		return
+	}
+	found := make(map[uuid.UUID]bool, len(existing))
+	for _, id := range existing {
+		found[id] = true
+	}
+	response := models.RecipeExistsResponse{Existing: []uuid.UUID{}, Missing: []uuid.UUID{}}
+	for _, id := range ids {
+		if found[id] {
+			response.Existing = append(response.Existing, id)
+		} else {
+			response.Missing = append(response.Missing, id)
+		}
+	}
+	RespondWithJSON(c, http.StatusOK, response)
+}
+
+// UpsertRecipeTranslation handles creating or replacing a recipe's translation.
+// @Summary Create or replace a recipe translation
+// @Description Set the title and description of a recipe in another language.
+// @Tags recipes
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID (UUID)"
+// @Param lang path string true "Language code (e.g. fr)"
+// @Param translation body models.RecipeTranslationRequest true "Translated fields"
+// @Success 200 {object} models.RecipeTranslation
+// @Failure 400 {object} APIError "Invalid input, ID format or language"
+// @Failure 404 {object} APIError "Recipe not found"
+// @Failure 500 {object} APIError "Server error"
+// @Router /recipes/{id}/translations/{lang} [put]
+func (h *RecipeHandler) UpsertRecipeTranslation(c *gin.Context) {
+	idStr := c.Param("id")
+	recipeID, err := uuid.Parse(idStr)
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid recipe ID format: "+err.Error())
+		return
+	}
+
+	lang, ok := normalizeLang(c.Param("lang"))
+	if !ok {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidLanguage, "Invalid language code: "+c.Param("lang"))
+		return
+	}
+
+	var req models.RecipeTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPayload, "Invalid request payload: "+err.Error())
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		validationErrors := formatValidationErrors(err)
+		RespondWithDetailedError(c, http.StatusBadRequest, ErrCodeValidationFailed, "Validation failed", validationErrors)
+		return
+	}
+
+	translation, err := h.store.UpsertRecipeTranslation(c.Request.Context(), recipeID, lang, &req)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") { // Basic check
+			RespondWithError(c, http.StatusNotFound, ErrCodeRecipeNotFound, "Recipe not found for translation: "+err.Error())
+		} else {
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to save recipe translation: "+err.Error())
+		}
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, translation)
+}
@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors" // Added for store error simulation
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,7 +17,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/gaanon/gorecipes_v2/config"
 	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store"
 	"github.com/gaanon/gorecipes_v2/store/mocks" // Import the generated mocks
 )
 
@@ -25,6 +30,19 @@ func setupTestRouter(handler *RecipeHandler) *gin.Engine {
 	api := router.Group("/api/v1")
 	{
 		api.POST("/recipes", handler.CreateRecipe)
+		api.GET("/recipes", handler.ListRecipes)
+		api.DELETE("/recipes", handler.DeleteRecipes)
+		api.POST("/recipes/exists", handler.RecipesExist)
+		api.GET("/recipes/search", handler.SearchRecipes)
+		api.GET("/recipes/:id/steps/:number", handler.GetRecipeStep)
+		api.GET("/recipes/:id/similar", handler.GetSimilarRecipes)
+		api.GET("/recipes/:id/tags", handler.GetRecipeTags)
+		api.GET("/recipes/:id/difficulty-estimate", handler.GetDifficultyEstimate)
+		api.GET("/recipes/:id/cost", handler.GetRecipeCost)
+		api.POST("/recipes/:id/ingredients", handler.AddRecipeIngredient)
+		api.DELETE("/recipes/:id/ingredients/:ingredient_id", handler.RemoveRecipeIngredient)
+		api.GET("/recipes/:id", handler.GetRecipe)
+		api.GET("/recipes/slug/:slug", handler.GetRecipeBySlug)
 		api.PUT("/recipes/:id", handler.UpdateRecipe)
 		// Add other routes as you test them
 	}
@@ -36,7 +54,7 @@ func TestRecipeHandler_CreateRecipe_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockStore := mocks.NewMockRecipeStore(ctrl)
-	recipeHandler := NewRecipeHandler(mockStore)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
 	router := setupTestRouter(recipeHandler)
 
 	recipeReq := &models.RecipeRequest{
@@ -46,7 +64,7 @@ func TestRecipeHandler_CreateRecipe_Success(t *testing.T) {
 		PrepTimeMinutes: intPtr(15),
 		CookTimeMinutes: intPtr(30),
 		Ingredients: []models.RecipeIngredientRequest{
-			{IngredientName: "Test Ingredient", Quantity: float64Ptr(1.0), UnitName: strPtr("cup"), SortOrder: 1},
+			{IngredientName: "Test Ingredient", Quantity: float64Ptr(1.0), UnitName: strPtr("cup"), SortOrder: intPtr(1)},
 		},
 		Steps: []models.RecipeStepRequest{
 			{StepNumber: 1, Instruction: "Test Step"},
@@ -98,6 +116,28 @@ func TestRecipeHandler_CreateRecipe_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedRecipe.Title, responseRecipe.Title)
 	assert.Equal(t, expectedRecipe.ID, responseRecipe.ID)
+	assert.Equal(t, "/api/v1/recipes/"+createdRecipeID.String(), w.Header().Get("Location"))
+}
+
+func TestRecipeHandler_CreateRecipe_LocationUsesBasePath(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Group("/recipes-api").POST("/recipes", recipeHandler.CreateRecipe)
+
+	recipeID := uuid.New()
+	mockStore.EXPECT().CreateRecipe(gomock.Any(), gomock.Any()).Return(&models.Recipe{ID: recipeID, Title: "Soup"}, nil).Times(1)
+
+	req, _ := http.NewRequest(http.MethodPost, "/recipes-api/recipes", bytes.NewBufferString(`{"title":"Soup"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/recipes-api/recipes/"+recipeID.String(), w.Header().Get("Location"))
 }
 
 func TestRecipeHandler_CreateRecipe_BindError(t *testing.T) {
@@ -105,7 +145,7 @@ func TestRecipeHandler_CreateRecipe_BindError(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockStore := mocks.NewMockRecipeStore(ctrl)
-	recipeHandler := NewRecipeHandler(mockStore)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
 	router := setupTestRouter(recipeHandler)
 
 	// Invalid JSON
@@ -125,7 +165,7 @@ func TestRecipeHandler_CreateRecipe_ValidationError(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockStore := mocks.NewMockRecipeStore(ctrl)
-	recipeHandler := NewRecipeHandler(mockStore)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
 	router := setupTestRouter(recipeHandler)
 
 	// Request missing required 'Title'
@@ -146,6 +186,7 @@ func TestRecipeHandler_CreateRecipe_ValidationError(t *testing.T) {
 	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
 	assert.NoError(t, err)
 	assert.Contains(t, errorResponse["details"].(map[string]interface{}), "Title")
+	assert.Equal(t, ErrCodeValidationFailed, errorResponse["code"])
 }
 
 // Helper functions for pointers to make test setup cleaner
@@ -158,7 +199,7 @@ func TestRecipeHandler_CreateRecipe_StoreError(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockStore := mocks.NewMockRecipeStore(ctrl)
-	recipeHandler := NewRecipeHandler(mockStore)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
 	router := setupTestRouter(recipeHandler)
 
 	recipeReq := &models.RecipeRequest{
@@ -168,7 +209,7 @@ func TestRecipeHandler_CreateRecipe_StoreError(t *testing.T) {
 		PrepTimeMinutes: intPtr(10),
 		CookTimeMinutes: intPtr(20),
 		Ingredients: []models.RecipeIngredientRequest{
-			{IngredientName: "Ingredient", Quantity: float64Ptr(1.0), UnitName: strPtr("each"), SortOrder: 1},
+			{IngredientName: "Ingredient", Quantity: float64Ptr(1.0), UnitName: strPtr("each"), SortOrder: intPtr(1)},
 		},
 		Steps: []models.RecipeStepRequest{
 			{StepNumber: 1, Instruction: "A step"},
@@ -202,6 +243,7 @@ func TestRecipeHandler_CreateRecipe_StoreError(t *testing.T) {
 	status, ok := errorResponse["status"].(float64) // JSON numbers are float64
 	assert.True(t, ok, "Status should be a number")
 	assert.Equal(t, float64(http.StatusInternalServerError), status)
+	assert.Equal(t, ErrCodeDBError, errorResponse["code"])
 }
 
 func TestRecipeHandler_UpdateRecipe_Success(t *testing.T) {
@@ -209,7 +251,7 @@ func TestRecipeHandler_UpdateRecipe_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockStore := mocks.NewMockRecipeStore(ctrl)
-	recipeHandler := NewRecipeHandler(mockStore)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
 	router := setupTestRouter(recipeHandler)
 
 	recipeID := uuid.New()
@@ -220,7 +262,7 @@ func TestRecipeHandler_UpdateRecipe_Success(t *testing.T) {
 		PrepTimeMinutes: intPtr(20),
 		CookTimeMinutes: intPtr(35),
 		Ingredients: []models.RecipeIngredientRequest{
-			{IngredientName: "Updated Ingredient", Quantity: float64Ptr(2.0), UnitName: strPtr("tbsp"), SortOrder: 1},
+			{IngredientName: "Updated Ingredient", Quantity: float64Ptr(2.0), UnitName: strPtr("tbsp"), SortOrder: intPtr(1)},
 		},
 		Steps: []models.RecipeStepRequest{
 			{StepNumber: 1, Instruction: "Updated Test Step"},
@@ -280,7 +322,7 @@ func TestRecipeHandler_UpdateRecipe_InvalidID(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockStore := mocks.NewMockRecipeStore(ctrl)
-	recipeHandler := NewRecipeHandler(mockStore)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
 	router := setupTestRouter(recipeHandler)
 
 	recipeReq := &models.RecipeRequest{Title: "Valid Title"} // Minimal valid body
@@ -305,7 +347,7 @@ func TestRecipeHandler_UpdateRecipe_BindError(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockStore := mocks.NewMockRecipeStore(ctrl)
-	recipeHandler := NewRecipeHandler(mockStore)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
 	router := setupTestRouter(recipeHandler)
 	recipeID := uuid.New()
 
@@ -324,7 +366,7 @@ func TestRecipeHandler_UpdateRecipe_ValidationError(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockStore := mocks.NewMockRecipeStore(ctrl)
-	recipeHandler := NewRecipeHandler(mockStore)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
 	router := setupTestRouter(recipeHandler)
 	recipeID := uuid.New()
 
@@ -350,7 +392,7 @@ func TestRecipeHandler_UpdateRecipe_NotFound(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockStore := mocks.NewMockRecipeStore(ctrl)
-	recipeHandler := NewRecipeHandler(mockStore)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
 	router := setupTestRouter(recipeHandler)
 
 	recipeID := uuid.New()
@@ -359,7 +401,7 @@ func TestRecipeHandler_UpdateRecipe_NotFound(t *testing.T) {
 		Serves: intPtr(1), // Ensure it's a valid request
 		PrepTimeMinutes: intPtr(1),
 		CookTimeMinutes: intPtr(1),
-		Ingredients: []models.RecipeIngredientRequest{{IngredientName: "i", Quantity: float64Ptr(1), UnitName: strPtr("u"), SortOrder: 1}},
+		Ingredients: []models.RecipeIngredientRequest{{IngredientName: "i", Quantity: float64Ptr(1), UnitName: strPtr("u"), SortOrder: intPtr(1)}},
 		Steps: []models.RecipeStepRequest{{StepNumber: 1, Instruction: "s"}},
 	}
 	jsonBody, _ := json.Marshal(recipeReq)
@@ -375,6 +417,10 @@ func TestRecipeHandler_UpdateRecipe_NotFound(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusNotFound, w.Code) // Handler should convert "not found" to 404
+	var errorResponse map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrCodeRecipeNotFound, errorResponse["code"])
 }
 
 func TestRecipeHandler_UpdateRecipe_StoreError(t *testing.T) {
@@ -382,7 +428,7 @@ func TestRecipeHandler_UpdateRecipe_StoreError(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockStore := mocks.NewMockRecipeStore(ctrl)
-	recipeHandler := NewRecipeHandler(mockStore)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
 	router := setupTestRouter(recipeHandler)
 
 	recipeID := uuid.New()
@@ -391,7 +437,7 @@ func TestRecipeHandler_UpdateRecipe_StoreError(t *testing.T) {
 		Serves: intPtr(1),
 		PrepTimeMinutes: intPtr(1),
 		CookTimeMinutes: intPtr(1),
-		Ingredients: []models.RecipeIngredientRequest{{IngredientName: "i", Quantity: float64Ptr(1), UnitName: strPtr("u"), SortOrder: 1}},
+		Ingredients: []models.RecipeIngredientRequest{{IngredientName: "i", Quantity: float64Ptr(1), UnitName: strPtr("u"), SortOrder: intPtr(1)}},
 		Steps: []models.RecipeStepRequest{{StepNumber: 1, Instruction: "s"}},
 	}
 	jsonBody, _ := json.Marshal(recipeReq)
@@ -413,4 +459,1466 @@ func TestRecipeHandler_UpdateRecipe_StoreError(t *testing.T) {
 	assert.Contains(t, errorResponse["error"], expectedStoreErrorMessage)
 }
 
+func TestRecipeHandler_ListRecipes_KeysetPagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	recipes := []*models.Recipe{
+		{ID: uuid.New(), Title: "First", UpdatedAt: now},
+		{ID: uuid.New(), Title: "Second", UpdatedAt: now.Add(-time.Minute)},
+		{ID: uuid.New(), Title: "Third", UpdatedAt: now.Add(-2 * time.Minute)},
+	}
+
+	// page_size=2 asks the store for one extra row to detect the next page
+	mockStore.EXPECT().ListRecipes(gomock.Any(), models.RecipeListParams{Limit: 3}).Return(recipes, nil).Times(1)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes?page_size=2", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.RecipeListResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response.Recipes, 2)
+	assert.Equal(t, 2, response.PageSize)
+	if assert.NotNil(t, response.NextCursor) {
+		cursor, err := decodeRecipeCursor(*response.NextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, recipes[1].ID, cursor.ID)
+		assert.True(t, recipes[1].UpdatedAt.Equal(cursor.UpdatedAt))
+
+		// Following the cursor passes it through to the store as a keyset position
+		mockStore.EXPECT().ListRecipes(gomock.Any(), models.RecipeListParams{Limit: 3, After: cursor}).Return(recipes[2:], nil).Times(1)
+
+		req, _ = http.NewRequest(http.MethodGet, "/api/v1/recipes?page_size=2&after="+*response.NextCursor, nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var nextPage models.RecipeListResponse
+		err = json.Unmarshal(w.Body.Bytes(), &nextPage)
+		assert.NoError(t, err)
+		assert.Len(t, nextPage.Recipes, 1)
+		assert.Nil(t, nextPage.NextCursor)
+	}
+}
+
+func TestRecipeHandler_ListRecipes_InvalidCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes?after=not-a-cursor", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var errorResponse map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrCodeInvalidQuery, errorResponse["code"])
+}
+
+func TestRecipeHandler_CreateRecipe_IngredientNameOrIDRequired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	ingredientID := uuid.New()
+	testCases := map[string]models.RecipeIngredientRequest{
+		"neither name nor ID": {Quantity: float64Ptr(1), SortOrder: intPtr(1)},
+		"both name and ID":    {IngredientName: "flour", IngredientID: &ingredientID, SortOrder: intPtr(1)},
+	}
+	for name, ingredient := range testCases {
+		t.Run(name, func(t *testing.T) {
+			recipeReq := &models.RecipeRequest{
+				Title:       "Ingredient Reference Test",
+				Ingredients: []models.RecipeIngredientRequest{ingredient},
+			}
+			jsonBody, _ := json.Marshal(recipeReq)
+			req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			var errorResponse map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+			assert.NoError(t, err)
+			assert.Equal(t, ErrCodeValidationFailed, errorResponse["code"])
+		})
+	}
+}
+
+func TestRecipeHandler_CreateRecipe_UnknownIngredientID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	ingredientID := uuid.New()
+	recipeReq := &models.RecipeRequest{
+		Title:       "Unknown Ingredient Test",
+		Ingredients: []models.RecipeIngredientRequest{{IngredientID: &ingredientID, SortOrder: intPtr(1)}},
+	}
+
+	mockStore.EXPECT().CreateRecipe(gomock.Any(), recipeReq).
+		Return(nil, fmt.Errorf("processing ingredient %s: %w", ingredientID, store.ErrUnknownIngredient)).Times(1)
+
+	jsonBody, _ := json.Marshal(recipeReq)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var errorResponse map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrCodeUnknownIngredient, errorResponse["code"])
+}
+
+func TestRecipeHandler_CreateRecipe_RelationErrorDetails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	recipeReq := &models.RecipeRequest{
+		Title: "Broken Steps",
+		Steps: []models.RecipeStepRequest{{StepNumber: 1, Instruction: "Mix"}, {StepNumber: 2, Instruction: "Bake"}},
+	}
+	mockStore.EXPECT().CreateRecipe(gomock.Any(), gomock.Any()).Return(nil, &store.RelationError{
+		Relation: "steps", Index: 1, Name: "2", Err: errors.New("failed to insert recipe step 2: check constraint violated"),
+	}).Times(1)
+
+	jsonBody, _ := json.Marshal(recipeReq)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	var errorResponse APIError
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+	assert.Equal(t, ErrCodeDBError, errorResponse.Code)
+	assert.Contains(t, errorResponse.Error, "failed to insert recipe step 2")
+	assert.Equal(t, map[string]interface{}{"relation": "steps", "index": float64(1), "name": "2"}, errorResponse.Details)
+}
+
+func TestRecipeHandler_CreateRecipe_RepeatedIngredientConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	// "Salt" and "salt" resolve to the same ingredient, which a recipe can only list once.
+	mockStore.EXPECT().CreateRecipe(gomock.Any(), gomock.Any()).Return(nil, &store.RelationError{
+		Relation: "ingredients", Index: 1, Name: "salt", Err: fmt.Errorf("%w: ingredient salt is listed more than once", store.ErrConflict),
+	}).Times(1)
+
+	body := `{"title":"Brine","ingredients":[{"ingredient_name":"Salt"},{"ingredient_name":"salt"}]}`
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var errorResponse APIError
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+	assert.Equal(t, ErrCodeConflict, errorResponse.Code)
+	assert.Equal(t, map[string]interface{}{"relation": "ingredients", "index": float64(1), "name": "salt"}, errorResponse.Details)
+}
+
+func TestRequestedLang(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testCases := []struct {
+		name           string
+		query          string
+		acceptLanguage string
+		expected       string
+	}{
+		{"none", "", "", ""},
+		{"query param wins", "?lang=FR", "de", "fr"},
+		{"region subtag is dropped", "", "fr-CA", "fr"},
+		{"highest q-value wins", "", "en;q=0.5, fr;q=0.9, *;q=0.1", "fr"},
+		{"invalid query falls back to header", "?lang=123", "de", "de"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/api/v1/recipes"+tc.query, nil)
+			if tc.acceptLanguage != "" {
+				c.Request.Header.Set("Accept-Language", tc.acceptLanguage)
+			}
+			assert.Equal(t, tc.expected, requestedLang(c))
+		})
+	}
+}
+
+func TestRecipeHandler_ListRecipes_DifficultyFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	easy := models.DifficultyEasy
+	mockStore.EXPECT().ListRecipes(gomock.Any(), models.RecipeListParams{Limit: defaultPageSize + 1, Difficulty: &easy}).
+		Return([]*models.Recipe{}, nil).Times(1)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes?difficulty=easy", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/recipes?difficulty=impossible", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRecipeHandler_ListRecipes_ServesFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	mockStore.EXPECT().ListRecipes(gomock.Any(), models.RecipeListParams{Limit: defaultPageSize + 1, MinServes: intPtr(2), MaxServes: intPtr(4)}).
+		Return([]*models.Recipe{}, nil).Times(1)
+	mockStore.EXPECT().ListRecipes(gomock.Any(), models.RecipeListParams{Limit: defaultPageSize + 1, MaxServes: intPtr(2)}).
+		Return([]*models.Recipe{}, nil).Times(1)
+
+	for _, query := range []string{"min_serves=2&max_serves=4", "max_serves=2"} {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code, query)
+	}
+
+	for _, query := range []string{"min_serves=4&max_serves=2", "min_serves=0", "max_serves=-1"} {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestRecipeHandler_ListRecipes_EquipmentFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	mockStore.EXPECT().ListRecipes(gomock.Any(), models.RecipeListParams{Limit: defaultPageSize + 1, Equipment: "cast iron skillet"}).
+		Return([]*models.Recipe{}, nil).Times(1)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes?equipment=+cast+iron+skillet+", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRecipeHandler_ListRecipes_IngredientContains(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	// Combines with the other filters.
+	difficulty := models.DifficultyEasy
+	mockStore.EXPECT().ListRecipes(gomock.Any(), models.RecipeListParams{Limit: defaultPageSize + 1, IngredientContains: "choc", Difficulty: &difficulty}).
+		Return([]*models.Recipe{}, nil).Times(1)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes?ingredient_contains=+choc+&difficulty=easy", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRecipeHandler_ListRecipes_ExcludeTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	mockStore.EXPECT().ListRecipes(gomock.Any(), models.RecipeListParams{Limit: defaultPageSize + 1, ExcludeTags: []string{"dessert", "Spicy"}}).
+		Return([]*models.Recipe{}, nil).Times(1)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes?exclude_tag=dessert&exclude_tag=+Spicy", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/recipes?exclude_tag=dessert&exclude_tag=", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRecipeHandler_ListRecipes_Untagged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	mockStore.EXPECT().ListRecipes(gomock.Any(), models.RecipeListParams{Limit: 10 + 1, Untagged: true}).
+		Return([]*models.Recipe{}, nil).Times(1)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes?untagged=true&page_size=10", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/recipes?untagged=sometimes", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRecipeHandler_ListRecipes_InSeason(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	mockStore.EXPECT().ListRecipes(gomock.Any(), models.RecipeListParams{Limit: 10 + 1, InSeasonMonth: int(time.Now().Month())}).
+		Return([]*models.Recipe{}, nil).Times(1)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes?in_season=true&page_size=10", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/recipes?in_season=summer", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRecipeHandler_ListRecipes_Sort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{DefaultRecipeSort: "title"})
+	router := setupTestRouter(recipeHandler)
+
+	get := func(query string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	recipes := []*models.Recipe{{ID: uuid.New(), Title: "Apple pie"}, {ID: uuid.New(), Title: "Brownies"}}
+
+	// The deployment default applies without ?sort=; only updated_at orderings get a cursor.
+	mockStore.EXPECT().ListRecipes(gomock.Any(), models.RecipeListParams{Limit: 2, Sort: models.RecipeSort{Field: "title"}}).
+		Return(recipes, nil).Times(1)
+	w := get("?page_size=1")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.RecipeListResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response.Recipes, 1)
+	assert.Nil(t, response.NextCursor)
+
+	mockStore.EXPECT().ListRecipes(gomock.Any(), models.RecipeListParams{Limit: 2, Sort: models.RecipeSort{Field: "updated_at", Desc: true}}).
+		Return(recipes, nil).Times(1)
+	w = get("?page_size=1&sort=-updated_at")
+	assert.Equal(t, http.StatusOK, w.Code)
+	response = models.RecipeListResponse{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.NotNil(t, response.NextCursor)
+
+	cursor := encodeRecipeCursor(models.RecipeCursor{UpdatedAt: time.Now(), ID: uuid.New()})
+	assert.Equal(t, http.StatusBadRequest, get("?after="+cursor).Code)
+	assert.Equal(t, http.StatusBadRequest, get("?sort=rating").Code)
+	assert.Equal(t, http.StatusBadRequest, get("?sort=title&updated_since=2024-01-01T00:00:00Z").Code)
+}
+
+func TestRecipeHandler_ListRecipes_UpdatedSince(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	since := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	mockStore.EXPECT().ListRecipes(gomock.Any(), models.RecipeListParams{Limit: defaultPageSize + 1, UpdatedSince: &since}).
+		Return([]*models.Recipe{}, nil).Times(1)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes?updated_since=2024-05-01T12:00:00Z", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	for _, query := range []string{"updated_since=yesterday", "updated_since=2024-05-01"} {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestRecipeHandler_ListRecipes_PageSizeClamping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{MaxPageSize: 50})
+	router := setupTestRouter(recipeHandler)
+
+	tests := []struct {
+		query        string
+		wantPageSize int
+	}{
+		{"page_size=100000", 50},
+		{"page_size=0", defaultPageSize},
+		{"page_size=-5", defaultPageSize},
+		{"page_size=7", 7},
+	}
+	for _, tt := range tests {
+		mockStore.EXPECT().ListRecipes(gomock.Any(), models.RecipeListParams{Limit: tt.wantPageSize + 1}).
+			Return([]*models.Recipe{}, nil).Times(1)
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes?"+tt.query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code, tt.query)
+		var response models.RecipeListResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, tt.wantPageSize, response.PageSize, tt.query)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes?page_size=lots", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRecipeHandler_GetRecipeStep(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	recipeID := uuid.New()
+	step := &models.RecipeStep{ID: uuid.New(), StepNumber: 2, Instruction: "Stir"}
+	mockStore.EXPECT().GetStep(gomock.Any(), recipeID, 2).Return(step, nil).Times(1)
+	mockStore.EXPECT().GetStep(gomock.Any(), recipeID, 9).Return(nil, fmt.Errorf("step 9 not found for recipe %s", recipeID)).Times(1)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes/"+recipeID.String()+"/steps/2", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.RecipeStep
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "Stir", response.Instruction)
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/recipes/"+recipeID.String()+"/steps/9", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/recipes/"+recipeID.String()+"/steps/0", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRecipeHandler_GetRecipeTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	taggedID, untaggedID, missingID := uuid.New(), uuid.New(), uuid.New()
+	tags := []models.Tag{{ID: uuid.New(), Name: "dessert"}, {ID: uuid.New(), Name: "quick"}}
+	mockStore.EXPECT().GetRecipeTags(gomock.Any(), taggedID).Return(tags, nil).Times(1)
+	mockStore.EXPECT().GetRecipeTags(gomock.Any(), untaggedID).Return([]models.Tag{}, nil).Times(1)
+	mockStore.EXPECT().GetRecipeTags(gomock.Any(), missingID).Return(nil, fmt.Errorf("recipe with ID %s not found", missingID)).Times(1)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes/"+taggedID.String()+"/tags", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []models.Tag
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, []string{"dessert", "quick"}, []string{response[0].Name, response[1].Name})
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/recipes/"+untaggedID.String()+"/tags", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "[]", w.Body.String())
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/recipes/"+missingID.String()+"/tags", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRecipeHandler_GetDifficultyEstimate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	recipeID, missingID := uuid.New(), uuid.New()
+	recipe := &models.Recipe{ID: recipeID, Title: "Toast", Steps: []models.RecipeStep{{StepNumber: 1, Instruction: "Toast the bread"}}}
+	mockStore.EXPECT().GetRecipeByID(gomock.Any(), recipeID).Return(recipe, nil).Times(1)
+	mockStore.EXPECT().GetRecipeByID(gomock.Any(), missingID).Return(nil, fmt.Errorf("recipe with ID %s not found", missingID)).Times(1)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes/"+recipeID.String()+"/difficulty-estimate", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var estimate models.DifficultyEstimate
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &estimate))
+	assert.Equal(t, recipeID, estimate.RecipeID)
+	assert.Equal(t, models.DifficultyEasy, estimate.Difficulty)
+	assert.Len(t, estimate.Factors, 4)
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/recipes/"+missingID.String()+"/difficulty-estimate", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRecipeHandler_CreateRecipe_RequireIngredientsAndSteps(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{RequireIngredientsAndSteps: true})
+	router := setupTestRouter(recipeHandler)
+
+	recipeReq := &models.RecipeRequest{Title: "Empty Recipe"}
+	jsonBody, _ := json.Marshal(recipeReq)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var errorResponse map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	details := errorResponse["details"].(map[string]interface{})
+	assert.Contains(t, details, "Ingredients")
+	assert.Contains(t, details, "Steps")
+}
+
+func TestRecipeHandler_CreateRecipe_RequireCreatedBy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{RequireCreatedBy: true})
+	router := setupTestRouter(recipeHandler)
+
+	post := func(recipeReq *models.RecipeRequest) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(recipeReq)
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(&models.RecipeRequest{Title: "Anonymous Recipe"})
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "CreatedBy")
+	nilUser := uuid.Nil
+	assert.Equal(t, http.StatusUnprocessableEntity, post(&models.RecipeRequest{Title: "Anonymous Recipe", CreatedBy: &nilUser}).Code)
+
+	userID := uuid.New()
+	owned := &models.RecipeRequest{Title: "Owned Recipe", CreatedBy: &userID}
+	mockStore.EXPECT().CreateRecipe(gomock.Any(), owned).Return(&models.Recipe{ID: uuid.New(), Title: "Owned Recipe", CreatedBy: &userID}, nil).Times(1)
+	assert.Equal(t, http.StatusCreated, post(owned).Code)
+}
+
+func TestRecipeHandler_GetSimilarRecipes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	recipeID := uuid.New()
+	lonelyID := uuid.New()
+	similar := []*models.SimilarRecipe{
+		{Recipe: models.Recipe{ID: uuid.New(), Title: "Minestrone"}, SharedTags: 1, SharedIngredients: 3, Score: 4},
+	}
+	// The limit is clamped to the maximum.
+	mockStore.EXPECT().SimilarRecipes(gomock.Any(), recipeID, maxSimilarLimit).Return(similar, nil).Times(1)
+	mockStore.EXPECT().SimilarRecipes(gomock.Any(), lonelyID, defaultSimilarLimit).Return([]*models.SimilarRecipe{}, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes/"+recipeID.String()+"/similar?limit=500", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp []models.SimilarRecipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp, 1)
+	assert.Equal(t, "Minestrone", resp[0].Title)
+	assert.Equal(t, 4, resp[0].Score)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/recipes/"+lonelyID.String()+"/similar", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "[]", w.Body.String())
+}
+
+func TestRecipeHandler_CreateRecipe_StepDurationSeconds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	// 90 seconds on its own is a total; alongside minutes it must be a seconds part.
+	recipeReq := &models.RecipeRequest{
+		Title: "Blanched Beans",
+		Steps: []models.RecipeStepRequest{
+			{StepNumber: 1, Instruction: "Blanch", DurationSeconds: intPtr(90)},
+			{StepNumber: 2, Instruction: "Rest", DurationMinutes: intPtr(1), DurationSeconds: intPtr(90)},
+		},
+	}
+	jsonBody, _ := json.Marshal(recipeReq)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var errorResponse map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
+	assert.NoError(t, err)
+	details := errorResponse["details"].(map[string]interface{})
+	assert.Len(t, details, 1)
+	assert.Contains(t, details, "Steps[1].DurationSeconds")
+}
+
+func TestRecipeHandler_CreateRecipe_QuantityText(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	// Parsed text reaches the store as a plain quantity.
+	mockStore.EXPECT().CreateRecipe(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *models.RecipeRequest) (*models.Recipe, error) {
+		assert.Equal(t, 1.5, *r.Ingredients[0].Quantity)
+		assert.Equal(t, 0.75, *r.Ingredients[1].Quantity)
+		return &models.Recipe{ID: uuid.New(), Title: r.Title}, nil
+	}).Times(1)
+
+	recipeReq := &models.RecipeRequest{
+		Title: "Scones",
+		Ingredients: []models.RecipeIngredientRequest{
+			{IngredientName: "flour", QuantityText: strPtr("1 1/2")},
+			{IngredientName: "butter", QuantityText: strPtr("¾")},
+		},
+	}
+	jsonBody, _ := json.Marshal(recipeReq)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	recipeReq.Ingredients = []models.RecipeIngredientRequest{
+		{IngredientName: "flour", QuantityText: strPtr("1 1/0")},
+		{IngredientName: "butter", QuantityText: strPtr("0/4")},
+		{IngredientName: "sugar", QuantityText: strPtr("1/2"), Quantity: float64Ptr(0.5)},
+	}
+	jsonBody, _ = json.Marshal(recipeReq)
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var errorResponse map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+	details := errorResponse["details"].(map[string]interface{})
+	assert.Len(t, details, 3)
+	assert.Contains(t, details["Ingredients[0].QuantityText"], "zero denominator")
+	assert.Contains(t, details, "Ingredients[1].QuantityText")
+	assert.Contains(t, details, "Ingredients[2].QuantityText")
+}
+
+func TestRecipeHandler_GetRecipeBySlug(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	recipe := &models.Recipe{ID: uuid.New(), Title: "Apple Pie", Slug: "apple-pie"}
+	mockStore.EXPECT().GetRecipeBySlug(gomock.Any(), "apple-pie").Return(recipe, nil).Times(1)
+	mockStore.EXPECT().GetRecipeBySlug(gomock.Any(), "pear-pie").Return(nil, fmt.Errorf("recipe with slug pear-pie not found")).Times(1)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes/slug/apple-pie", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.Recipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, recipe.ID, response.ID)
+	assert.Equal(t, "apple-pie", response.Slug)
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/recipes/slug/pear-pie", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRecipeHandler_GetRecipe_IfModifiedSince(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	updatedAt := time.Date(2024, 5, 1, 12, 30, 15, 500_000_000, time.UTC)
+	recipe := &models.Recipe{ID: uuid.New(), Title: "Apple Pie", UpdatedAt: updatedAt}
+	mockStore.EXPECT().GetRecipeByID(gomock.Any(), recipe.ID).Return(recipe, nil).Times(3)
+
+	tests := []struct {
+		ifModifiedSince string
+		wantStatus      int
+	}{
+		{"", http.StatusOK},
+		{"Wed, 01 May 2024 12:30:15 GMT", http.StatusNotModified}, // Sub-second part is ignored
+		{"Wed, 01 May 2024 12:30:14 GMT", http.StatusOK},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes/"+recipe.ID.String(), nil)
+		if tt.ifModifiedSince != "" {
+			req.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, tt.wantStatus, w.Code, tt.ifModifiedSince)
+		assert.Equal(t, "Wed, 01 May 2024 12:30:15 GMT", w.Header().Get("Last-Modified"))
+		if tt.wantStatus == http.StatusNotModified {
+			assert.Empty(t, w.Body.String())
+		}
+	}
+}
+
+func TestRecipeHandler_GetRecipe_GroupIngredients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	recipeID := uuid.New()
+	mockStore.EXPECT().GetRecipeByID(gomock.Any(), recipeID).DoAndReturn(func(_ context.Context, id uuid.UUID) (*models.Recipe, error) {
+		return &models.Recipe{ID: id, Title: "Salad", Ingredients: []models.RecipeIngredient{
+			{IngredientName: strPtr("lettuce"), IngredientCategory: strPtr("Produce")},
+			{IngredientName: strPtr("feta"), IngredientCategory: strPtr("Dairy")},
+			{IngredientName: strPtr("salt")},
+			{IngredientName: strPtr("tomato"), IngredientCategory: strPtr("Produce")},
+		}}, nil
+	}).Times(2)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes/"+recipeID.String()+"?group_ingredients=true", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.Recipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Empty(t, response.Ingredients)
+	if assert.Len(t, response.IngredientGroups, 3) {
+		assert.Equal(t, "Produce", *response.IngredientGroups[0].Category)
+		assert.Equal(t, "lettuce", *response.IngredientGroups[0].Ingredients[0].IngredientName)
+		assert.Equal(t, "tomato", *response.IngredientGroups[0].Ingredients[1].IngredientName)
+		assert.Equal(t, "Dairy", *response.IngredientGroups[1].Category)
+		assert.Nil(t, response.IngredientGroups[2].Category)
+		assert.Equal(t, "salt", *response.IngredientGroups[2].Ingredients[0].IngredientName)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/recipes/"+recipeID.String(), nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	response = models.Recipe{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response.Ingredients, 4)
+	assert.Empty(t, response.IngredientGroups)
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/recipes/"+recipeID.String()+"?group_ingredients=maybe", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRecipeHandler_GetRecipe_RoundsQuantities(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeID := uuid.New()
+	mockStore.EXPECT().GetRecipeByID(gomock.Any(), recipeID).DoAndReturn(func(_ context.Context, id uuid.UUID) (*models.Recipe, error) {
+		third, whole := 1.0/3, 2.0
+		return &models.Recipe{ID: id, Title: "Pancakes", Ingredients: []models.RecipeIngredient{
+			{IngredientName: strPtr("flour"), Quantity: &third},
+			{IngredientName: strPtr("eggs"), Quantity: &whole},
+			{IngredientName: strPtr("salt")},
+		}}, nil
+	}).Times(3)
+
+	tests := []struct {
+		decimals int
+		want     []*float64
+	}{
+		{0, []*float64{float64Ptr(0.333), float64Ptr(2), nil}}, // Default of 3 decimals
+		{1, []*float64{float64Ptr(0.3), float64Ptr(2), nil}},
+		{-1, []*float64{float64Ptr(1.0 / 3), float64Ptr(2), nil}}, // Rounding disabled
+	}
+	for _, tt := range tests {
+		router := setupTestRouter(NewRecipeHandler(mockStore, config.APIConfig{QuantityDecimals: tt.decimals}))
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes/"+recipeID.String(), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.Recipe
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		for i, want := range tt.want {
+			assert.Equal(t, want, response.Ingredients[i].Quantity, "decimals=%d ingredient %d", tt.decimals, i)
+		}
+	}
+}
+
+func TestRecipeHandler_SearchRecipes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	results := []*models.RecipeSearchResult{{Recipe: models.Recipe{ID: uuid.New(), Title: "Chocolate Cake"}, Rank: 0.6}}
+	mockStore.EXPECT().SearchRecipes(gomock.Any(), models.RecipeSearchParams{Query: "choco", Prefix: true, Limit: defaultPageSize}).
+		Return(results, nil).Times(1)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes/search?q=choco&prefix=true", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []models.RecipeSearchResult
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 1)
+	assert.Equal(t, "Chocolate Cake", response[0].Title)
+
+	for _, query := range []string{"", "q=", "q=cake&prefix=maybe"} {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes/search?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestRecipeHandler_SearchRecipes_Fuzzy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	router := setupTestRouter(NewRecipeHandler(mockStore, config.APIConfig{SearchFuzzyThreshold: 0.3}))
+
+	results := []*models.RecipeSearchResult{{Recipe: models.Recipe{ID: uuid.New(), Title: "Chocolate Cake"}, Rank: 0.55, Fuzzy: true}}
+	mockStore.EXPECT().SearchRecipes(gomock.Any(), models.RecipeSearchParams{Query: "chocolat cak", Limit: defaultPageSize, FuzzyThreshold: 0.3}).
+		Return(results, nil).Times(1)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes/search?q=chocolat+cak", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response []models.RecipeSearchResult
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	if assert.Len(t, response, 1) {
+		assert.True(t, response[0].Fuzzy)
+	}
+}
+
+func TestRecipeHandler_CreateRecipe_YieldValidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	valid := &models.RecipeRequest{Title: "Cookies", Serves: intPtr(6), YieldQuantity: float64Ptr(24), YieldUnit: strPtr("cookies")}
+	mockStore.EXPECT().CreateRecipe(gomock.Any(), valid).
+		Return(&models.Recipe{ID: uuid.New(), Title: "Cookies", YieldQuantity: float64Ptr(24), YieldUnit: strPtr("cookies")}, nil).Times(1)
+
+	jsonBody, _ := json.Marshal(valid)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"yield_unit":"cookies"`)
+
+	invalid := []*models.RecipeRequest{
+		{Title: "Cookies", YieldUnit: strPtr("cookies")},  // Unit without a quantity
+		{Title: "Cookies", YieldQuantity: float64Ptr(-1)}, // Non-positive quantity
+	}
+	for _, recipeReq := range invalid {
+		jsonBody, _ := json.Marshal(recipeReq)
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestRecipeHandler_CreateRecipe_SourceValidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	valid := &models.RecipeRequest{Title: "Shakshuka", SourceName: strPtr("Jerusalem"), SourceURL: strPtr("https://example.com/shakshuka")}
+	mockStore.EXPECT().CreateRecipe(gomock.Any(), valid).
+		Return(&models.Recipe{ID: uuid.New(), Title: "Shakshuka", SourceName: valid.SourceName, SourceURL: valid.SourceURL}, nil).Times(1)
+
+	jsonBody, _ := json.Marshal(valid)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"source_url":"https://example.com/shakshuka"`)
+
+	for _, sourceURL := range []string{"not a url", "ftp://example.com/recipe", "example.com/recipe"} {
+		recipeReq := &models.RecipeRequest{Title: "Shakshuka", SourceURL: strPtr(sourceURL)}
+		jsonBody, _ := json.Marshal(recipeReq)
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, sourceURL)
+	}
+}
+
+func TestRecipeHandler_CreateRecipe_NotesValidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	valid := &models.RecipeRequest{Title: "Risotto", Notes: strPtr("Keep the stock at a simmer.")}
+	mockStore.EXPECT().CreateRecipe(gomock.Any(), valid).
+		Return(&models.Recipe{ID: uuid.New(), Title: "Risotto", Notes: valid.Notes}, nil).Times(1)
+
+	jsonBody, _ := json.Marshal(valid)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"notes":"Keep the stock at a simmer."`)
+
+	jsonBody, _ = json.Marshal(&models.RecipeRequest{Title: "Risotto", Notes: strPtr(strings.Repeat("a", 10001))})
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRecipeHandler_CreateRecipe_StrictDuplicateIngredients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	// Lines 1 and 2 repeat line 0 (line 2 once quantity_text is parsed, and with its own sort order);
+	// line 3 differs in quantity.
+	body := `{"title":"Bread","ingredients":[
+		{"ingredient_name":"flour","quantity":500,"unit_name":"g"},
+		{"ingredient_name":"flour","quantity":500,"unit_name":"g"},
+		{"ingredient_name":"flour","quantity_text":"500","unit_name":"g","sort_order":7},
+		{"ingredient_name":"flour","quantity":250,"unit_name":"g"}]}`
+	post := func(query string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes"+query, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post("?strict=true")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var errorResponse APIError
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+	details, _ := errorResponse.Details.(map[string]interface{})
+	assert.Contains(t, details, "Ingredients[1]")
+	assert.Contains(t, details, "Ingredients[2]")
+	assert.NotContains(t, details, "Ingredients[3]")
+
+	assert.Equal(t, http.StatusBadRequest, post("?strict=maybe").Code)
+
+	// Not strict: passed through to the store unchanged.
+	mockStore.EXPECT().CreateRecipe(gomock.Any(), gomock.Any()).Return(&models.Recipe{ID: uuid.New(), Title: "Bread"}, nil).Times(1)
+	assert.Equal(t, http.StatusCreated, post("").Code)
+}
+
+func TestRecipeHandler_CreateRecipe_IngredientSortOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// A shared sort_order is rejected and points at the earlier line.
+	w := post(`{"title":"Bread","ingredients":[
+		{"ingredient_name":"flour","sort_order":1},
+		{"ingredient_name":"water","sort_order":0},
+		{"ingredient_name":"salt","sort_order":1}]}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var errorResponse APIError
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+	details, _ := errorResponse.Details.(map[string]interface{})
+	assert.Equal(t, "sort_order 1 is already used by ingredients[0]", details["Ingredients[2].SortOrder"])
+
+	// Lines without a sort_order are numbered after the highest one given, in the order they were sent.
+	for body, expected := range map[string][]int{
+		`[{"ingredient_name":"flour"},{"ingredient_name":"water"},{"ingredient_name":"salt"}]`:                {0, 1, 2},
+		`[{"ingredient_name":"flour","sort_order":1},{"ingredient_name":"water"},{"ingredient_name":"salt"}]`: {1, 2, 3},
+		`[{"ingredient_name":"flour"},{"ingredient_name":"water","sort_order":5},{"ingredient_name":"salt"}]`: {6, 5, 7},
+		`[{"ingredient_name":"flour","sort_order":0},{"ingredient_name":"water"},{"ingredient_name":"salt"}]`: {0, 1, 2},
+	} {
+		mockStore.EXPECT().CreateRecipe(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, req *models.RecipeRequest) (*models.Recipe, error) {
+				var got []int
+				for _, ing := range req.Ingredients {
+					got = append(got, *ing.SortOrder)
+				}
+				assert.Equal(t, expected, got, body)
+				return &models.Recipe{ID: uuid.New(), Title: req.Title}, nil
+			}).Times(1)
+		w = post(`{"title":"Bread","ingredients":` + body + `}`)
+		assert.Equal(t, http.StatusCreated, w.Code, body)
+	}
+}
+
+func TestRecipeHandler_CreateRecipe_QuantityRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	valid := &models.RecipeRequest{Title: "Aioli", Ingredients: []models.RecipeIngredientRequest{
+		{IngredientName: "garlic", QuantityMin: float64Ptr(2), QuantityMax: float64Ptr(3), UnitName: strPtr("clove"), SortOrder: intPtr(0)},
+	}}
+	mockStore.EXPECT().CreateRecipe(gomock.Any(), valid).Return(&models.Recipe{ID: uuid.New(), Title: "Aioli", Ingredients: []models.RecipeIngredient{
+		{IngredientName: strPtr("garlic"), QuantityMin: float64Ptr(2), QuantityMax: float64Ptr(10.0 / 3)},
+	}}, nil).Times(1)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	jsonBody, _ := json.Marshal(valid)
+	w := post(string(jsonBody))
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"quantity_min":2,"quantity_max":3.333`)
+
+	for _, ingredient := range []string{
+		`{"ingredient_name":"garlic","quantity_min":3,"quantity_max":2}`,              // Inverted
+		`{"ingredient_name":"garlic","quantity_max":3}`,                               // Half a range
+		`{"ingredient_name":"garlic","quantity":2,"quantity_min":2,"quantity_max":3}`, // Both forms
+		`{"ingredient_name":"garlic","quantity_text":"2","quantity_min":2,"quantity_max":3}`,
+	} {
+		w := post(`{"title":"Aioli","ingredients":[` + ingredient + `]}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code, ingredient)
+	}
+}
+
+func TestRecipeHandler_CreateRecipe_StepTypeValidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	makeAhead := models.StepTypeMakeAhead
+	valid := &models.RecipeRequest{Title: "Overnight oats", Steps: []models.RecipeStepRequest{
+		{StepNumber: 1, Instruction: "Soak the oats overnight", StepType: &makeAhead},
+		{StepNumber: 2, Instruction: "Top with fruit"},
+	}}
+	mockStore.EXPECT().CreateRecipe(gomock.Any(), valid).Return(&models.Recipe{ID: uuid.New(), Title: "Overnight oats", Steps: []models.RecipeStep{
+		{StepNumber: 1, Instruction: "Soak the oats overnight", StepType: models.StepTypeMakeAhead},
+		{StepNumber: 2, Instruction: "Top with fruit", StepType: models.StepTypeNormal},
+	}}, nil).Times(1)
+
+	jsonBody, _ := json.Marshal(valid)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"step_type":"make_ahead"`)
+
+	body := `{"title":"Overnight oats","steps":[{"step_number":1,"instruction":"Soak","step_type":"skippable"}]}`
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "StepType")
+}
+
+func TestRecipeHandler_CreateRecipe_PreparationValidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	valid := &models.RecipeRequest{Title: "Soffritto", Ingredients: []models.RecipeIngredientRequest{
+		{IngredientName: "onion", Quantity: float64Ptr(2), Preparation: strPtr("finely chopped"), SortOrder: intPtr(0)},
+	}}
+	mockStore.EXPECT().CreateRecipe(gomock.Any(), valid).Return(&models.Recipe{ID: uuid.New(), Title: "Soffritto", Ingredients: []models.RecipeIngredient{
+		{IngredientName: strPtr("onion"), Quantity: float64Ptr(2), Preparation: strPtr("finely chopped")},
+	}}, nil).Times(1)
+
+	jsonBody, _ := json.Marshal(valid)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"preparation":"finely chopped"`)
+
+	tooLong := &models.RecipeRequest{Title: "Soffritto", Ingredients: []models.RecipeIngredientRequest{
+		{IngredientName: "onion", Preparation: strPtr(strings.Repeat("a", 256))},
+	}}
+	jsonBody, _ = json.Marshal(tooLong)
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "Preparation")
+}
+
+func TestRecipeHandler_DeleteRecipe_CamelCase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(JSONKeyCaseMiddleware(KeyCaseCamel))
+	router.DELETE("/api/v1/recipes/:id", recipeHandler.DeleteRecipe)
+
+	recipeID := uuid.New()
+	mockStore.EXPECT().DeleteRecipe(gomock.Any(), recipeID).Return(nil).Times(1)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/recipes/"+recipeID.String(), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestRecipeHandler_DeleteRecipes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	id1, id2, missing := uuid.New(), uuid.New(), uuid.New()
+	mockStore.EXPECT().DeleteRecipes(gomock.Any(), []uuid.UUID{id1, id2, missing}).Return(2, nil).Times(1)
+
+	jsonBody, _ := json.Marshal(models.RecipeBulkDeleteRequest{RecipeIDs: []uuid.UUID{id1, id2, id1, missing}})
+	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/recipes", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.RecipeBulkDeleteResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, models.RecipeBulkDeleteResponse{Deleted: 2, NotFound: 1}, response)
+
+	// An empty list is rejected before reaching the store.
+	req, _ = http.NewRequest(http.MethodDelete, "/api/v1/recipes", bytes.NewBufferString(`{"recipe_ids":[]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRecipeHandler_RecipesExist(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	router := setupTestRouter(NewRecipeHandler(mockStore, config.APIConfig{}))
+
+	id1, id2, missing := uuid.New(), uuid.New(), uuid.New()
+	mockStore.EXPECT().ExistingRecipeIDs(gomock.Any(), []uuid.UUID{id1, missing, id2}).Return([]uuid.UUID{id2, id1}, nil).Times(1)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes/exists", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(fmt.Sprintf(`{"recipe_ids":[%q,%q,%q,%q]}`, id1, missing, id2, id1))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.RecipeExistsResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, []uuid.UUID{id1, id2}, response.Existing)
+	assert.Equal(t, []uuid.UUID{missing}, response.Missing)
+
+	assert.Equal(t, http.StatusBadRequest, post(`{"recipe_ids":[]}`).Code)
+	assert.Equal(t, http.StatusBadRequest, post(`{"recipe_ids":["not-a-uuid"]}`).Code)
+}
+
+func TestRecipeHandler_CreateRecipe_UnitQuantityCoherence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// A unit with no quantity is rejected with a readable message.
+	w := post(`{"title":"Bread","ingredients":[{"ingredient_name":"flour","unit_name":"cup"}]}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var errorResponse APIError
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+	details, _ := errorResponse.Details.(map[string]interface{})
+	assert.Equal(t, validationMessages["quantity_with_unit"], details["Ingredients[0].UnitName"])
+
+	// A quantity with no unit is accepted but flagged; quantity_text and ranges count as quantities.
+	mockStore.EXPECT().CreateRecipe(gomock.Any(), gomock.Any()).Return(&models.Recipe{ID: uuid.New(), Title: "Bread"}, nil).Times(1)
+	w = post(`{"title":"Bread","ingredients":[
+		{"ingredient_name":"flour","quantity_text":"2","unit_name":"cup"},
+		{"ingredient_name":"egg","quantity":3},
+		{"ingredient_name":"salt","quantity_min":1,"quantity_max":2,"unit_name":"pinch"},
+		{"ingredient_name":"pepper"}],
+		"steps":[{"step_number":1,"instruction":"Mix."}]}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response models.Recipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	if assert.Len(t, response.Warnings, 1) {
+		assert.Equal(t, "Ingredients[1].UnitName", response.Warnings[0].Field)
+	}
+}
+
+func TestRecipeHandler_CreateRecipe_Warnings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	fields := func(warnings []models.ValidationWarning) []string {
+		var names []string
+		for _, w := range warnings {
+			names = append(names, w.Field)
+		}
+		return names
+	}
+
+	// Suspicious but valid input is created and the warnings come back with the 201.
+	mockStore.EXPECT().CreateRecipe(gomock.Any(), gomock.Any()).Return(&models.Recipe{ID: uuid.New(), Title: "Toast"}, nil).Times(1)
+	w := post(`{"title":"Toast","cook_time_minutes":0}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response models.Recipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, []string{"CookTimeMinutes", "Ingredients", "Steps"}, fields(response.Warnings))
+
+	// When validation fails, warnings are returned next to the blocking errors.
+	w = post(`{"title":"To","prep_time_minutes":0,"ingredients":[{"ingredient_name":"bread","quantity":2}],
+		"steps":[{"step_number":1,"instruction":"Toast."}]}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var errorResponse APIError
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+	assert.Contains(t, errorResponse.Details, "Title")
+	assert.Equal(t, []string{"PrepTimeMinutes", "Ingredients[0].UnitName"}, fields(errorResponse.Warnings))
+
+	// A complete recipe has no warnings at all.
+	mockStore.EXPECT().CreateRecipe(gomock.Any(), gomock.Any()).Return(&models.Recipe{ID: uuid.New(), Title: "Toast"}, nil).Times(1)
+	w = post(`{"title":"Toast","cook_time_minutes":3,"ingredients":[{"ingredient_name":"bread","quantity":2,"unit_name":"slice"}],
+		"steps":[{"step_number":1,"instruction":"Toast."}]}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.NotContains(t, w.Body.String(), "warnings")
+}
+
+func TestRecipeHandler_CreateRecipe_NameLengthLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	post := func(handler *RecipeHandler, recipeReq *models.RecipeRequest) map[string]interface{} {
+		jsonBody, _ := json.Marshal(recipeReq)
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		setupTestRouter(handler).ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var errorResponse map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+		details, _ := errorResponse["details"].(map[string]interface{})
+		return details
+	}
+
+	// Without a configured limit the column sizes apply.
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	details := post(NewRecipeHandler(mockStore, config.APIConfig{}), &models.RecipeRequest{
+		Title: "Long Names",
+		Ingredients: []models.RecipeIngredientRequest{
+			{IngredientName: strings.Repeat("a", 256), Quantity: float64Ptr(1), UnitName: strPtr(strings.Repeat("g", 51))},
+		},
+	})
+	assert.Equal(t, "must be at most 255 characters", details["Ingredients[0].IngredientName"])
+	assert.Equal(t, "must be at most 50 characters", details["Ingredients[0].UnitName"])
+
+	// A configured limit is counted in characters, not bytes.
+	details = post(NewRecipeHandler(mockStore, config.APIConfig{MaxNameLength: 5}), &models.RecipeRequest{
+		Title:       "Short Names",
+		Ingredients: []models.RecipeIngredientRequest{{IngredientName: "crème"}, {IngredientName: "butter"}},
+		Tags:        []models.RecipeTagRequest{{Name: "dinner"}},
+	})
+	assert.NotContains(t, details, "Ingredients[0].IngredientName")
+	assert.Equal(t, "must be at most 5 characters", details["Ingredients[1].IngredientName"])
+	assert.Equal(t, "must be at most 5 characters", details["Tags[0].Name"])
+}
+
+func TestRecipeHandler_GetRecipeStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+	router.GET("/api/v1/stats", recipeHandler.GetRecipeStats)
+
+	get := func() *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/stats", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	tagID := uuid.New()
+	mockStore.EXPECT().RecipeStats(gomock.Any()).Return(&models.RecipeStats{
+		TotalRecipes:           12,
+		TotalIngredients:       40,
+		TotalTags:              7,
+		AveragePrepTimeMinutes: float64Ptr(14.5),
+		MostCommonTag:          &models.TagCount{ID: tagID, Name: "dinner", Count: 5},
+	}, nil)
+	w := get()
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"total_recipes":12,"total_ingredients":40,"total_tags":7,
+		"average_prep_time_minutes":14.5,"average_cook_time_minutes":null,
+		"most_common_tag":{"id":"`+tagID.String()+`","name":"dinner","count":5,"archived":false}}`, w.Body.String())
+
+	mockStore.EXPECT().RecipeStats(gomock.Any()).Return(nil, errors.New("connection refused"))
+	assert.Equal(t, http.StatusInternalServerError, get().Code)
+}
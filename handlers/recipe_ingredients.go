@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store"
+)
+
+// AddRecipeIngredient handles adding one ingredient to a recipe without replacing the recipe.
+// @Summary Add an ingredient to a recipe
+// @Description Append an ingredient to a recipe, after its current ingredients; sort_order in the request is ignored.
+// @Description The ingredient and unit are found or created by name as when creating a recipe.
+// @Tags recipes
+// @Accept json
+// @Produce json
+// @Param id path string true "Recipe ID (UUID)"
+// @Param ingredient body models.RecipeIngredientRequest true "Ingredient to add"
+// @Success 201 {object} models.RecipeIngredient
+// @Failure 400 {object} APIError "Invalid input, ID format or unknown ingredient ID"
+// @Failure 404 {object} APIError "Recipe not found"
+// @Failure 409 {object} APIError "The recipe already has this ingredient"
+// @Failure 500 {object} APIError "Server error"
+// @Header 201 {string} Location "URL of the recipe's ingredient"
+// @Router /recipes/{id}/ingredients [post]
+func (h *RecipeHandler) AddRecipeIngredient(c *gin.Context) {
+	recipeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid recipe ID format: "+err.Error())
+		return
+	}
+
+	var req models.RecipeIngredientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPayload, "Invalid request payload: "+err.Error())
+		return
+	}
+	trimStrings(&req)
+	validationErrors := map[string]string{}
+	if err := validate.Struct(req); err != nil {
+		validationErrors = formatValidationErrors(err)
+	}
+	h.checkIngredientRequest(&req, "", validationErrors)
+	if len(validationErrors) > 0 {
+		RespondWithDetailedError(c, http.StatusBadRequest, ErrCodeValidationFailed, "Validation failed", validationErrors)
+		return
+	}
+
+	ingredient, err := h.store.AddIngredient(c.Request.Context(), recipeID, &req)
+	if err != nil {
+		if errors.Is(err, store.ErrUnknownIngredient) {
+			RespondWithError(c, http.StatusBadRequest, ErrCodeUnknownIngredient, "Invalid ingredient reference: "+err.Error())
+		} else if errors.Is(err, store.ErrConflict) {
+			RespondWithError(c, http.StatusConflict, ErrCodeConflict, "Failed to add ingredient: "+err.Error())
+		} else if strings.Contains(err.Error(), "not found") { // Basic check
+			RespondWithError(c, http.StatusNotFound, ErrCodeRecipeNotFound, "Recipe not found: "+err.Error())
+		} else {
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to add ingredient: "+err.Error())
+		}
+		return
+	}
+	if decimals, ok := h.quantityDecimals(); ok {
+		ingredient.RoundQuantities(decimals)
+	}
+	c.Header("Location", c.Request.URL.Path+"/"+ingredient.IngredientID.String())
+	RespondWithJSON(c, http.StatusCreated, ingredient)
+}
+
+// RemoveRecipeIngredient handles removing one ingredient from a recipe without replacing the recipe.
+// @Summary Remove an ingredient from a recipe
+// @Description Remove an ingredient from a recipe and return the remaining ingredients; later ingredients move up so
+// @Description sort_order has no gaps. The ingredient itself is not deleted.
+// @Tags recipes
+// @Produce json
+// @Param id path string true "Recipe ID (UUID)"
+// @Param ingredient_id path string true "Ingredient ID (UUID)"
+// @Success 200 {array} models.RecipeIngredient
+// @Failure 400 {object} APIError "Invalid ID format"
+// @Failure 404 {object} APIError "Recipe not found, or the ingredient is not in the recipe"
+// @Failure 500 {object} APIError "Server error"
+// @Router /recipes/{id}/ingredients/{ingredient_id} [delete]
+func (h *RecipeHandler) RemoveRecipeIngredient(c *gin.Context) {
+	recipeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid recipe ID format: "+err.Error())
+		return
+	}
+	ingredientID, err := uuid.Parse(c.Param("ingredient_id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid ingredient ID format: "+err.Error())
+		return
+	}
+
+	ingredients, err := h.store.RemoveIngredient(c.Request.Context(), recipeID, ingredientID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found in recipe") {
+			RespondWithError(c, http.StatusNotFound, ErrCodeIngredientNotFound, "Ingredient not found: "+err.Error())
+		} else if strings.Contains(err.Error(), "not found") { // Basic check
+			RespondWithError(c, http.StatusNotFound, ErrCodeRecipeNotFound, "Recipe not found: "+err.Error())
+		} else {
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to remove ingredient: "+err.Error())
+		}
+		return
+	}
+	if ingredients == nil {
+		ingredients = []models.RecipeIngredient{} // Removing the last ingredient responds [], not null
+	}
+	if decimals, ok := h.quantityDecimals(); ok {
+		for i := range ingredients {
+			ingredients[i].RoundQuantities(decimals)
+		}
+	}
+	RespondWithJSON(c, http.StatusOK, ingredients)
+}
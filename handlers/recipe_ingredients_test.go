@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gaanon/gorecipes_v2/config"
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store"
+	"github.com/gaanon/gorecipes_v2/store/mocks"
+)
+
+func TestRecipeHandler_AddRecipeIngredient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	router := setupTestRouter(NewRecipeHandler(mockStore, config.APIConfig{}))
+
+	recipeID, missingID, flour, unknownID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	mockStore.EXPECT().AddIngredient(gomock.Any(), recipeID, &models.RecipeIngredientRequest{
+		IngredientName: "flour", Quantity: float64Ptr(1.5), QuantityText: strPtr("1 1/2"), UnitName: strPtr("cups"), SortOrder: intPtr(0),
+	}).Return(&models.RecipeIngredient{IngredientID: flour, IngredientName: strPtr("flour"), Quantity: float64Ptr(1.5), SortOrder: 3}, nil).Times(1)
+	mockStore.EXPECT().AddIngredient(gomock.Any(), recipeID, &models.RecipeIngredientRequest{IngredientID: &unknownID}).
+		Return(nil, fmt.Errorf("processing ingredient %s: %w: no ingredient with ID %s", unknownID, store.ErrUnknownIngredient, unknownID)).Times(1)
+	mockStore.EXPECT().AddIngredient(gomock.Any(), missingID, &models.RecipeIngredientRequest{IngredientName: "salt"}).
+		Return(nil, fmt.Errorf("recipe with ID %s not found", missingID)).Times(1)
+	mockStore.EXPECT().AddIngredient(gomock.Any(), recipeID, &models.RecipeIngredientRequest{IngredientName: "sugar"}).
+		Return(nil, fmt.Errorf("%w: recipe %s already has ingredient sugar", store.ErrConflict, recipeID)).Times(1)
+
+	post := func(id uuid.UUID, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/recipes/"+id.String()+"/ingredients", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(recipeID, `{"ingredient_name": " flour ", "quantity_text": "1 1/2", "unit_name": "cups", "sort_order": 0}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/api/v1/recipes/"+recipeID.String()+"/ingredients/"+flour.String(), w.Header().Get("Location"))
+	var ingredient models.RecipeIngredient
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &ingredient))
+	assert.Equal(t, 3, ingredient.SortOrder)
+
+	w = post(recipeID, fmt.Sprintf(`{"ingredient_id": %q}`, unknownID))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrCodeUnknownIngredient)
+	assert.Equal(t, http.StatusNotFound, post(missingID, `{"ingredient_name": "salt"}`).Code)
+	w = post(recipeID, `{"ingredient_name": "sugar"}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), ErrCodeConflict)
+
+	w = post(recipeID, `{"ingredient_name": "flour", "quantity_text": "lots"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "QuantityText")
+	assert.Equal(t, http.StatusBadRequest, post(recipeID, `{"ingredient_name": "flour", "quantity_min": 3, "quantity_max": 2}`).Code)
+	assert.Equal(t, http.StatusBadRequest, post(recipeID, `{"unit_name": "cups"}`).Code)
+}
+
+func TestRecipeHandler_RemoveRecipeIngredient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	router := setupTestRouter(NewRecipeHandler(mockStore, config.APIConfig{}))
+
+	recipeID, missingID := uuid.New(), uuid.New()
+	salt, flour, sugar := uuid.New(), uuid.New(), uuid.New()
+	mockStore.EXPECT().RemoveIngredient(gomock.Any(), recipeID, salt).Return([]models.RecipeIngredient{
+		{IngredientID: flour, IngredientName: strPtr("flour"), SortOrder: 0},
+	}, nil).Times(1)
+	mockStore.EXPECT().RemoveIngredient(gomock.Any(), recipeID, sugar).
+		Return(nil, fmt.Errorf("ingredient %s not found in recipe %s", sugar, recipeID)).Times(1)
+	mockStore.EXPECT().RemoveIngredient(gomock.Any(), missingID, salt).
+		Return(nil, fmt.Errorf("recipe with ID %s not found", missingID)).Times(1)
+
+	remove := func(id, ingredientID string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodDelete, "/api/v1/recipes/"+id+"/ingredients/"+ingredientID, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := remove(recipeID.String(), salt.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	var ingredients []models.RecipeIngredient
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &ingredients))
+	if assert.Len(t, ingredients, 1) {
+		assert.Equal(t, flour, ingredients[0].IngredientID)
+	}
+
+	w = remove(recipeID.String(), sugar.String())
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), ErrCodeIngredientNotFound)
+	w = remove(missingID.String(), salt.String())
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), ErrCodeRecipeNotFound)
+	assert.Equal(t, http.StatusBadRequest, remove(recipeID.String(), "not-a-uuid").Code)
+
+	// Removing the last ingredient leaves an empty list.
+	mockStore.EXPECT().RemoveIngredient(gomock.Any(), recipeID, flour).Return(nil, nil).Times(1)
+	w = remove(recipeID.String(), flour.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[]`, w.Body.String())
+}
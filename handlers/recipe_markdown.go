@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/gaanon/gorecipes_v2/models"
+)
+
+// markdownContentType is the media type of recipes rendered as Markdown (RFC 7763).
+const markdownContentType = "text/markdown; charset=utf-8"
+
+// markdownEscaper escapes characters that would otherwise be read as inline Markdown formatting.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`, `<`, `\<`, `>`, `\>`,
+)
+
+// GetRecipeMarkdown handles rendering a recipe as Markdown for printing or sharing.
+// @Summary Export a recipe as Markdown
+// @Description Render a recipe as Markdown: a title heading, an ingredient list with quantities and units,
+// @Description numbered steps, equipment, notes and tags. Quantities are rounded like in JSON responses.
+// @Tags recipes
+// @Produce text/markdown
+// @Param id path string true "Recipe ID (UUID)"
+// @Success 200 {string} string "The recipe as Markdown"
+// @Failure 400 {object} APIError "Invalid ID format"
+// @Failure 404 {object} APIError "Recipe not found"
+// @Failure 500 {object} APIError "Server error"
+// @Router /recipes/{id}/markdown [get]
+func (h *RecipeHandler) GetRecipeMarkdown(c *gin.Context) {
+	recipeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid recipe ID format: "+err.Error())
+		return
+	}
+
+	recipe, err := h.store.GetRecipeByID(c.Request.Context(), recipeID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") { // Basic check
+			RespondWithError(c, http.StatusNotFound, ErrCodeRecipeNotFound, "Recipe not found: "+err.Error())
+		} else {
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to get recipe: "+err.Error())
+		}
+		return
+	}
+	h.roundQuantities(recipe)
+	c.Data(http.StatusOK, markdownContentType, []byte(renderRecipeMarkdown(recipe)))
+}
+
+// renderRecipeMarkdown renders a recipe as Markdown. Sections without content are left out.
+func renderRecipeMarkdown(recipe *models.Recipe) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", markdownEscaper.Replace(recipe.Title))
+	if recipe.Description != nil && strings.TrimSpace(*recipe.Description) != "" {
+		fmt.Fprintf(&b, "\n%s\n", markdownEscaper.Replace(strings.TrimSpace(*recipe.Description)))
+	}
+
+	var facts []string
+	if recipe.Serves != nil {
+		facts = append(facts, fmt.Sprintf("**Serves:** %d", *recipe.Serves))
+	}
+	if recipe.YieldQuantity != nil {
+		yield := formatMarkdownQuantity(*recipe.YieldQuantity)
+		if recipe.YieldUnit != nil {
+			yield += " " + markdownEscaper.Replace(*recipe.YieldUnit)
+		}
+		facts = append(facts, "**Makes:** "+yield)
+	}
+	for _, t := range []struct {
+		label   string
+		minutes *int
+	}{
+		{"Prep", recipe.PrepTimeMinutes},
+		{"Cook", recipe.CookTimeMinutes},
+		{"Total", recipe.TotalTimeMinutes},
+	} {
+		if t.minutes != nil && *t.minutes > 0 {
+			facts = append(facts, fmt.Sprintf("**%s:** %d min", t.label, *t.minutes))
+		}
+	}
+	if recipe.Difficulty != nil {
+		facts = append(facts, fmt.Sprintf("**Difficulty:** %s", *recipe.Difficulty))
+	}
+	if len(facts) > 0 {
+		fmt.Fprintf(&b, "\n%s\n", strings.Join(facts, " · "))
+	}
+
+	if len(recipe.Ingredients) > 0 {
+		b.WriteString("\n## Ingredients\n\n")
+		for _, ing := range recipe.Ingredients {
+			fmt.Fprintf(&b, "- %s\n", formatMarkdownIngredient(ing))
+		}
+	}
+
+	if len(recipe.Equipment) > 0 {
+		b.WriteString("\n## Equipment\n\n")
+		for _, eq := range recipe.Equipment {
+			fmt.Fprintf(&b, "- %s\n", markdownEscaper.Replace(eq.Name))
+		}
+	}
+
+	if len(recipe.Steps) > 0 {
+		b.WriteString("\n## Steps\n\n")
+		for i, step := range recipe.Steps {
+			// Continuation lines are indented so that multi-paragraph steps stay inside their list item.
+			lines := strings.Split(strings.TrimSpace(markdownEscaper.Replace(step.Instruction)), "\n")
+			fmt.Fprintf(&b, "%d. %s\n", i+1, strings.Join(lines, "\n   "))
+		}
+	}
+
+	if recipe.Notes != nil && strings.TrimSpace(*recipe.Notes) != "" {
+		fmt.Fprintf(&b, "\n## Notes\n\n%s\n", markdownEscaper.Replace(strings.TrimSpace(*recipe.Notes)))
+	}
+
+	if len(recipe.Tags) > 0 {
+		names := make([]string, len(recipe.Tags))
+		for i, tag := range recipe.Tags {
+			names[i] = markdownEscaper.Replace(tag.Name)
+		}
+		fmt.Fprintf(&b, "\n**Tags:** %s\n", strings.Join(names, ", "))
+	}
+
+	if recipe.SourceURL != nil {
+		name := *recipe.SourceURL
+		if recipe.SourceName != nil {
+			name = *recipe.SourceName
+		}
+		fmt.Fprintf(&b, "\n*Source: [%s](<%s>)*\n", markdownEscaper.Replace(name), *recipe.SourceURL)
+	} else if recipe.SourceName != nil {
+		fmt.Fprintf(&b, "\n*Source: %s*\n", markdownEscaper.Replace(*recipe.SourceName))
+	}
+	return b.String()
+}
+
+// formatMarkdownIngredient renders one ingredient line, e.g. "200 g flour, sifted (plain)" or "2–3 eggs".
+func formatMarkdownIngredient(ing models.RecipeIngredient) string {
+	return formatIngredientLine(ing, markdownEscaper.Replace)
+}
+
+// formatIngredientLine renders one ingredient line, passing each piece of recipe text through escape.
+func formatIngredientLine(ing models.RecipeIngredient, escape func(string) string) string {
+	var parts []string
+	switch {
+	case ing.Quantity != nil:
+		parts = append(parts, formatMarkdownQuantity(*ing.Quantity))
+	case ing.QuantityMin != nil && ing.QuantityMax != nil:
+		parts = append(parts, formatMarkdownQuantity(*ing.QuantityMin)+"–"+formatMarkdownQuantity(*ing.QuantityMax))
+	}
+	if ing.Unit != nil {
+		if ing.Unit.Abbreviation != nil && *ing.Unit.Abbreviation != "" {
+			parts = append(parts, escape(*ing.Unit.Abbreviation))
+		} else if ing.Unit.Name != nil {
+			parts = append(parts, escape(*ing.Unit.Name))
+		}
+	}
+	if ing.IngredientName != nil {
+		parts = append(parts, escape(*ing.IngredientName))
+	}
+	line := strings.Join(parts, " ")
+	if ing.Preparation != nil && *ing.Preparation != "" {
+		line += ", " + escape(*ing.Preparation)
+	}
+	if ing.Notes != nil && *ing.Notes != "" {
+		line += " (" + escape(*ing.Notes) + ")"
+	}
+	return line
+}
+
+// formatMarkdownQuantity formats a quantity without trailing zeros, e.g. 1.5 rather than 1.500000.
+func formatMarkdownQuantity(q float64) string {
+	return strconv.FormatFloat(q, 'f', -1, 64)
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gaanon/gorecipes_v2/config"
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store/mocks"
+)
+
+func TestRenderRecipeMarkdown(t *testing.T) {
+	easy := models.DifficultyEasy
+	recipe := &models.Recipe{
+		Title:            "Pancakes *Deluxe*",
+		Description:      strPtr("Fluffy."),
+		Serves:           intPtr(4),
+		PrepTimeMinutes:  intPtr(10),
+		CookTimeMinutes:  intPtr(0),
+		TotalTimeMinutes: intPtr(10),
+		Difficulty:       &easy,
+		Ingredients: []models.RecipeIngredient{
+			{IngredientName: strPtr("flour"), Quantity: float64Ptr(200), Unit: &models.MeasurementUnit{Name: strPtr("gram"), Abbreviation: strPtr("g")}, Preparation: strPtr("sifted")},
+			{IngredientName: strPtr("eggs"), QuantityMin: float64Ptr(2), QuantityMax: float64Ptr(3)},
+			{IngredientName: strPtr("milk"), Quantity: float64Ptr(1.5), Unit: &models.MeasurementUnit{Name: strPtr("cup")}, Notes: strPtr("whole")},
+			{IngredientName: strPtr("salt")},
+		},
+		Equipment: []models.Equipment{{Name: "frying pan"}},
+		Steps: []models.RecipeStep{
+			{StepNumber: 1, Instruction: "Mix."},
+			{StepNumber: 2, Instruction: "Fry.\nFlip once."},
+		},
+		Tags:       []models.Tag{{Name: "breakfast"}, {Name: "sweet"}},
+		SourceName: strPtr("Grandma"),
+	}
+
+	assert.Equal(t, `# Pancakes \*Deluxe\*
+
+Fluffy.
+
+**Serves:** 4 · **Prep:** 10 min · **Total:** 10 min · **Difficulty:** easy
+
+## Ingredients
+
+- 200 g flour, sifted
+- 2–3 eggs
+- 1.5 cup milk (whole)
+- salt
+
+## Equipment
+
+- frying pan
+
+## Steps
+
+1. Mix.
+2. Fry.
+   Flip once.
+
+**Tags:** breakfast, sweet
+
+*Source: Grandma*
+`, renderRecipeMarkdown(recipe))
+
+	assert.Equal(t, "# Toast\n", renderRecipeMarkdown(&models.Recipe{Title: "Toast"}))
+}
+
+func TestRecipeHandler_GetRecipeMarkdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+	router.GET("/api/v1/recipes/:id/markdown", recipeHandler.GetRecipeMarkdown)
+
+	get := func(id string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes/"+id+"/markdown", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	recipeID := uuid.New()
+	mockStore.EXPECT().GetRecipeByID(gomock.Any(), recipeID).Return(&models.Recipe{
+		ID:          recipeID,
+		Title:       "Toast",
+		Ingredients: []models.RecipeIngredient{{IngredientName: strPtr("bread"), Quantity: float64Ptr(1.0 / 3)}},
+	}, nil)
+	w := get(recipeID.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/markdown; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "# Toast\n\n## Ingredients\n\n- 0.333 bread\n", w.Body.String())
+
+	missingID := uuid.New()
+	mockStore.EXPECT().GetRecipeByID(gomock.Any(), missingID).Return(nil, errors.New("recipe not found"))
+	assert.Equal(t, http.StatusNotFound, get(missingID.String()).Code)
+	assert.Equal(t, http.StatusBadRequest, get("not-a-uuid").Code)
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/gaanon/gorecipes_v2/models"
+)
+
+// printContentSecurityPolicy replaces APIContentSecurityPolicy on the print page, whose styles are inline.
+const printContentSecurityPolicy = "default-src 'none'; style-src 'unsafe-inline'; frame-ancestors 'none'"
+
+// printTemplate renders a recipe as a self-contained HTML page laid out for paper.
+var printTemplate = template.Must(template.New("print").Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: Georgia, serif; max-width: 42em; margin: 2em auto; padding: 0 1em; color: #000; line-height: 1.4; }
+h1 { margin-bottom: 0.2em; }
+h2 { font-size: 1.2em; border-bottom: 1px solid #999; padding-bottom: 0.2em; margin-top: 1.5em; }
+.facts { color: #333; }
+.facts span + span::before { content: " · "; }
+ol li { margin-bottom: 0.6em; white-space: pre-line; }
+.notes { white-space: pre-line; }
+@media print { body { margin: 0; max-width: none; } h2 { break-after: avoid; } li { break-inside: avoid; } }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{with .Description}}<p>{{.}}</p>
+{{end}}{{with .Facts}}<p class="facts">{{range .}}<span>{{.}}</span>{{end}}</p>
+{{end}}{{with .Ingredients}}<h2>Ingredients</h2>
+<ul>
+{{range .}}<li>{{.}}</li>
+{{end}}</ul>
+{{end}}{{with .Steps}}<h2>Steps</h2>
+<ol>
+{{range .}}<li>{{.}}</li>
+{{end}}</ol>
+{{end}}{{with .Notes}}<h2>Notes</h2>
+<p class="notes">{{.}}</p>
+{{end}}</body>
+</html>
+`))
+
+// printPage is the data printTemplate renders; empty fields are left out of the page.
+type printPage struct {
+	Lang        string
+	Title       string
+	Description string
+	Facts       []string
+	Ingredients []string
+	Steps       []string
+	Notes       string
+}
+
+// GetRecipePrint handles rendering a recipe as a print-friendly HTML page.
+// @Summary Get a printable recipe
+// @Description Render a recipe as a self-contained HTML page with inline styles, for printing: the title, servings,
+// @Description times, ingredients and numbered steps. Quantities are rounded like in JSON responses.
+// @Tags recipes
+// @Produce html
+// @Param id path string true "Recipe ID (UUID)"
+// @Success 200 {string} string "The recipe as HTML"
+// @Failure 400 {object} APIError "Invalid ID format"
+// @Failure 404 {object} APIError "Recipe not found"
+// @Failure 500 {object} APIError "Server error"
+// @Router /recipes/{id}/print [get]
+func (h *RecipeHandler) GetRecipePrint(c *gin.Context) {
+	recipeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid recipe ID format: "+err.Error())
+		return
+	}
+
+	recipe, err := h.store.GetRecipeByID(c.Request.Context(), recipeID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") { // Basic check
+			RespondWithError(c, http.StatusNotFound, ErrCodeRecipeNotFound, "Recipe not found: "+err.Error())
+		} else {
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to get recipe: "+err.Error())
+		}
+		return
+	}
+	h.roundQuantities(recipe)
+
+	var page bytes.Buffer
+	if err := printTemplate.Execute(&page, newPrintPage(recipe)); err != nil {
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeInternal, "Failed to render recipe: "+err.Error())
+		return
+	}
+	c.Header("Content-Security-Policy", printContentSecurityPolicy)
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page.Bytes())
+}
+
+// newPrintPage collects what the print page shows of a recipe.
+func newPrintPage(recipe *models.Recipe) printPage {
+	page := printPage{Lang: "en", Title: recipe.Title}
+	if recipe.Lang != nil {
+		page.Lang = *recipe.Lang
+	}
+	if recipe.Description != nil {
+		page.Description = strings.TrimSpace(*recipe.Description)
+	}
+	if recipe.Notes != nil {
+		page.Notes = strings.TrimSpace(*recipe.Notes)
+	}
+
+	if recipe.Serves != nil {
+		page.Facts = append(page.Facts, fmt.Sprintf("Serves %d", *recipe.Serves))
+	}
+	if recipe.YieldQuantity != nil {
+		yield := formatMarkdownQuantity(*recipe.YieldQuantity)
+		if recipe.YieldUnit != nil {
+			yield += " " + *recipe.YieldUnit
+		}
+		page.Facts = append(page.Facts, "Makes "+yield)
+	}
+	for _, t := range []struct {
+		label   string
+		minutes *int
+	}{
+		{"Prep", recipe.PrepTimeMinutes},
+		{"Cook", recipe.CookTimeMinutes},
+		{"Total", recipe.TotalTimeMinutes},
+	} {
+		if t.minutes != nil && *t.minutes > 0 {
+			page.Facts = append(page.Facts, fmt.Sprintf("%s %d min", t.label, *t.minutes))
+		}
+	}
+
+	plain := func(s string) string { return s } // The template escapes for HTML
+	for _, ing := range recipe.Ingredients {
+		page.Ingredients = append(page.Ingredients, formatIngredientLine(ing, plain))
+	}
+	for _, step := range recipe.Steps {
+		page.Steps = append(page.Steps, strings.TrimSpace(step.Instruction))
+	}
+	return page
+}
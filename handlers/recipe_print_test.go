@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gaanon/gorecipes_v2/config"
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store/mocks"
+)
+
+func TestNewPrintPage(t *testing.T) {
+	page := newPrintPage(&models.Recipe{
+		Title:            "Pancakes",
+		Serves:           intPtr(4),
+		PrepTimeMinutes:  intPtr(10),
+		CookTimeMinutes:  intPtr(0),
+		TotalTimeMinutes: intPtr(10),
+		Ingredients: []models.RecipeIngredient{
+			{IngredientName: strPtr("flour"), Quantity: float64Ptr(200), Unit: &models.MeasurementUnit{Name: strPtr("gram"), Abbreviation: strPtr("g")}, Preparation: strPtr("sifted")},
+			{IngredientName: strPtr("eggs"), QuantityMin: float64Ptr(2), QuantityMax: float64Ptr(3)},
+		},
+		Steps: []models.RecipeStep{{StepNumber: 1, Instruction: "Mix."}, {StepNumber: 2, Instruction: " Fry. "}},
+	})
+	assert.Equal(t, "en", page.Lang)
+	assert.Equal(t, []string{"Serves 4", "Prep 10 min", "Total 10 min"}, page.Facts)
+	assert.Equal(t, []string{"200 g flour, sifted", "2–3 eggs"}, page.Ingredients)
+	assert.Equal(t, []string{"Mix.", "Fry."}, page.Steps)
+}
+
+func TestRecipeHandler_GetRecipePrint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	recipeHandler := NewRecipeHandler(mockStore, config.APIConfig{})
+	router := setupTestRouter(recipeHandler)
+	router.GET("/api/v1/recipes/:id/print", recipeHandler.GetRecipePrint)
+
+	get := func(id string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes/"+id+"/print", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	recipeID := uuid.New()
+	mockStore.EXPECT().GetRecipeByID(gomock.Any(), recipeID).Return(&models.Recipe{
+		ID:          recipeID,
+		Title:       "Fish & <Chips>",
+		Ingredients: []models.RecipeIngredient{{IngredientName: strPtr("potatoes"), Quantity: float64Ptr(1.0 / 3)}},
+		Steps:       []models.RecipeStep{{StepNumber: 1, Instruction: "Fry <script>alert(1)</script>"}},
+	}, nil)
+	w := get(recipeID.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, printContentSecurityPolicy, w.Header().Get("Content-Security-Policy"))
+	body := w.Body.String()
+	assert.Contains(t, body, "<h1>Fish &amp; &lt;Chips&gt;</h1>")
+	assert.Contains(t, body, "<li>0.333 potatoes</li>")
+	assert.Contains(t, body, "<ol>\n<li>Fry &lt;script&gt;")
+	assert.NotContains(t, body, "<script>")
+	assert.NotContains(t, body, "Notes")
+
+	missingID := uuid.New()
+	mockStore.EXPECT().GetRecipeByID(gomock.Any(), missingID).Return(nil, errors.New("recipe not found"))
+	assert.Equal(t, http.StatusNotFound, get(missingID.String()).Code)
+	assert.Equal(t, http.StatusBadRequest, get("not-a-uuid").Code)
+}
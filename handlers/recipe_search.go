@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gin-gonic/gin"
+)
+
+// SearchRecipes handles full-text recipe search.
+// @Summary Search recipes
+// @Description Full-text search over recipe titles, descriptions and ingredient names, most relevant first.
+// @Description Title matches rank above description matches, which rank above ingredient matches; each result's rank can be used as a threshold.
+// @Description With prefix=true each word also matches longer words, e.g. "choco" matches "chocolate" (useful for search-as-you-type).
+// @Description When nothing matches, recipes with similar titles are returned instead, flagged fuzzy and ranked by similarity,
+// @Description so misspellings such as "chocolat cak" still find "Chocolate Cake" (see SEARCH_FUZZY_THRESHOLD).
+// @Tags recipes
+// @Produce json
+// @Param q query string true "Search text"
+// @Param prefix query bool false "Match words by prefix (default false)"
+// @Param limit query int false "Maximum number of results (default 20; larger values are clamped to MAX_PAGE_SIZE, default 100)"
+// @Success 200 {array} models.RecipeSearchResult
+// @Failure 400 {object} APIError "Invalid query parameters"
+// @Failure 500 {object} APIError "Server error"
+// @Router /recipes/search [get]
+func (h *RecipeHandler) SearchRecipes(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: q is required")
+		return
+	}
+
+	params := models.RecipeSearchParams{Query: query, FuzzyThreshold: h.cfg.SearchFuzzyThreshold}
+	if prefixStr := c.Query("prefix"); prefixStr != "" {
+		prefix, err := strconv.ParseBool(prefixStr)
+		if err != nil {
+			RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: prefix must be true or false")
+			return
+		}
+		params.Prefix = prefix
+	}
+
+	limit, err := h.parsePageSizeQuery(c, "limit")
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: "+err.Error())
+		return
+	}
+	params.Limit = limit
+
+	results, err := h.store.SearchRecipes(c.Request.Context(), params)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to search recipes: "+err.Error())
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, results)
+}
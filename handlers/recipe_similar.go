@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultSimilarLimit = 10
+	maxSimilarLimit     = 50
+)
+
+// GetSimilarRecipes handles fetching recipes similar to a given one.
+// @Summary List similar recipes
+// @Description List recipes sharing the most tags and ingredients with the given recipe, highest overlap first.
+// @Tags recipes
+// @Produce json
+// @Param id path string true "Recipe ID (UUID)"
+// @Param limit query int false "Maximum number of recipes (default 10, max 50)"
+// @Success 200 {array} models.SimilarRecipe
+// @Failure 400 {object} APIError "Invalid ID or query parameters"
+// @Failure 404 {object} APIError "Recipe not found"
+// @Failure 500 {object} APIError "Server error"
+// @Router /recipes/{id}/similar [get]
+func (h *RecipeHandler) GetSimilarRecipes(c *gin.Context) {
+	idStr := c.Param("id")
+	recipeID, err := uuid.Parse(idStr)
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid recipe ID format: "+err.Error())
+		return
+	}
+
+	limit, err := parsePositiveIntQuery(c, "limit", defaultSimilarLimit)
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: "+err.Error())
+		return
+	}
+	if limit > maxSimilarLimit {
+		limit = maxSimilarLimit
+	}
+
+	similar, err := h.store.SimilarRecipes(c.Request.Context(), recipeID, limit)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") { // Basic check
+			RespondWithError(c, http.StatusNotFound, ErrCodeRecipeNotFound, "Recipe not found: "+err.Error())
+		} else {
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to find similar recipes: "+err.Error())
+		}
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, similar)
+}
@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetRecipeStats handles fetching catalogue-wide recipe statistics.
+// @Summary Get recipe statistics
+// @Description Get totals across all recipes for an overview dashboard: recipes, distinct ingredients in use and tags,
+// @Description average prep and cook times (over recipes that set them), and the unarchived tag on the most recipes.
+// @Tags recipes
+// @Produce json
+// @Success 200 {object} models.RecipeStats
+// @Failure 500 {object} APIError "Server error"
+// @Router /stats [get]
+func (h *RecipeHandler) GetRecipeStats(c *gin.Context) {
+	stats, err := h.store.RecipeStats(c.Request.Context())
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to get recipe stats: "+err.Error())
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, stats)
+}
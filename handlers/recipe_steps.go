@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetRecipeStep handles fetching a single step of a recipe.
+// @Summary Get a recipe step
+// @Description Get a single step of a recipe by its step number, e.g. to resume cook mode at that step.
+// @Tags recipes
+// @Produce json
+// @Param id path string true "Recipe ID (UUID)"
+// @Param number path int true "Step number (1-based)"
+// @Success 200 {object} models.RecipeStep
+// @Failure 400 {object} APIError "Invalid ID or step number"
+// @Failure 404 {object} APIError "Step not found"
+// @Failure 500 {object} APIError "Server error"
+// @Router /recipes/{id}/steps/{number} [get]
+func (h *RecipeHandler) GetRecipeStep(c *gin.Context) {
+	idStr := c.Param("id")
+	recipeID, err := uuid.Parse(idStr)
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid recipe ID format: "+err.Error())
+		return
+	}
+
+	stepNumber, err := strconv.Atoi(c.Param("number"))
+	if err != nil || stepNumber < 1 {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid step number: must be a positive integer")
+		return
+	}
+
+	step, err := h.store.GetStep(c.Request.Context(), recipeID, stepNumber)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") { // Basic check
+			RespondWithError(c, http.StatusNotFound, ErrCodeStepNotFound, "Step not found: "+err.Error())
+		} else {
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to get step: "+err.Error())
+		}
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, step)
+}
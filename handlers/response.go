@@ -1,18 +1,94 @@
 package handlers
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store"
+)
+
+// Machine-readable error codes returned in APIError.Code.
+// Clients should branch on these rather than parsing the human-readable message.
+const (
+	ErrCodeInvalidPayload     = "invalid_payload"
+	ErrCodeInvalidID          = "invalid_id"
+	ErrCodeInvalidQuery       = "invalid_query"
+	ErrCodeInvalidLanguage    = "invalid_language"
+	ErrCodeInvalidPhoto       = "invalid_photo"
+	ErrCodePhotoTooLarge      = "photo_too_large"
+	ErrCodeUnsupportedPhoto   = "unsupported_photo_type"
+	ErrCodeValidationFailed   = "validation_failed"
+	ErrCodeRecipeNotFound     = "recipe_not_found"
+	ErrCodeStepNotFound       = "step_not_found"
+	ErrCodePhotoNotFound      = "photo_not_found"
+	ErrCodeIngredientNotFound = "ingredient_not_found"
+	ErrCodeTagNotFound        = "tag_not_found"
+	ErrCodeCollectionNotFound = "collection_not_found"
+	ErrCodeUnknownIngredient  = "unknown_ingredient"
+	ErrCodeUnknownRecipe      = "unknown_recipe"
+	ErrCodeUnknownUnit        = "unknown_unit"
+	ErrCodeConflict           = "conflict"
+	ErrCodeDBError            = "db_error"
+	ErrCodeStorageError       = "storage_error"
+	ErrCodeUnauthorized       = "unauthorized"
+	ErrCodeInternalPanic      = "internal_panic"
+	ErrCodeRequestTimeout     = "request_timeout"
+	ErrCodeServiceOverloaded  = "service_overloaded"
+	ErrCodeInternal           = "internal_error"
 )
 
 // APIError represents a standard error response format.
 type APIError struct {
-	Error  string `json:"error"`
-	Status int    `json:"status,omitempty"` // Optional: include HTTP status in body
+	Error     string      `json:"error"`
+	Status    int         `json:"status,omitempty"`     // Optional: include HTTP status in body
+	Code      string      `json:"code,omitempty"`       // Machine-readable error code, e.g. "recipe_not_found"
+	Details   interface{} `json:"details,omitempty"`    // Optional: structured details, e.g. per-field validation errors
+	RequestID string      `json:"request_id,omitempty"` // Correlation ID of the request, as in the X-Request-ID response header
+
+	// Warnings flags suspicious but valid input next to blocking validation errors; see collectWarnings.
+	Warnings []models.ValidationWarning `json:"warnings,omitempty"`
 }
 
 // RespondWithError sends a JSON error response.
-func RespondWithError(c *gin.Context, code int, message string) {
-	c.JSON(code, APIError{Error: message, Status: code})
+func RespondWithError(c *gin.Context, code int, errCode string, message string) {
+	if respondIfOverloaded(c, code) {
+		return
+	}
+	c.JSON(code, APIError{Error: message, Status: code, Code: errCode, RequestID: requestID(c)})
+}
+
+// RespondWithDetailedError sends a JSON error response with additional details.
+// Map details are marshaled with sorted keys, so the body is byte-for-byte stable for the same input.
+func RespondWithDetailedError(c *gin.Context, code int, errCode string, message string, details interface{}) {
+	if respondIfOverloaded(c, code) {
+		return
+	}
+	c.JSON(code, APIError{Error: message, Status: code, Code: errCode, Details: details, RequestID: requestID(c)})
+}
+
+// respondIfOverloaded replaces a 5xx with 503 service_overloaded, and returns true, when a store call of
+// the request failed waiting for a database connection (see PoolExhaustionMiddleware). Handlers report
+// such failures like any other database error, so this is the one place they are told apart.
+func respondIfOverloaded(c *gin.Context, code int) bool {
+	if code < http.StatusInternalServerError || !store.PoolExhausted(c.Request.Context()) {
+		return false
+	}
+	c.Header("Retry-After", "1")
+	c.JSON(http.StatusServiceUnavailable, APIError{
+		Error:     "The server is overloaded: no database connection became available in time, please retry",
+		Status:    http.StatusServiceUnavailable,
+		Code:      ErrCodeServiceOverloaded,
+		RequestID: requestID(c),
+	})
+	return true
+}
+
+// RespondWithValidationErrors sends a validation failure with per-field errors in details and
+// any soft warnings, so clients can show both in one pass.
+func RespondWithValidationErrors(c *gin.Context, code int, errors map[string]string, warnings []models.ValidationWarning) {
+	c.JSON(code, APIError{Error: "Validation failed", Status: code, Code: ErrCodeValidationFailed, Details: errors, Warnings: warnings, RequestID: requestID(c)})
 }
 
 // RespondWithJSON sends a JSON success response.
@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// encoding/json (and the JSON backends gin can be built with) sorts map keys, so error details
+// serialize in a stable order. This golden test guards that for client snapshot tests.
+func TestRespondWithDetailedError_StableOutput(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	details := map[string]string{
+		"Title":                         "failed on 'required' validation (value: '')",
+		"Ingredients[0].IngredientName": "failed on 'required_without' validation (value: '')",
+		"Steps":                         "at least one step is required",
+		"Ingredients":                   "at least one ingredient is required",
+	}
+	want := `{"error":"Validation failed","status":400,"code":"validation_failed","details":{` +
+		`"Ingredients":"at least one ingredient is required",` +
+		`"Ingredients[0].IngredientName":"failed on 'required_without' validation (value: '')",` +
+		`"Steps":"at least one step is required",` +
+		`"Title":"failed on 'required' validation (value: '')"}}`
+
+	for i := 0; i < 20; i++ {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		RespondWithDetailedError(c, http.StatusBadRequest, ErrCodeValidationFailed, "Validation failed", details)
+		assert.Equal(t, want, w.Body.String())
+	}
+}
@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// jsonSchemaDialect is the JSON Schema version the generated schemas follow.
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// RecipeSchema handles describing the recipe create/update payload as a JSON Schema.
+// @Summary Get the recipe request JSON Schema
+// @Description Returns a JSON Schema (draft 2020-12) for the body of POST /recipes and PUT /recipes/{id}.
+// @Description It is generated from the same validation rules the API applies, so clients can validate locally.
+// @Description Rules that need the database (e.g. that an ingredient_id exists) are not expressed.
+// @Tags recipes
+// @Produce json
+// @Success 200 {object} map[string]interface{} "JSON Schema"
+// @Failure 500 {object} APIError "Server error"
+// @Router /schema/recipe [get]
+func (h *RecipeHandler) RecipeSchema(c *gin.Context) {
+	schema, err := structSchema(reflect.TypeOf(models.RecipeRequest{}))
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeInternal, "Failed to build schema: "+err.Error())
+		return
+	}
+	schema["$schema"] = jsonSchemaDialect
+	schema["title"] = "RecipeRequest"
+
+	// Mirror the checks validateRecipeRequest adds on top of the struct tags.
+	properties := schema["properties"].(map[string]any)
+	if h.cfg.RequireIngredientsAndSteps {
+		for _, name := range []string{"ingredients", "steps"} {
+			properties[name].(map[string]any)["minItems"] = 1
+			schema["required"] = append(schema["required"].([]string), name)
+		}
+	}
+	if h.cfg.RequireCreatedBy {
+		properties["created_by"].(map[string]any)["description"] = "Required when creating a recipe (not on update)"
+	}
+	ingredient := properties["ingredients"].(map[string]any)["items"].(map[string]any)
+	if limit := h.cfg.MaxNameLength; limit > 0 {
+		tag := properties["tags"].(map[string]any)["items"].(map[string]any)
+		for _, name := range []map[string]any{
+			ingredient["properties"].(map[string]any)["ingredient_name"].(map[string]any),
+			ingredient["properties"].(map[string]any)["unit_name"].(map[string]any),
+			tag["properties"].(map[string]any)["name"].(map[string]any),
+		} {
+			if current, ok := name["maxLength"].(int64); !ok || int64(limit) < current {
+				name["maxLength"] = int64(limit)
+			}
+		}
+	}
+	ingredient["properties"].(map[string]any)["quantity_text"].(map[string]any)["description"] =
+		`A quantity such as "2", "1.5", "1 1/2" or "½"; parsed into quantity`
+	ingredient["properties"].(map[string]any)["quantity_max"].(map[string]any)["description"] = "Must not be less than quantity_min"
+	ingredient["properties"].(map[string]any)["unit_name"].(map[string]any)["description"] =
+		"Requires quantity, quantity_text or quantity_min/quantity_max"
+	ingredient["properties"].(map[string]any)["sort_order"].(map[string]any)["description"] =
+		"Unique within the recipe; lines without one are numbered after the highest given, in the order sent"
+
+	c.JSON(http.StatusOK, schema)
+}
+
+var (
+	uuidType = reflect.TypeOf(uuid.UUID{})
+	timeType = reflect.TypeOf(time.Time{})
+)
+
+// typeSchema returns the JSON Schema for values of type t, without any validation rules.
+func typeSchema(t reflect.Type) (map[string]any, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case t == uuidType:
+		return map[string]any{"type": "string", "format": "uuid"}, nil
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}, nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.Slice:
+		items, err := typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+	case reflect.Struct:
+		return structSchema(t)
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+// structSchema returns the JSON Schema for a struct, translating each field's validate tag into keywords.
+// It fails on validation rules it does not know, so the schema cannot silently drift from the validators.
+func structSchema(t reflect.Type) (map[string]any, error) {
+	jsonNames := make(map[string]string, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if name := jsonFieldName(field); name != "" {
+			jsonNames[field.Name] = name
+		}
+	}
+
+	properties := make(map[string]any)
+	required := []string{}
+	var allOf []any
+	dependentRequired := make(map[string][]string)
+	seenPairs := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := jsonNames[field.Name]
+		if name == "" {
+			continue
+		}
+		schema, err := typeSchema(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+		}
+
+		// Rules after "dive" apply to the elements of a slice rather than the slice itself.
+		target := schema
+		for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+			key, param, _ := strings.Cut(rule, "=")
+			switch key {
+			case "", "omitempty":
+			case "dive":
+				target = schema["items"].(map[string]any)
+			case "required":
+				required = append(required, name)
+				if target["type"] == "string" {
+					target["minLength"] = 1
+				}
+			case "min", "max", "gt", "gte", "lt", "lte":
+				if err := applyBound(target, key, param); err != nil {
+					return nil, fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+				}
+			case "oneof":
+				enum := []any{}
+				for _, value := range strings.Fields(param) {
+					enum = append(enum, value)
+				}
+				target["enum"] = enum
+			case "http_url":
+				target["format"] = "uri"
+				target["pattern"] = "^https?://"
+			case "required_without", "required_with", "excluded_with", "excluded_without":
+				// Only single-field or "any of" semantics are used, so each named field is a separate rule.
+				for _, param := range strings.Fields(param) {
+					other, ok := jsonNames[param]
+					if !ok {
+						return nil, fmt.Errorf("%s.%s: %s refers to unknown field %q", t.Name(), field.Name, key, param)
+					}
+					pair := []string{name, other}
+					sort.Strings(pair)
+					pairKey := key + ":" + strings.Join(pair, ",")
+					if key == "required_with" || key == "excluded_without" {
+						pairKey = key + ":" + name + "," + other // Directional
+					}
+					if seenPairs[pairKey] {
+						continue
+					}
+					seenPairs[pairKey] = true
+					switch key {
+					case "required_without": // At least one of the two
+						allOf = append(allOf, map[string]any{"anyOf": []any{
+							map[string]any{"required": []string{name}},
+							map[string]any{"required": []string{other}},
+						}})
+					case "excluded_with": // Not both
+						allOf = append(allOf, map[string]any{"not": map[string]any{"required": []string{name, other}}})
+					case "required_with": // Required once the other is present
+						dependentRequired[other] = append(dependentRequired[other], name)
+					case "excluded_without": // Only together with the other
+						dependentRequired[name] = append(dependentRequired[name], other)
+					}
+				}
+			default:
+				return nil, fmt.Errorf("%s.%s: unsupported validation rule %q", t.Name(), field.Name, key)
+			}
+		}
+		properties[name] = schema
+	}
+
+	schema := map[string]any{"type": "object", "properties": properties, "required": required}
+	if len(allOf) > 0 {
+		schema["allOf"] = allOf
+	}
+	if len(dependentRequired) > 0 {
+		schema["dependentRequired"] = dependentRequired
+	}
+	return schema, nil
+}
+
+// applyBound adds a min/max/gt/gte/lt/lte rule to schema, using the length, item or value keyword that
+// matches how the validator interprets the rule for the schema's type.
+func applyBound(schema map[string]any, rule, param string) error {
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s parameter %q", rule, param)
+	}
+	var keywords map[string]string
+	switch schema["type"] {
+	case "string":
+		keywords = map[string]string{"min": "minLength", "gte": "minLength", "max": "maxLength", "lte": "maxLength"}
+	case "array":
+		keywords = map[string]string{"min": "minItems", "gte": "minItems", "max": "maxItems", "lte": "maxItems"}
+	case "integer", "number":
+		keywords = map[string]string{"min": "minimum", "gte": "minimum", "max": "maximum", "lte": "maximum",
+			"gt": "exclusiveMinimum", "lt": "exclusiveMaximum"}
+	}
+	keyword, ok := keywords[rule]
+	if !ok {
+		return fmt.Errorf("%s is not supported for type %v", rule, schema["type"])
+	}
+	if value == float64(int64(value)) {
+		schema[keyword] = int64(value)
+	} else {
+		schema[keyword] = value
+	}
+	return nil
+}
+
+// jsonFieldName returns the name a field has in JSON, or "" if it is not serialised.
+func jsonFieldName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	default:
+		return name
+	}
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gaanon/gorecipes_v2/config"
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store/mocks"
+)
+
+func TestRecipeHandler_RecipeSchema(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/api/v1/schema/recipe", NewRecipeHandler(mocks.NewMockRecipeStore(ctrl), config.APIConfig{RequireIngredientsAndSteps: true}).RecipeSchema)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/schema/recipe", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var schema struct {
+		Schema     string   `json:"$schema"`
+		Required   []string `json:"required"`
+		Properties map[string]struct {
+			Type      string   `json:"type"`
+			MinLength *int     `json:"minLength"`
+			MaxLength *int     `json:"maxLength"`
+			MinItems  *int     `json:"minItems"`
+			Minimum   *float64 `json:"minimum"`
+			ExclMin   *float64 `json:"exclusiveMinimum"`
+			Enum      []string `json:"enum"`
+			Format    string   `json:"format"`
+			Items     struct {
+				Required          []string                   `json:"required"`
+				Properties        map[string]json.RawMessage `json:"properties"`
+				AllOf             []json.RawMessage          `json:"allOf"`
+				DependentRequired map[string][]string        `json:"dependentRequired"`
+			} `json:"items"`
+		} `json:"properties"`
+		DependentRequired map[string][]string `json:"dependentRequired"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &schema))
+	assert.Equal(t, jsonSchemaDialect, schema.Schema)
+	assert.ElementsMatch(t, []string{"title", "ingredients", "steps"}, schema.Required)
+
+	title := schema.Properties["title"]
+	assert.Equal(t, "string", title.Type)
+	assert.Equal(t, 3, *title.MinLength)
+	assert.Equal(t, 255, *title.MaxLength)
+	assert.Equal(t, 0.0, *schema.Properties["serves"].ExclMin)
+	assert.Equal(t, 0.0, *schema.Properties["prep_time_minutes"].Minimum)
+	assert.Equal(t, []string{"easy", "medium", "hard"}, schema.Properties["difficulty"].Enum)
+	assert.Equal(t, "uri", schema.Properties["source_url"].Format)
+	assert.Equal(t, "uuid", schema.Properties["created_by"].Format)
+	assert.Equal(t, 1, *schema.Properties["steps"].MinItems)
+	assert.Equal(t, map[string][]string{"yield_unit": {"yield_quantity"}}, schema.DependentRequired)
+
+	steps := schema.Properties["steps"].Items
+	assert.ElementsMatch(t, []string{"step_number", "instruction"}, steps.Required)
+	assert.JSONEq(t, `{"type":"string","enum":["normal","optional","make_ahead"]}`, string(steps.Properties["step_type"]))
+
+	// ingredient_name/ingredient_id: one is required and they are mutually exclusive; quantity/quantity_text exclusive;
+	// quantity_min and quantity_max each exclude quantity and quantity_text.
+	ingredients := schema.Properties["ingredients"].Items
+	assert.Len(t, ingredients.AllOf, 7)
+	assert.Equal(t, map[string][]string{"quantity_max": {"quantity_min"}, "quantity_min": {"quantity_max"}}, ingredients.DependentRequired)
+}
+
+func TestStructSchema_RequestModels(t *testing.T) {
+	// Every request body must be expressible; an unknown validation rule should fail here, not drift silently.
+	for _, v := range []any{models.RecipeRequest{}, models.IngredientRequest{}, models.TagAssignmentRequest{}, models.ShoppingListRequest{}} {
+		_, err := structSchema(reflect.TypeOf(v))
+		assert.NoError(t, err, reflect.TypeOf(v).Name())
+	}
+
+	type unsupported struct {
+		Email string `json:"email" validate:"required,email"`
+	}
+	_, err := structSchema(reflect.TypeOf(unsupported{}))
+	assert.ErrorContains(t, err, `unsupported validation rule "email"`)
+}
@@ -0,0 +1,337 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// BuildShoppingList handles consolidating the ingredients of several recipes into one shopping list.
+// @Summary Build a shopping list
+// @Description Consolidate the ingredients of the given recipes, optionally scaled to a number of servings.
+// @Description Quantities of the same ingredient in convertible units (e.g. g and kg) are summed and shown in the largest
+// @Description unit used that keeps the amount at least 1; amounts in units that cannot be converted stay separate.
+// @Tags shopping
+// @Accept json
+// @Produce json
+// @Param request body models.ShoppingListRequest true "Recipes to shop for"
+// @Success 200 {object} models.ShoppingListResponse
+// @Failure 400 {object} APIError "Invalid input"
+// @Failure 404 {object} APIError "Recipe not found"
+// @Failure 500 {object} APIError "Server error"
+// @Router /shopping-list [post]
+func (h *RecipeHandler) BuildShoppingList(c *gin.Context) {
+	var req models.ShoppingListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPayload, "Invalid request payload: "+err.Error())
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		RespondWithDetailedError(c, http.StatusBadRequest, ErrCodeValidationFailed, "Validation failed", formatValidationErrors(err))
+		return
+	}
+
+	lines, ok := h.shoppingListLines(c, req.Recipes)
+	if !ok {
+		return
+	}
+	h.respondWithShoppingList(c, buildShoppingList(req.Recipes, lines))
+}
+
+// DiffShoppingList handles building a shopping list of only what the pantry does not already cover.
+// @Summary Build a shopping list minus the pantry
+// @Description Consolidate the ingredients of the given recipes like POST /shopping-list, then subtract what is already in the pantry.
+// @Description A pantry amount only covers the ingredient in units convertible to its unit (e.g. kg covers g); an amount
+// @Description without a unit covers unitless quantities such as "2 eggs". A pantry item without a quantity covers the ingredient
+// @Description entirely. Unquantified lines such as "salt to taste" are covered by any pantry item of the ingredient.
+// @Description Ingredients fully covered are left out.
+// @Tags shopping
+// @Accept json
+// @Produce json
+// @Param request body models.ShoppingListDiffRequest true "Recipes to shop for and the pantry"
+// @Success 200 {object} models.ShoppingListResponse
+// @Failure 400 {object} APIError "Invalid input or unknown unit_id"
+// @Failure 404 {object} APIError "Recipe not found"
+// @Failure 500 {object} APIError "Server error"
+// @Router /shopping-list/diff [post]
+func (h *RecipeHandler) DiffShoppingList(c *gin.Context) {
+	var req models.ShoppingListDiffRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPayload, "Invalid request payload: "+err.Error())
+		return
+	}
+	if err := validate.Struct(req); err != nil {
+		RespondWithDetailedError(c, http.StatusBadRequest, ErrCodeValidationFailed, "Validation failed", formatValidationErrors(err))
+		return
+	}
+
+	units, err := h.store.ListConversionUnits(c.Request.Context())
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to get measurement units: "+err.Error())
+		return
+	}
+	pantry, err := newPantryStock(req.Pantry, units)
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeUnknownUnit, err.Error())
+		return
+	}
+
+	lines, ok := h.shoppingListLines(c, req.Recipes)
+	if !ok {
+		return
+	}
+	h.respondWithShoppingList(c, diffShoppingList(req.Recipes, lines, pantry))
+}
+
+// shoppingListLines loads the lines of the requested recipes, each recipe once. On failure it writes
+// the error response and returns false.
+func (h *RecipeHandler) shoppingListLines(c *gin.Context, recipes []models.ShoppingListRecipeRequest) ([]models.ShoppingListLine, bool) {
+	seen := make(map[uuid.UUID]bool, len(recipes))
+	var recipeIDs []uuid.UUID
+	for _, r := range recipes {
+		if !seen[r.RecipeID] {
+			seen[r.RecipeID] = true
+			recipeIDs = append(recipeIDs, r.RecipeID)
+		}
+	}
+
+	lines, err := h.store.ShoppingListLines(c.Request.Context(), recipeIDs)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") { // Basic check
+			RespondWithError(c, http.StatusNotFound, ErrCodeRecipeNotFound, "Recipe not found: "+err.Error())
+		} else {
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to build shopping list: "+err.Error())
+		}
+		return nil, false
+	}
+	return lines, true
+}
+
+// respondWithShoppingList writes items as a shopping list, rounding quantities as configured.
+func (h *RecipeHandler) respondWithShoppingList(c *gin.Context, items []models.ShoppingListItem) {
+	if decimals, ok := h.quantityDecimals(); ok {
+		for i := range items {
+			items[i].Quantity = models.RoundQuantity(items[i].Quantity, decimals)
+		}
+	}
+	RespondWithJSON(c, http.StatusOK, models.ShoppingListResponse{Items: items})
+}
+
+// unitBase returns the unit a line's quantity can be converted into and the factor to get there.
+// Units without a base (or without a conversion factor) are their own base.
+func unitBase(unit *models.MeasurementUnit) (uuid.UUID, float64) {
+	if unit.BaseUnitID != nil && unit.ConversionFactor != nil && *unit.ConversionFactor > 0 {
+		return *unit.BaseUnitID, *unit.ConversionFactor
+	}
+	return *unit.ID, 1
+}
+
+// shoppingGroup accumulates the lines of one ingredient that share a unit family.
+type shoppingGroup struct {
+	item    models.ShoppingListItem
+	total   float64                              // In base units
+	units   map[uuid.UUID]models.MeasurementUnit // Units seen, to pick the display unit from
+	factors map[uuid.UUID]float64
+	recipes map[uuid.UUID]bool
+}
+
+// shoppingGroupKey identifies the lines of one ingredient in one unit family.
+type shoppingGroupKey struct {
+	ingredientID uuid.UUID
+	family       string // Base unit ID, "count" for unitless quantities or "unquantified"
+}
+
+// buildShoppingList consolidates the lines of the requested recipes into shopping list items.
+// Each request entry contributes its recipe's lines, scaled by servings / serves when both are known.
+// Items are ordered by category (uncategorised last), then ingredient name.
+func buildShoppingList(recipes []models.ShoppingListRecipeRequest, lines []models.ShoppingListLine) []models.ShoppingListItem {
+	order, groups := groupShoppingLines(recipes, lines)
+	return shoppingListItems(order, groups)
+}
+
+// diffShoppingList builds the shopping list like buildShoppingList, less what the pantry covers.
+// Groups the pantry covers fully are dropped; the rest keep the remaining amount.
+func diffShoppingList(recipes []models.ShoppingListRecipeRequest, lines []models.ShoppingListLine, pantry pantryStock) []models.ShoppingListItem {
+	order, groups := groupShoppingLines(recipes, lines)
+	remaining := order[:0]
+	for _, key := range order {
+		if pantry.plenty[key.ingredientID] {
+			continue
+		}
+		if key.family == "unquantified" {
+			if pantry.listed[key.ingredientID] {
+				continue
+			}
+		} else if have, ok := pantry.amounts[key]; ok {
+			group := groups[key]
+			group.total -= have
+			if group.total <= 1e-9 {
+				continue
+			}
+		}
+		remaining = append(remaining, key)
+	}
+	return shoppingListItems(remaining, groups)
+}
+
+// groupShoppingLines sums the lines of the requested recipes per ingredient and unit family, in base units.
+// order lists the groups in the order they were first seen.
+func groupShoppingLines(recipes []models.ShoppingListRecipeRequest, lines []models.ShoppingListLine) (order []shoppingGroupKey, groups map[shoppingGroupKey]*shoppingGroup) {
+	linesByRecipe := make(map[uuid.UUID][]models.ShoppingListLine)
+	for _, line := range lines {
+		linesByRecipe[line.RecipeID] = append(linesByRecipe[line.RecipeID], line)
+	}
+
+	groups = make(map[shoppingGroupKey]*shoppingGroup)
+	for _, r := range recipes {
+		for _, line := range linesByRecipe[r.RecipeID] {
+			scale := 1.0
+			if r.Servings != nil && line.RecipeServes != nil && *line.RecipeServes > 0 {
+				scale = float64(*r.Servings) / float64(*line.RecipeServes)
+			}
+
+			key := shoppingGroupKey{ingredientID: line.IngredientID, family: "unquantified"}
+			var amount, factor float64
+			if line.Quantity != nil {
+				amount, factor = *line.Quantity*scale, 1
+				key.family = "count"
+				if line.Unit != nil {
+					var base uuid.UUID
+					base, factor = unitBase(line.Unit)
+					key.family = base.String()
+				}
+			}
+
+			group, ok := groups[key]
+			if !ok {
+				group = &shoppingGroup{
+					item: models.ShoppingListItem{
+						IngredientID:       line.IngredientID,
+						IngredientName:     line.IngredientName,
+						IngredientCategory: line.IngredientCategory,
+					},
+					units:   make(map[uuid.UUID]models.MeasurementUnit),
+					factors: make(map[uuid.UUID]float64),
+					recipes: make(map[uuid.UUID]bool),
+				}
+				groups[key] = group
+				order = append(order, key)
+			}
+			group.total += amount * factor
+			if line.Quantity != nil && line.Unit != nil {
+				group.units[*line.Unit.ID] = *line.Unit
+				group.factors[*line.Unit.ID] = factor
+			}
+			if !group.recipes[r.RecipeID] {
+				group.recipes[r.RecipeID] = true
+				group.item.RecipeIDs = append(group.item.RecipeIDs, r.RecipeID)
+			}
+		}
+	}
+	return order, groups
+}
+
+// shoppingListItems turns the given groups into shopping list items, each shown in its display unit,
+// ordered by category (uncategorised last), then ingredient name.
+func shoppingListItems(order []shoppingGroupKey, groups map[shoppingGroupKey]*shoppingGroup) []models.ShoppingListItem {
+	items := make([]models.ShoppingListItem, 0, len(order))
+	for _, key := range order {
+		group := groups[key]
+		item := group.item
+		if key.family != "unquantified" {
+			total := group.total
+			if unitID, ok := displayUnit(group.total, group.factors); ok {
+				unit := group.units[unitID]
+				unit.BaseUnitID, unit.ConversionFactor = nil, nil // Conversion data is internal
+				item.Unit = &unit
+				total = group.total / group.factors[unitID]
+			}
+			item.Quantity = &total
+		}
+		items = append(items, item)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		ci, cj := items[i].IngredientCategory, items[j].IngredientCategory
+		if (ci == nil) != (cj == nil) {
+			return cj == nil
+		}
+		if ci != nil && *ci != *cj {
+			return *ci < *cj
+		}
+		return items[i].IngredientName < items[j].IngredientName
+	})
+	return items
+}
+
+// pantryStock is the pantry in the terms of shopping groups.
+type pantryStock struct {
+	amounts map[shoppingGroupKey]float64 // In base units
+	plenty  map[uuid.UUID]bool           // Ingredients listed without a quantity
+	listed  map[uuid.UUID]bool           // Every ingredient in the pantry
+}
+
+// newPantryStock sums the pantry items per ingredient and unit family, converting amounts with units
+// to their base unit. It fails if an item's unit_id is not one of units.
+func newPantryStock(items []models.PantryItem, units []*models.MeasurementUnit) (pantryStock, error) {
+	unitByID := make(map[uuid.UUID]*models.MeasurementUnit, len(units))
+	for _, unit := range units {
+		unitByID[*unit.ID] = unit
+	}
+
+	pantry := pantryStock{
+		amounts: make(map[shoppingGroupKey]float64),
+		plenty:  make(map[uuid.UUID]bool),
+		listed:  make(map[uuid.UUID]bool),
+	}
+	for i, item := range items {
+		pantry.listed[item.IngredientID] = true
+		if item.Quantity == nil {
+			pantry.plenty[item.IngredientID] = true
+			continue
+		}
+		key := shoppingGroupKey{ingredientID: item.IngredientID, family: "count"}
+		factor := 1.0
+		if item.UnitID != nil {
+			unit, ok := unitByID[*item.UnitID]
+			if !ok {
+				return pantryStock{}, fmt.Errorf("pantry[%d]: no measurement unit with ID %s", i, *item.UnitID)
+			}
+			var base uuid.UUID
+			base, factor = unitBase(unit)
+			key.family = base.String()
+		}
+		pantry.amounts[key] += *item.Quantity * factor
+	}
+	return pantry, nil
+}
+
+// displayUnit picks the unit to show a total (in base units) in: the largest of the units seen
+// that keeps the amount at least 1, or the smallest one if none does. ok is false when no unit was seen.
+func displayUnit(total float64, factors map[uuid.UUID]float64) (unitID uuid.UUID, ok bool) {
+	ids := make([]uuid.UUID, 0, len(factors))
+	for id := range factors {
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return uuid.Nil, false
+	}
+	// Largest factor first; ties broken by ID so the choice is deterministic.
+	sort.Slice(ids, func(i, j int) bool {
+		if factors[ids[i]] != factors[ids[j]] {
+			return factors[ids[i]] > factors[ids[j]]
+		}
+		return ids[i].String() < ids[j].String()
+	})
+	for _, id := range ids {
+		if total/factors[id] >= 1-1e-9 {
+			return id, true
+		}
+	}
+	return ids[len(ids)-1], true
+}
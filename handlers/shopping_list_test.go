@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gaanon/gorecipes_v2/config"
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store/mocks"
+)
+
+func testUnit(name string, base *uuid.UUID, factor float64) *models.MeasurementUnit {
+	id := uuid.New()
+	unit := &models.MeasurementUnit{ID: &id, Name: strPtr(name)}
+	if base != nil {
+		unit.BaseUnitID, unit.ConversionFactor = base, float64Ptr(factor)
+	}
+	return unit
+}
+
+func TestBuildShoppingList(t *testing.T) {
+	gram := testUnit("gram", nil, 0)
+	kilogram := testUnit("kilogram", gram.ID, 1000)
+	millilitre := testUnit("millilitre", nil, 0)
+	cup := testUnit("cup", millilitre.ID, 240)
+	piece := testUnit("piece", nil, 0)
+
+	flour, milk, eggs, salt := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	pancakes, bread := uuid.New(), uuid.New()
+	lines := []models.ShoppingListLine{
+		{RecipeID: pancakes, RecipeServes: intPtr(2), IngredientID: flour, IngredientName: "flour", IngredientCategory: strPtr("baking"), Quantity: float64Ptr(250), Unit: gram},
+		{RecipeID: pancakes, RecipeServes: intPtr(2), IngredientID: milk, IngredientName: "milk", IngredientCategory: strPtr("dairy"), Quantity: float64Ptr(1), Unit: cup},
+		{RecipeID: pancakes, RecipeServes: intPtr(2), IngredientID: eggs, IngredientName: "eggs", IngredientCategory: strPtr("dairy"), Quantity: float64Ptr(2)},
+		{RecipeID: pancakes, RecipeServes: intPtr(2), IngredientID: salt, IngredientName: "salt"},
+		{RecipeID: bread, IngredientID: flour, IngredientName: "flour", IngredientCategory: strPtr("baking"), Quantity: float64Ptr(1), Unit: kilogram},
+		{RecipeID: bread, IngredientID: milk, IngredientName: "milk", IngredientCategory: strPtr("dairy"), Quantity: float64Ptr(100), Unit: millilitre},
+		{RecipeID: bread, IngredientID: eggs, IngredientName: "eggs", IngredientCategory: strPtr("dairy"), Quantity: float64Ptr(1), Unit: piece},
+	}
+	// Pancakes doubled to 4 servings; bread has no serves, so servings cannot scale it.
+	requests := []models.ShoppingListRecipeRequest{{RecipeID: pancakes, Servings: intPtr(4)}, {RecipeID: bread, Servings: intPtr(8)}}
+
+	items := buildShoppingList(requests, lines)
+	var got []string
+	for _, item := range items {
+		desc := item.IngredientName
+		if item.Quantity != nil {
+			desc += fmt.Sprintf(" %.3f", *item.Quantity)
+		}
+		if item.Unit != nil {
+			desc += " " + *item.Unit.Name
+			assert.Nil(t, item.Unit.ConversionFactor)
+		}
+		got = append(got, desc)
+	}
+	assert.Equal(t, []string{
+		"flour 1.500 kilogram", // 500 g + 1 kg
+		"eggs 4.000",           // Unitless eggs stay apart from eggs in pieces
+		"eggs 1.000 piece",
+		"milk 2.417 cup", // 2 cups (480 ml) + 100 ml
+		"salt",           // Unquantified, uncategorised last
+	}, got)
+	assert.ElementsMatch(t, []uuid.UUID{pancakes, bread}, items[0].RecipeIDs)
+}
+
+func TestRecipeHandler_BuildShoppingList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/shopping-list", NewRecipeHandler(mockStore, config.APIConfig{}).BuildShoppingList)
+
+	recipeID, missingID := uuid.New(), uuid.New()
+	ingredientID := uuid.New()
+	mockStore.EXPECT().ShoppingListLines(gomock.Any(), []uuid.UUID{recipeID}).Return([]models.ShoppingListLine{
+		{RecipeID: recipeID, RecipeServes: intPtr(3), IngredientID: ingredientID, IngredientName: "lemon", Quantity: float64Ptr(1)},
+	}, nil).Times(1)
+	mockStore.EXPECT().ShoppingListLines(gomock.Any(), []uuid.UUID{missingID}).Return(nil, fmt.Errorf("recipe with ID %s not found", missingID)).Times(1)
+
+	// Listed twice, once scaled: 1 + 1 * 4/3 lemons, rounded to 3 decimals.
+	body := fmt.Sprintf(`{"recipes":[{"recipe_id":%q},{"recipe_id":%q,"servings":4}]}`, recipeID, recipeID)
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/shopping-list", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.ShoppingListResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	if assert.Len(t, response.Items, 1) {
+		assert.Equal(t, 2.333, *response.Items[0].Quantity)
+		assert.Equal(t, []uuid.UUID{recipeID}, response.Items[0].RecipeIDs)
+	}
+
+	body = fmt.Sprintf(`{"recipes":[{"recipe_id":%q}]}`, missingID)
+	req, _ = http.NewRequest(http.MethodPost, "/api/v1/shopping-list", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	for _, body := range []string{`{"recipes":[]}`, fmt.Sprintf(`{"recipes":[{"recipe_id":%q,"servings":0}]}`, recipeID)} {
+		req, _ = http.NewRequest(http.MethodPost, "/api/v1/shopping-list", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+}
+
+func TestDiffShoppingList(t *testing.T) {
+	gram := testUnit("gram", nil, 0)
+	kilogram := testUnit("kilogram", gram.ID, 1000)
+	millilitre := testUnit("millilitre", nil, 0)
+	litre := testUnit("litre", millilitre.ID, 1000)
+	piece := testUnit("piece", nil, 0)
+
+	flour, milk, eggs, salt, butter := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	pancakes := uuid.New()
+	lines := []models.ShoppingListLine{
+		{RecipeID: pancakes, IngredientID: flour, IngredientName: "flour", Quantity: float64Ptr(1.5), Unit: kilogram},
+		{RecipeID: pancakes, IngredientID: milk, IngredientName: "milk", Quantity: float64Ptr(500), Unit: millilitre},
+		{RecipeID: pancakes, IngredientID: eggs, IngredientName: "eggs", Quantity: float64Ptr(3)},
+		{RecipeID: pancakes, IngredientID: salt, IngredientName: "salt"},
+		{RecipeID: pancakes, IngredientID: butter, IngredientName: "butter", Quantity: float64Ptr(50), Unit: gram},
+	}
+	pantry, err := newPantryStock([]models.PantryItem{
+		{IngredientID: flour, Quantity: float64Ptr(700), UnitID: gram.ID},
+		{IngredientID: milk, Quantity: float64Ptr(1), UnitID: litre.ID},
+		{IngredientID: eggs, Quantity: float64Ptr(2), UnitID: piece.ID}, // Pieces do not cover unitless eggs
+		{IngredientID: salt, Quantity: float64Ptr(1)},
+		{IngredientID: butter},
+	}, []*models.MeasurementUnit{gram, kilogram, millilitre, litre, piece})
+	assert.NoError(t, err)
+
+	items := diffShoppingList([]models.ShoppingListRecipeRequest{{RecipeID: pancakes}}, lines, pantry)
+	var got []string
+	for _, item := range items {
+		desc := fmt.Sprintf("%s %.3f", item.IngredientName, *item.Quantity)
+		if item.Unit != nil {
+			desc += " " + *item.Unit.Name
+		}
+		got = append(got, desc)
+	}
+	assert.Equal(t, []string{
+		"eggs 3.000",
+		"flour 0.800 kilogram", // 1.5 kg - 700 g
+	}, got)
+
+	_, err = newPantryStock([]models.PantryItem{{IngredientID: flour, Quantity: float64Ptr(1), UnitID: &pancakes}}, []*models.MeasurementUnit{gram})
+	assert.Error(t, err)
+}
+
+func TestRecipeHandler_DiffShoppingList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/api/v1/shopping-list/diff", NewRecipeHandler(mockStore, config.APIConfig{}).DiffShoppingList)
+	post := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, "/api/v1/shopping-list/diff", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	gram := testUnit("gram", nil, 0)
+	recipeID, lemon, sugar := uuid.New(), uuid.New(), uuid.New()
+	mockStore.EXPECT().ListConversionUnits(gomock.Any()).Return([]*models.MeasurementUnit{gram}, nil).Times(2)
+	mockStore.EXPECT().ShoppingListLines(gomock.Any(), []uuid.UUID{recipeID}).Return([]models.ShoppingListLine{
+		{RecipeID: recipeID, IngredientID: lemon, IngredientName: "lemon", Quantity: float64Ptr(2)},
+		{RecipeID: recipeID, IngredientID: sugar, IngredientName: "sugar", Quantity: float64Ptr(100), Unit: gram},
+	}, nil).Times(1)
+
+	w := post(fmt.Sprintf(`{"recipes":[{"recipe_id":%q}],"pantry":[{"ingredient_id":%q,"quantity":150,"unit_id":%q}]}`, recipeID, sugar, *gram.ID))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.ShoppingListResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	if assert.Len(t, response.Items, 1) {
+		assert.Equal(t, lemon, response.Items[0].IngredientID)
+		assert.Equal(t, 2.0, *response.Items[0].Quantity)
+	}
+
+	w = post(fmt.Sprintf(`{"recipes":[{"recipe_id":%q}],"pantry":[{"ingredient_id":%q,"quantity":1,"unit_id":%q}]}`, recipeID, sugar, uuid.New()))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var errorResponse APIError
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+	assert.Equal(t, ErrCodeUnknownUnit, errorResponse.Code)
+
+	// A unit without a quantity is rejected by validation.
+	w = post(fmt.Sprintf(`{"recipes":[{"recipe_id":%q}],"pantry":[{"ingredient_id":%q,"unit_id":%q}]}`, recipeID, sugar, *gram.ID))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
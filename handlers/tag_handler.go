@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// TagHandler handles HTTP requests for tags.
+type TagHandler struct {
+	store store.TagStore
+}
+
+// NewTagHandler creates a new TagHandler.
+func NewTagHandler(store store.TagStore) *TagHandler {
+	return &TagHandler{store: store}
+}
+
+// AssignTagToRecipes handles tagging many recipes at once.
+// @Summary Assign a tag to recipes
+// @Description Link a tag to every listed recipe. Recipes that already have the tag, or do not exist, are skipped, so the call is idempotent.
+// @Tags tags
+// @Accept json
+// @Produce json
+// @Param id path string true "Tag ID (UUID)"
+// @Param assignment body models.TagAssignmentRequest true "Recipes to tag"
+// @Success 200 {object} models.TagAssignmentResponse
+// @Failure 400 {object} APIError "Invalid input or ID format"
+// @Failure 404 {object} APIError "Tag not found"
+// @Failure 500 {object} APIError "Server error"
+// @Router /tags/{id}/recipes [post]
+func (h *TagHandler) AssignTagToRecipes(c *gin.Context) {
+	idStr := c.Param("id")
+	tagID, err := uuid.Parse(idStr)
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid tag ID format: "+err.Error())
+		return
+	}
+
+	var req models.TagAssignmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidPayload, "Invalid request payload: "+err.Error())
+		return
+	}
+
+	if err := validate.Struct(req); err != nil {
+		validationErrors := formatValidationErrors(err)
+		RespondWithDetailedError(c, http.StatusBadRequest, ErrCodeValidationFailed, "Validation failed", validationErrors)
+		return
+	}
+
+	added, err := h.store.AssignTagToRecipes(c.Request.Context(), tagID, req.RecipeIDs)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") { // Basic check
+			RespondWithError(c, http.StatusNotFound, ErrCodeTagNotFound, "Tag not found: "+err.Error())
+		} else {
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to assign tag: "+err.Error())
+		}
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, models.TagAssignmentResponse{Added: added, Skipped: len(req.RecipeIDs) - added})
+}
+
+const (
+	defaultPopularTagsLimit = 10
+	maxPopularTagsLimit     = 50 // Larger limits are clamped
+)
+
+// PopularTags handles listing the most used tags.
+// @Summary List popular tags
+// @Description List the tags carried by the most recipes, with their recipe counts, most used first.
+// @Description Archived tags are left out unless include_archived is set.
+// @Tags tags
+// @Produce json
+// @Param limit query int false "Number of tags to return (default 10, at most 50)"
+// @Param include_archived query bool false "Also list archived tags"
+// @Success 200 {array} models.TagCount
+// @Failure 400 {object} APIError "Invalid limit"
+// @Failure 500 {object} APIError "Server error"
+// @Router /tags/popular [get]
+func (h *TagHandler) PopularTags(c *gin.Context) {
+	limit, err := parsePositiveIntQuery(c, "limit", defaultPopularTagsLimit)
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: "+err.Error())
+		return
+	}
+	if limit > maxPopularTagsLimit {
+		limit = maxPopularTagsLimit
+	}
+	includeArchived, err := strconv.ParseBool(c.DefaultQuery("include_archived", "false"))
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: include_archived must be a boolean")
+		return
+	}
+
+	tags, err := h.store.PopularTags(c.Request.Context(), limit, includeArchived)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to list popular tags: "+err.Error())
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, tags)
+}
+
+// ArchiveTag handles hiding a tag from tag listings.
+// @Summary Archive a tag
+// @Description Hide a tag from tag listings without deleting it. Recipes keep the tag. Archiving an archived tag is a no-op.
+// @Tags tags
+// @Produce json
+// @Param id path string true "Tag ID (UUID)"
+// @Success 200 {object} models.Tag
+// @Failure 400 {object} APIError "Invalid ID format"
+// @Failure 404 {object} APIError "Tag not found"
+// @Failure 500 {object} APIError "Server error"
+// @Router /tags/{id}/archive [post]
+func (h *TagHandler) ArchiveTag(c *gin.Context) {
+	h.setTagArchived(c, true)
+}
+
+// UnarchiveTag handles listing an archived tag again.
+// @Summary Unarchive a tag
+// @Description Show an archived tag in tag listings again. Unarchiving a tag that is not archived is a no-op.
+// @Tags tags
+// @Produce json
+// @Param id path string true "Tag ID (UUID)"
+// @Success 200 {object} models.Tag
+// @Failure 400 {object} APIError "Invalid ID format"
+// @Failure 404 {object} APIError "Tag not found"
+// @Failure 500 {object} APIError "Server error"
+// @Router /tags/{id}/unarchive [post]
+func (h *TagHandler) UnarchiveTag(c *gin.Context) {
+	h.setTagArchived(c, false)
+}
+
+// setTagArchived sets the archived state of the tag in the :id path parameter and responds with the tag.
+func (h *TagHandler) setTagArchived(c *gin.Context, archived bool) {
+	idStr := c.Param("id")
+	tagID, err := uuid.Parse(idStr)
+	if err != nil {
+		RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidID, "Invalid tag ID format: "+err.Error())
+		return
+	}
+
+	tag, err := h.store.SetTagArchived(c.Request.Context(), tagID, archived)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") { // Basic check
+			RespondWithError(c, http.StatusNotFound, ErrCodeTagNotFound, "Tag not found: "+err.Error())
+		} else {
+			RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to update tag: "+err.Error())
+		}
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, tag)
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store/mocks"
+)
+
+// Helper function to create a new Gin engine for tag handler tests
+func setupTagTestRouter(handler *TagHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	api := router.Group("/api/v1")
+	{
+		api.POST("/tags/:id/recipes", handler.AssignTagToRecipes)
+		api.GET("/tags/popular", handler.PopularTags)
+		api.POST("/tags/:id/archive", handler.ArchiveTag)
+		api.POST("/tags/:id/unarchive", handler.UnarchiveTag)
+	}
+	return router
+}
+
+func TestTagHandler_AssignTagToRecipes_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockTagStore(ctrl)
+	router := setupTagTestRouter(NewTagHandler(mockStore))
+
+	tagID := uuid.New()
+	recipeIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	mockStore.EXPECT().AssignTagToRecipes(gomock.Any(), tagID, recipeIDs).Return(2, nil).Times(1)
+
+	jsonBody, _ := json.Marshal(models.TagAssignmentRequest{RecipeIDs: recipeIDs})
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/tags/"+tagID.String()+"/recipes", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp models.TagAssignmentResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, models.TagAssignmentResponse{Added: 2, Skipped: 1}, resp)
+}
+
+func TestTagHandler_AssignTagToRecipes_TagNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockTagStore(ctrl)
+	router := setupTagTestRouter(NewTagHandler(mockStore))
+
+	tagID := uuid.New()
+	recipeIDs := []uuid.UUID{uuid.New()}
+	mockStore.EXPECT().AssignTagToRecipes(gomock.Any(), tagID, recipeIDs).Return(0, fmt.Errorf("tag with ID %s not found", tagID)).Times(1)
+
+	jsonBody, _ := json.Marshal(models.TagAssignmentRequest{RecipeIDs: recipeIDs})
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/tags/"+tagID.String()+"/recipes", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var errorResponse APIError
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &errorResponse))
+	assert.Equal(t, ErrCodeTagNotFound, errorResponse.Code)
+}
+
+func TestTagHandler_AssignTagToRecipes_EmptyList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockTagStore(ctrl)
+	router := setupTagTestRouter(NewTagHandler(mockStore))
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/tags/"+uuid.New().String()+"/recipes", bytes.NewBufferString(`{"recipe_ids":[]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestTagHandler_PopularTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockTagStore(ctrl)
+	router := setupTagTestRouter(NewTagHandler(mockStore))
+
+	tags := []models.TagCount{{ID: uuid.New(), Name: "vegetarian", Count: 12}, {ID: uuid.New(), Name: "quick", Count: 7}}
+	gomock.InOrder(
+		mockStore.EXPECT().PopularTags(gomock.Any(), defaultPopularTagsLimit, false).Return(tags, nil),
+		mockStore.EXPECT().PopularTags(gomock.Any(), 3, false).Return(tags, nil),
+		mockStore.EXPECT().PopularTags(gomock.Any(), maxPopularTagsLimit, true).Return(tags, nil),
+	)
+
+	for _, query := range []string{"", "?limit=3", "?limit=1000&include_archived=true"} {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/tags/popular"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code, query)
+		var response []models.TagCount
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, tags, response)
+	}
+
+	for _, query := range []string{"?limit=abc", "?include_archived=maybe"} {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/tags/popular"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestTagHandler_ArchiveTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockTagStore(ctrl)
+	router := setupTagTestRouter(NewTagHandler(mockStore))
+
+	tagID := uuid.New()
+	gomock.InOrder(
+		mockStore.EXPECT().SetTagArchived(gomock.Any(), tagID, true).Return(&models.Tag{ID: tagID, Name: "old", Archived: true}, nil),
+		mockStore.EXPECT().SetTagArchived(gomock.Any(), tagID, false).Return(&models.Tag{ID: tagID, Name: "old"}, nil),
+		mockStore.EXPECT().SetTagArchived(gomock.Any(), tagID, true).Return(nil, fmt.Errorf("tag with ID %s not found", tagID)),
+	)
+
+	post := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post("/api/v1/tags/" + tagID.String() + "/archive")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var tag models.Tag
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &tag))
+	assert.True(t, tag.Archived)
+
+	w = post("/api/v1/tags/" + tagID.String() + "/unarchive")
+	assert.Equal(t, http.StatusOK, w.Code)
+	tag = models.Tag{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &tag))
+	assert.False(t, tag.Archived)
+
+	assert.Equal(t, http.StatusNotFound, post("/api/v1/tags/"+tagID.String()+"/archive").Code)
+	assert.Equal(t, http.StatusBadRequest, post("/api/v1/tags/not-a-uuid/archive").Code)
+}
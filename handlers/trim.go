@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"reflect"
+	"strings"
+)
+
+// trimStrings trims leading and trailing whitespace from every string reachable from v,
+// which must be a pointer: struct fields, *string, and slice elements, recursively.
+func trimStrings(v interface{}) {
+	trimValue(reflect.ValueOf(v))
+}
+
+func trimValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			trimValue(v.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				trimValue(v.Field(i))
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			trimValue(v.Index(i))
+		}
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(strings.TrimSpace(v.String()))
+		}
+	}
+}
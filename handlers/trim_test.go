@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gaanon/gorecipes_v2/models"
+)
+
+func TestTrimStrings(t *testing.T) {
+	req := &models.RecipeRequest{
+		Title:       "  Soup  ",
+		Description: strPtr("\tHearty \n"),
+		Ingredients: []models.RecipeIngredientRequest{{IngredientName: " leek ", UnitName: strPtr(" g")}},
+		Steps:       []models.RecipeStepRequest{{StepNumber: 1, Instruction: "Chop. "}},
+		Tags:        []models.RecipeTagRequest{{Name: " winter"}},
+	}
+	trimStrings(req)
+
+	assert.Equal(t, "Soup", req.Title)
+	assert.Equal(t, "Hearty", *req.Description)
+	assert.Equal(t, "leek", req.Ingredients[0].IngredientName)
+	assert.Equal(t, "g", *req.Ingredients[0].UnitName)
+	assert.Nil(t, req.Ingredients[0].Notes)
+	assert.Equal(t, "Chop.", req.Steps[0].Instruction)
+	assert.Equal(t, "winter", req.Tags[0].Name)
+}
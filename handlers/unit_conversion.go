@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/gaanon/gorecipes_v2/models"
+)
+
+// convertIngredientUnits expresses the recipe's ingredient quantities in units of the given system, using
+// the same conversion data as the shopping list (see unitBase). Each quantity moves to the largest unit of
+// that system, sharing its base unit, that keeps the amount at least 1. Converted is set on every
+// ingredient: false when it keeps a unit of another system because no convertible unit exists.
+func convertIngredientUnits(recipe *models.Recipe, units []*models.MeasurementUnit, system models.MeasurementSystem) {
+	byID := make(map[uuid.UUID]*models.MeasurementUnit, len(units))
+	for _, unit := range units {
+		byID[*unit.ID] = unit
+	}
+
+	for i := range recipe.Ingredients {
+		ing := &recipe.Ingredients[i]
+		converted := true
+		ing.Converted = &converted
+		if ing.Unit == nil || ing.Unit.ID == nil || (ing.Quantity == nil && ing.QuantityMin == nil) {
+			continue // Nothing to convert, e.g. "2 eggs" or "salt to taste"
+		}
+		unit, ok := byID[*ing.Unit.ID]
+		if !ok || (unit.System != nil && *unit.System == system) {
+			converted = ok
+			continue
+		}
+
+		base, factor := unitBase(unit)
+		candidates := make(map[uuid.UUID]float64)
+		for _, candidate := range units {
+			if candidate.System == nil || *candidate.System != system {
+				continue
+			}
+			if candidateBase, candidateFactor := unitBase(candidate); candidateBase == base {
+				candidates[*candidate.ID] = candidateFactor
+			}
+		}
+		amount := ing.Quantity
+		if amount == nil {
+			amount = ing.QuantityMin // A range is shown in the unit that suits its lower bound
+		}
+		targetID, ok := displayUnit(*amount*factor, candidates)
+		if !ok {
+			converted = false
+			continue
+		}
+
+		scale := factor / candidates[targetID]
+		ing.Quantity = scaleQuantity(ing.Quantity, scale)
+		ing.QuantityMin = scaleQuantity(ing.QuantityMin, scale)
+		ing.QuantityMax = scaleQuantity(ing.QuantityMax, scale)
+		target := *byID[targetID]
+		target.BaseUnitID, target.ConversionFactor = nil, nil // Conversion data is internal
+		ing.Unit = &target
+		ing.UnitID = target.ID
+	}
+}
+
+// scaleQuantity returns quantity multiplied by scale, or nil for a nil quantity.
+func scaleQuantity(quantity *float64, scale float64) *float64 {
+	if quantity == nil {
+		return nil
+	}
+	scaled := *quantity * scale
+	return &scaled
+}
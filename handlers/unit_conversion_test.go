@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gaanon/gorecipes_v2/config"
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store/mocks"
+)
+
+// conversionTestUnits returns gram, millilitre, cup, tablespoon, ounce and pinch, with their systems set.
+func conversionTestUnits() map[string]*models.MeasurementUnit {
+	withSystem := func(unit *models.MeasurementUnit, system models.MeasurementSystem) *models.MeasurementUnit {
+		unit.System = &system
+		return unit
+	}
+	gram := withSystem(testUnit("gram", nil, 0), models.Metric)
+	millilitre := withSystem(testUnit("millilitre", nil, 0), models.Metric)
+	return map[string]*models.MeasurementUnit{
+		"gram":       gram,
+		"millilitre": millilitre,
+		"cup":        withSystem(testUnit("cup", millilitre.ID, 240), models.Imperial),
+		"tablespoon": withSystem(testUnit("tablespoon", millilitre.ID, 15), models.Imperial),
+		"ounce":      withSystem(testUnit("ounce", gram.ID, 28.35), models.Imperial),
+		"pinch":      withSystem(testUnit("pinch", nil, 0), models.Other),
+	}
+}
+
+func TestConvertIngredientUnits(t *testing.T) {
+	units := conversionTestUnits()
+	var all []*models.MeasurementUnit
+	for _, unit := range units {
+		all = append(all, unit)
+	}
+	recipe := &models.Recipe{Ingredients: []models.RecipeIngredient{
+		{IngredientName: strPtr("milk"), Quantity: float64Ptr(480), Unit: units["millilitre"]},
+		{IngredientName: strPtr("oil"), Quantity: float64Ptr(30), Unit: units["millilitre"]},
+		{IngredientName: strPtr("flour"), QuantityMin: float64Ptr(283.5), QuantityMax: float64Ptr(567), Unit: units["gram"]},
+		{IngredientName: strPtr("sugar"), Quantity: float64Ptr(1), Unit: units["cup"]},
+		{IngredientName: strPtr("salt"), Quantity: float64Ptr(1), Unit: units["pinch"]},
+		{IngredientName: strPtr("eggs"), Quantity: float64Ptr(2)},
+	}}
+
+	convertIngredientUnits(recipe, all, models.Imperial)
+
+	ings := recipe.Ingredients
+	assert.Equal(t, "cup", *ings[0].Unit.Name)
+	assert.InDelta(t, 2, *ings[0].Quantity, 1e-9)
+	assert.Nil(t, ings[0].Unit.ConversionFactor)
+	assert.Equal(t, "tablespoon", *ings[1].Unit.Name) // 30 ml is less than a cup
+	assert.InDelta(t, 2, *ings[1].Quantity, 1e-9)
+	assert.Equal(t, "ounce", *ings[2].Unit.Name)
+	assert.InDelta(t, 10, *ings[2].QuantityMin, 1e-9)
+	assert.InDelta(t, 20, *ings[2].QuantityMax, 1e-9)
+	assert.Equal(t, "cup", *ings[3].Unit.Name) // Already imperial
+	assert.Equal(t, 1.0, *ings[3].Quantity)
+	assert.Equal(t, "pinch", *ings[4].Unit.Name) // No imperial equivalent
+	for i, want := range []bool{true, true, true, true, false, true} {
+		assert.Equal(t, want, *ings[i].Converted, *ings[i].IngredientName)
+	}
+}
+
+func TestRecipeHandler_GetRecipe_Units(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockRecipeStore(ctrl)
+	router := setupTestRouter(NewRecipeHandler(mockStore, config.APIConfig{}))
+
+	units := conversionTestUnits()
+	recipeID := uuid.New()
+	mockStore.EXPECT().GetRecipeByID(gomock.Any(), recipeID).Return(&models.Recipe{ID: recipeID, Title: "Pancakes", Ingredients: []models.RecipeIngredient{
+		{IngredientName: strPtr("milk"), Quantity: float64Ptr(240), Unit: units["millilitre"]},
+	}}, nil).Times(1)
+	mockStore.EXPECT().ListConversionUnits(gomock.Any()).Return([]*models.MeasurementUnit{units["millilitre"], units["cup"]}, nil).Times(1)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/recipes/"+recipeID.String()+"?units=imperial", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var recipe models.Recipe
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &recipe))
+	assert.Equal(t, "cup", *recipe.Ingredients[0].Unit.Name)
+	assert.Equal(t, 1.0, *recipe.Ingredients[0].Quantity)
+	assert.True(t, *recipe.Ingredients[0].Converted)
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/recipes/"+recipeID.String()+"?units=other", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store"
+	"github.com/gin-gonic/gin"
+)
+
+// UnitHandler handles HTTP requests for measurement units.
+type UnitHandler struct {
+	store store.UnitStore
+}
+
+// NewUnitHandler creates a new UnitHandler.
+func NewUnitHandler(store store.UnitStore) *UnitHandler {
+	return &UnitHandler{store: store}
+}
+
+// ListUnits handles listing the known measurement units.
+// @Summary List measurement units
+// @Description List all measurement units with their abbreviation and system, e.g. to populate a unit dropdown.
+// @Tags units
+// @Produce json
+// @Param system query string false "Only units of this system" Enums(metric, imperial, other)
+// @Success 200 {array} models.MeasurementUnit
+// @Failure 400 {object} APIError "Invalid query parameters"
+// @Failure 500 {object} APIError "Server error"
+// @Router /units [get]
+func (h *UnitHandler) ListUnits(c *gin.Context) {
+	var system *models.MeasurementSystem
+	if systemStr := c.Query("system"); systemStr != "" {
+		ms := models.MeasurementSystem(systemStr)
+		if !ms.IsValid() {
+			RespondWithError(c, http.StatusBadRequest, ErrCodeInvalidQuery, "Invalid query parameter: system must be one of metric, imperial, other")
+			return
+		}
+		system = &ms
+	}
+
+	units, err := h.store.ListUnits(c.Request.Context(), system)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, ErrCodeDBError, "Failed to list measurement units: "+err.Error())
+		return
+	}
+	RespondWithJSON(c, http.StatusOK, units)
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/gaanon/gorecipes_v2/store/mocks"
+)
+
+// Helper function to create a new Gin engine for unit handler tests
+func setupUnitTestRouter(handler *UnitHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	api := router.Group("/api/v1")
+	{
+		api.GET("/units", handler.ListUnits)
+	}
+	return router
+}
+
+func TestUnitHandler_ListUnits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockUnitStore(ctrl)
+	router := setupUnitTestRouter(NewUnitHandler(mockStore))
+
+	metric := models.Metric
+	gram := &models.MeasurementUnit{Name: strPtr("gram"), Abbreviation: strPtr("g"), System: &metric}
+	mockStore.EXPECT().ListUnits(gomock.Any(), &metric).Return([]*models.MeasurementUnit{gram}, nil).Times(1)
+	mockStore.EXPECT().ListUnits(gomock.Any(), nil).Return([]*models.MeasurementUnit{gram}, nil).Times(1)
+
+	for _, path := range []string{"/api/v1/units?system=metric", "/api/v1/units"} {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code, path)
+		var units []models.MeasurementUnit
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &units))
+		assert.Len(t, units, 1)
+		assert.Equal(t, "g", *units[0].Abbreviation)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/units?system=nautical", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/gaanon/gorecipes_v2/models"
+)
+
+// collectWarnings returns the soft validation warnings for a recipe request: input that is valid but
+// often a mistake. They never block a write; create and update return them alongside the recipe,
+// and alongside the errors when validation fails. It returns nil when there is nothing to flag.
+func collectWarnings(req *models.RecipeRequest) []models.ValidationWarning {
+	var warnings []models.ValidationWarning
+	warn := func(field, message string) {
+		warnings = append(warnings, models.ValidationWarning{Field: field, Message: message})
+	}
+
+	if req.PrepTimeMinutes != nil && *req.PrepTimeMinutes == 0 {
+		warn("PrepTimeMinutes", "prep time is 0; leave it out if it is unknown")
+	}
+	if req.CookTimeMinutes != nil && *req.CookTimeMinutes == 0 {
+		warn("CookTimeMinutes", "cook time is 0; leave it out if it is unknown or the recipe needs no cooking")
+	}
+	if len(req.Ingredients) == 0 {
+		warn("Ingredients", "recipe has no ingredients")
+	}
+	if len(req.Steps) == 0 {
+		warn("Steps", "recipe has no steps")
+	}
+	// A quantity without a unit is right for countable ingredients ("3 eggs") but often a forgotten
+	// unit ("200 flour").
+	for i, ing := range req.Ingredients {
+		if hasQuantity(ing) && (ing.UnitName == nil || *ing.UnitName == "") {
+			warn(fmt.Sprintf("Ingredients[%d].UnitName", i), "quantity has no unit; ignore this for countable ingredients such as eggs")
+		}
+	}
+	return warnings
+}
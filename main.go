@@ -1,14 +1,23 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/gaanon/gorecipes_v2/buildinfo"
 	"github.com/gaanon/gorecipes_v2/config"
-	_ "github.com/gaanon/gorecipes_v2/docs" // docs is generated by Swag CLI
+	"github.com/gaanon/gorecipes_v2/docs" // docs is generated by Swag CLI
 	"github.com/gaanon/gorecipes_v2/handlers"
+	"github.com/gaanon/gorecipes_v2/models"
 	"github.com/gaanon/gorecipes_v2/store"
+	"github.com/gaanon/gorecipes_v2/telemetry"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
@@ -31,9 +40,51 @@ import (
 func main() {
 	// Load configuration
 	dbCfg := config.DefaultDBConfig()
+	serverCfg := config.DefaultServerConfig()
+	apiCfg := config.DefaultAPIConfig()
+	photoCfg := config.DefaultPhotoConfig()
+	telemetryCfg := config.DefaultTelemetryConfig()
+	if _, err := models.ParseRecipeSort(apiCfg.DefaultRecipeSort); err != nil {
+		log.Fatalf("Invalid DEFAULT_RECIPE_SORT: %v", err)
+	}
+	jsonKeyCase, err := handlers.ParseKeyCase(apiCfg.JSONKeyCase)
+	if err != nil {
+		log.Fatalf("Invalid JSON_KEY_CASE: %v", err)
+	}
+	if apiCfg.SearchFuzzyThreshold < 0 || apiCfg.SearchFuzzyThreshold > 1 {
+		log.Fatalf("Invalid SEARCH_FUZZY_THRESHOLD: must be between 0 and 1")
+	}
+	if photoCfg.MaxBytes < 0 {
+		log.Fatalf("Invalid MAX_PHOTO_BYTES: must not be negative")
+	}
+	if photoCfg.MaxPixels < 0 {
+		log.Fatalf("Invalid MAX_PHOTO_PIXELS: must not be negative")
+	}
+	for _, t := range photoCfg.AllowedTypes {
+		if !handlers.IsSupportedPhotoType(t) {
+			log.Fatalf("Invalid ALLOWED_PHOTO_TYPES: unsupported content type %q", t)
+		}
+	}
+	if apiCfg.AnonymousUserID != "" {
+		if id, err := uuid.Parse(apiCfg.AnonymousUserID); err != nil || id == uuid.Nil {
+			log.Fatalf("Invalid ANONYMOUS_USER_ID: must be a non-nil UUID")
+		}
+	}
+	if serverCfg.APIBasePath == "" {
+		// The root would swallow /ping, /version and /swagger and apply the API's CORS and CSP to them.
+		log.Fatalf("Invalid API_BASE_PATH: the API cannot be served at the root")
+	}
+	docs.SwaggerInfo.BasePath = serverCfg.APIBasePath // So "Try it out" calls the configured prefix
 	// Production reminder: Load credentials securely, e.g., from environment variables or a config file.
 	// Ensure config.go has your actual DB credentials if you haven't updated it yet.
 
+	// Initialize tracing; a no-op unless OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracing, err := telemetry.SetupTracing(context.Background(), telemetryCfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	// Initialize database pool
 	dbPool, err := store.NewDBPool(dbCfg)
 	if err != nil {
@@ -42,13 +93,38 @@ func main() {
 	defer dbPool.Close() // Ensure the pool is closed when the application exits
 
 	// Initialize store
-	recipeStore := store.NewRecipeStore(dbPool)
+	recipeStore := store.NewTracedRecipeStore(store.NewRecipeStore(dbPool))
+	ingredientStore := store.NewTracedIngredientStore(store.NewIngredientStore(dbPool))
+	tagStore := store.NewTracedTagStore(store.NewTagStore(dbPool))
+	unitStore := store.NewTracedUnitStore(store.NewUnitStore(dbPool))
+	adminStore := store.NewTracedAdminStore(store.NewAdminStore(dbPool))
+	collectionStore := store.NewTracedCollectionStore(store.NewCollectionStore(dbPool))
 
 	// Initialize handlers
-	recipeHandler := handlers.NewRecipeHandler(recipeStore)
+	recipeHandler := handlers.NewRecipeHandler(recipeStore, apiCfg)
+	ingredientHandler := handlers.NewIngredientHandler(ingredientStore, apiCfg)
+	photoHandler := handlers.NewPhotoHandler(recipeStore, photoCfg)
+	tagHandler := handlers.NewTagHandler(tagStore)
+	unitHandler := handlers.NewUnitHandler(unitStore)
+	adminHandler := handlers.NewAdminHandler(adminStore)
+	collectionHandler := handlers.NewCollectionHandler(collectionStore)
 
 	// Initialize Gin router
-	router := gin.Default()
+	router := gin.New()
+	router.Use(handlers.RequestIDMiddleware(), handlers.AccessLogMiddleware(gin.DefaultWriter), handlers.RecoveryMiddleware(), handlers.TracingMiddleware())
+	// Registered on the router rather than the groups so they also answer preflight requests, which match no route.
+	router.Use(
+		handlers.CORSMiddleware(serverCfg.APIBasePath, serverCfg.APICORS),
+		handlers.ContentSecurityPolicyMiddleware(serverCfg.APIBasePath, handlers.APIContentSecurityPolicy),
+		handlers.CORSMiddleware("/swagger", serverCfg.DocsCORS),
+		handlers.ContentSecurityPolicyMiddleware("/swagger", handlers.SwaggerUIContentSecurityPolicy(serverCfg.DocsFrameAncestors)),
+	)
+	// After CORS and CSP, so that a 408 keeps their headers.
+	router.Use(handlers.RequestTimeoutMiddleware(serverCfg.RequestTimeout), handlers.PoolExhaustionMiddleware())
+	// Only trust X-Forwarded-For/X-Real-IP from configured proxies; nil trusts none.
+	if err := router.SetTrustedProxies(serverCfg.TrustedProxies); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES configuration: %v", err)
+	}
 
 	// Health check endpoint
 	router.GET("/ping", func(c *gin.Context) {
@@ -57,16 +133,83 @@ func main() {
 		})
 	})
 
+	// Build metadata, for checking which build is deployed
+	router.GET("/version", func(c *gin.Context) {
+		c.JSON(http.StatusOK, buildinfo.Get())
+	})
+
 	// Recipe routes
-	apiV1 := router.Group("/api/v1") // Group routes under /api/v1
+	apiV1 := router.Group(serverCfg.APIBasePath) // Group routes under API_BASE_PATH, /api/v1 by default
+	apiV1.Use(handlers.JSONKeyCaseMiddleware(jsonKeyCase))
 	{
 		recipesGroup := apiV1.Group("/recipes")
 		{
 			recipesGroup.POST("", recipeHandler.CreateRecipe)
 			recipesGroup.GET("", recipeHandler.ListRecipes)
+			recipesGroup.DELETE("", recipeHandler.DeleteRecipes)
+			recipesGroup.POST("/exists", recipeHandler.RecipesExist)
+			recipesGroup.GET("/search", recipeHandler.SearchRecipes)
+			recipesGroup.POST("/import/mealie", recipeHandler.ImportMealieRecipes)
 			recipesGroup.GET("/:id", recipeHandler.GetRecipe)
+			recipesGroup.GET("/slug/:slug", recipeHandler.GetRecipeBySlug)
 			recipesGroup.PUT("/:id", recipeHandler.UpdateRecipe)
 			recipesGroup.DELETE("/:id", recipeHandler.DeleteRecipe)
+			recipesGroup.PUT("/:id/translations/:lang", recipeHandler.UpsertRecipeTranslation)
+			recipesGroup.GET("/:id/steps/:number", recipeHandler.GetRecipeStep)
+			recipesGroup.GET("/:id/markdown", recipeHandler.GetRecipeMarkdown)
+			recipesGroup.GET("/:id/print", recipeHandler.GetRecipePrint)
+			recipesGroup.GET("/:id/tags", recipeHandler.GetRecipeTags)
+			recipesGroup.GET("/:id/difficulty-estimate", recipeHandler.GetDifficultyEstimate)
+			recipesGroup.GET("/:id/cost", recipeHandler.GetRecipeCost)
+			recipesGroup.PUT("/:id/ingredients/order", recipeHandler.ReorderRecipeIngredients)
+			recipesGroup.POST("/:id/ingredients", recipeHandler.AddRecipeIngredient)
+			recipesGroup.DELETE("/:id/ingredients/:ingredient_id", recipeHandler.RemoveRecipeIngredient)
+			recipesGroup.GET("/:id/similar", recipeHandler.GetSimilarRecipes)
+			recipesGroup.POST("/:id/photo", photoHandler.UploadRecipePhoto)
+			recipesGroup.GET("/:id/photo", photoHandler.GetRecipePhoto)
+		}
+
+		ingredientsGroup := apiV1.Group("/ingredients")
+		{
+			ingredientsGroup.GET("", ingredientHandler.ListIngredients)
+			ingredientsGroup.GET("/popular", ingredientHandler.PopularIngredients)
+			ingredientsGroup.POST("/parse", ingredientHandler.ParseIngredients)
+			ingredientsGroup.PUT("/:id", ingredientHandler.UpdateIngredient)
+			ingredientsGroup.PATCH("/:id", ingredientHandler.PatchIngredient)
+			ingredientsGroup.GET("/:id/stats", ingredientHandler.GetIngredientStats)
+			ingredientsGroup.PUT("/:id/seasons", ingredientHandler.SetIngredientSeasons)
+			ingredientsGroup.PUT("/:id/price", ingredientHandler.SetIngredientPrice)
+		}
+
+		tagsGroup := apiV1.Group("/tags")
+		{
+			tagsGroup.GET("/popular", tagHandler.PopularTags)
+			tagsGroup.POST("/:id/recipes", tagHandler.AssignTagToRecipes)
+			tagsGroup.POST("/:id/archive", tagHandler.ArchiveTag)
+			tagsGroup.POST("/:id/unarchive", tagHandler.UnarchiveTag)
+		}
+
+		collectionsGroup := apiV1.Group("/collections")
+		{
+			collectionsGroup.POST("", collectionHandler.CreateCollection)
+			collectionsGroup.GET("/:id", collectionHandler.GetCollection)
+			collectionsGroup.GET("/:id/recipes", collectionHandler.ListCollectionRecipes)
+			collectionsGroup.POST("/:id/recipes", collectionHandler.AddRecipeToCollection)
+			collectionsGroup.PUT("/:id/recipes/order", collectionHandler.ReorderCollectionRecipes)
+			collectionsGroup.DELETE("/:id/recipes/:recipe_id", collectionHandler.RemoveRecipeFromCollection)
+		}
+
+		apiV1.GET("/units", unitHandler.ListUnits)
+		apiV1.POST("/shopping-list", recipeHandler.BuildShoppingList)
+		apiV1.POST("/shopping-list/diff", recipeHandler.DiffShoppingList)
+		apiV1.GET("/schema/recipe", recipeHandler.RecipeSchema)
+		apiV1.GET("/stats", recipeHandler.GetRecipeStats)
+
+		adminGroup := apiV1.Group("/admin", handlers.AdminAuthMiddleware(serverCfg.AdminAPIKey))
+		{
+			adminGroup.POST("/recompute-times", adminHandler.RecomputeTotalTimes)
+			adminGroup.POST("/cleanup-tags", adminHandler.CleanupTags)
+			adminGroup.POST("/reindex-search", adminHandler.ReindexSearch)
 		}
 	}
 
@@ -76,8 +219,53 @@ func main() {
 
 	// Start the server
 	serverAddr := ":8080" // Make this configurable later
-	log.Printf("Server starting on %s", serverAddr)
-	if err := router.Run(serverAddr); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	server := &http.Server{
+		Addr:              serverAddr,
+		Handler:           router,
+		ReadHeaderTimeout: serverCfg.ReadHeaderTimeout,
+		ReadTimeout:       serverCfg.ReadTimeout,
+		WriteTimeout:      serverCfg.WriteTimeout,
+		IdleTimeout:       serverCfg.IdleTimeout,
+	}
+	if serverCfg.WriteTimeout > 0 && serverCfg.RequestTimeout > 0 && serverCfg.WriteTimeout <= serverCfg.RequestTimeout {
+		log.Printf("WRITE_TIMEOUT (%s) is not above REQUEST_TIMEOUT (%s); timed-out requests may be cut off without a 408",
+			serverCfg.WriteTimeout, serverCfg.RequestTimeout)
+	}
+	useTLS := serverCfg.TLSCertFile != "" && serverCfg.TLSKeyFile != ""
+	if !useTLS && (serverCfg.TLSCertFile != "" || serverCfg.TLSKeyFile != "") {
+		log.Fatalf("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	go func() {
+		var err error
+		if useTLS {
+			// net/http negotiates HTTP/2 over TLS automatically.
+			log.Printf("Server starting on %s (HTTPS, HTTP/2 enabled)", serverAddr)
+			err = server.ListenAndServeTLS(serverCfg.TLSCertFile, serverCfg.TLSKeyFile)
+		} else {
+			log.Printf("Server starting on %s", serverAddr)
+			err = server.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Failed to start server: %v", err)
+		}
+	}()
+
+	// Wait for SIGINT/SIGTERM, then let in-flight requests finish before the deferred cleanups run.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+	stop()
+
+	log.Printf("Shutting down server (waiting up to %s for in-flight requests)", serverCfg.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), serverCfg.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Printf("Shutdown timeout of %s exceeded with requests still running; forcing connections closed", serverCfg.ShutdownTimeout)
+		} else {
+			log.Printf("Error during server shutdown: %v", err)
+		}
+		server.Close()
 	}
+	log.Println("Server stopped")
 }
@@ -0,0 +1,37 @@
+package models
+
+import "github.com/google/uuid"
+
+// TotalTimeMismatch is a recipe whose stored total_time_minutes differs from prep + cook.
+type TotalTimeMismatch struct {
+	RecipeID         uuid.UUID `json:"recipe_id"`
+	Title            string    `json:"title"`
+	PrepTimeMinutes  *int      `json:"prep_time_minutes"`
+	CookTimeMinutes  *int      `json:"cook_time_minutes"`
+	TotalTimeMinutes *int      `json:"total_time_minutes"` // As stored
+	ExpectedMinutes  *int      `json:"expected_minutes"`   // prep + cook; null when either is null
+}
+
+// TagCleanupResponse reports how many unused tags were deleted.
+type TagCleanupResponse struct {
+	Deleted int `json:"deleted"`
+}
+
+// TotalTimeCheckReport is the result of verifying total_time_minutes across all recipes.
+type TotalTimeCheckReport struct {
+	Checked int `json:"checked"`
+	// Generated reports whether total_time_minutes is a generated column in this database.
+	// When it is not, mismatches are fixed in place and counted in Fixed.
+	Generated  bool                `json:"generated"`
+	Mismatches []TotalTimeMismatch `json:"mismatches"`
+	Fixed      int                 `json:"fixed"`
+}
+
+// SearchReindexReport is the result of recomputing search_vector across all recipes.
+type SearchReindexReport struct {
+	// Generated reports whether search_vector is a generated column in this database. Postgres keeps
+	// a generated column in sync itself (from title and description only), so nothing is rewritten.
+	Generated bool `json:"generated"`
+	Reindexed int  `json:"reindexed"`
+	Batches   int  `json:"batches"`
+}
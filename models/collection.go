@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Collection is a named, ordered group of recipes owned by a user, such as a cookbook.
+// Unlike tags, a collection keeps its recipes in a chosen order.
+type Collection struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	Name        string     `json:"name" db:"name"`
+	Description *string    `json:"description,omitempty" db:"description"`
+	CreatedBy   *uuid.UUID `json:"created_by,omitempty" db:"created_by"`
+	IsPublic    bool       `json:"is_public" db:"is_public"`
+	RecipeCount int        `json:"recipe_count"` // Computed
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// CollectionRequest is used for creating a collection.
+type CollectionRequest struct {
+	Name        string     `json:"name" validate:"required,min=1,max=255"`
+	Description *string    `json:"description" validate:"omitempty,max=10000"`
+	CreatedBy   *uuid.UUID `json:"created_by"` // Optional, depends on auth context
+	IsPublic    bool       `json:"is_public"`
+}
+
+// CollectionRecipe is a recipe summary as listed in a collection.
+type CollectionRecipe struct {
+	Recipe
+	SortOrder int       `json:"sort_order"` // Position in the collection, from 0
+	AddedAt   time.Time `json:"added_at"`
+}
+
+// CollectionRecipeRequest adds a recipe to a collection.
+type CollectionRecipeRequest struct {
+	RecipeID uuid.UUID `json:"recipe_id" validate:"required"`
+	// SortOrder inserts the recipe at this position, moving later recipes down. Omitted or past the end appends it.
+	SortOrder *int `json:"sort_order" validate:"omitempty,gte=0"`
+}
+
+// CollectionOrderRequest lists every recipe of a collection, by recipe ID, in the desired order.
+type CollectionOrderRequest struct {
+	RecipeIDs []uuid.UUID `json:"recipe_ids" validate:"required,min=1,unique"`
+}
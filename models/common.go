@@ -5,13 +5,16 @@ import (
 	"fmt"
 )
 
-// MeasurementSystem is an ENUM type for 'metric' or 'imperial' systems.
+// MeasurementSystem is an ENUM type for 'metric', 'imperial' or 'other' systems.
 // It matches the PostgreSQL ENUM type 'measurement_system'.
 type MeasurementSystem string
 
 const (
 	Metric   MeasurementSystem = "metric"
 	Imperial MeasurementSystem = "imperial"
+	// Other is for units outside both systems, e.g. "pinch" or "clove", and for units created
+	// on the fly from a recipe's unit_name, whose system is unknown.
+	Other MeasurementSystem = "other"
 )
 
 // String returns the string representation of MeasurementSystem.
@@ -19,6 +22,16 @@ func (ms MeasurementSystem) String() string {
 	return string(ms)
 }
 
+// IsValid reports whether ms is one of the known MeasurementSystem values.
+func (ms MeasurementSystem) IsValid() bool {
+	switch ms {
+	case Metric, Imperial, Other:
+		return true
+	default:
+		return false
+	}
+}
+
 // Scan implements the sql.Scanner interface for MeasurementSystem.
 func (ms *MeasurementSystem) Scan(value interface{}) error {
 	s, ok := value.([]byte) // In pgx, ENUMs often come as []byte
@@ -30,15 +43,107 @@ func (ms *MeasurementSystem) Scan(value interface{}) error {
 		s = []byte(strVal)
 	}
 	*ms = MeasurementSystem(s)
-	switch *ms {
-	case Metric, Imperial:
-		return nil
-	default:
+	if !ms.IsValid() {
 		return fmt.Errorf("invalid MeasurementSystem value: %s", s)
 	}
+	return nil
 }
 
 // Value implements the driver.Valuer interface for MeasurementSystem.
 func (ms MeasurementSystem) Value() (driver.Value, error) {
 	return string(ms), nil
 }
+
+// Difficulty is an ENUM type for how hard a recipe is to make: 'easy', 'medium' or 'hard'.
+// It matches the PostgreSQL ENUM type 'recipe_difficulty'.
+type Difficulty string
+
+const (
+	DifficultyEasy   Difficulty = "easy"
+	DifficultyMedium Difficulty = "medium"
+	DifficultyHard   Difficulty = "hard"
+)
+
+// String returns the string representation of Difficulty.
+func (d Difficulty) String() string {
+	return string(d)
+}
+
+// IsValid reports whether d is one of the known Difficulty values.
+func (d Difficulty) IsValid() bool {
+	switch d {
+	case DifficultyEasy, DifficultyMedium, DifficultyHard:
+		return true
+	default:
+		return false
+	}
+}
+
+// Scan implements the sql.Scanner interface for Difficulty.
+func (d *Difficulty) Scan(value interface{}) error {
+	s, ok := value.([]byte) // In pgx, ENUMs often come as []byte
+	if !ok {
+		strVal, okStr := value.(string)
+		if !okStr {
+			return fmt.Errorf("failed to scan Difficulty: expected string or []byte, got %T", value)
+		}
+		s = []byte(strVal)
+	}
+	*d = Difficulty(s)
+	if !d.IsValid() {
+		return fmt.Errorf("invalid Difficulty value: %s", s)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface for Difficulty.
+func (d Difficulty) Value() (driver.Value, error) {
+	return string(d), nil
+}
+
+// StepType is an ENUM type for how a recipe step fits in: 'normal', 'optional' or 'make_ahead'.
+// It matches the PostgreSQL ENUM type 'step_type'.
+type StepType string
+
+const (
+	StepTypeNormal    StepType = "normal"
+	StepTypeOptional  StepType = "optional"
+	StepTypeMakeAhead StepType = "make_ahead"
+)
+
+// String returns the string representation of StepType.
+func (st StepType) String() string {
+	return string(st)
+}
+
+// IsValid reports whether st is one of the known StepType values.
+func (st StepType) IsValid() bool {
+	switch st {
+	case StepTypeNormal, StepTypeOptional, StepTypeMakeAhead:
+		return true
+	default:
+		return false
+	}
+}
+
+// Scan implements the sql.Scanner interface for StepType.
+func (st *StepType) Scan(value interface{}) error {
+	s, ok := value.([]byte) // In pgx, ENUMs often come as []byte
+	if !ok {
+		strVal, okStr := value.(string)
+		if !okStr {
+			return fmt.Errorf("failed to scan StepType: expected string or []byte, got %T", value)
+		}
+		s = []byte(strVal)
+	}
+	*st = StepType(s)
+	if !st.IsValid() {
+		return fmt.Errorf("invalid StepType value: %s", s)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface for StepType.
+func (st StepType) Value() (driver.Value, error) {
+	return string(st), nil
+}
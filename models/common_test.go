@@ -0,0 +1,19 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasurementSystem_Scan(t *testing.T) {
+	for _, value := range []interface{}{"metric", []byte("imperial"), "other"} {
+		var ms MeasurementSystem
+		assert.NoError(t, ms.Scan(value), value)
+		assert.True(t, ms.IsValid())
+	}
+
+	var ms MeasurementSystem
+	assert.Error(t, ms.Scan("nautical"))
+	assert.Error(t, ms.Scan(42))
+}
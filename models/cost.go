@@ -0,0 +1,29 @@
+package models
+
+import "github.com/google/uuid"
+
+// RecipeCost is a recipe's cost estimated from its ingredients' prices.
+type RecipeCost struct {
+	RecipeID       uuid.UUID        `json:"recipe_id"`
+	TotalCost      float64          `json:"total_cost"`                 // Sum of the costs of the ingredients that could be priced
+	Serves         *int             `json:"serves,omitempty"`           // The recipe's servings, if set
+	CostPerServing *float64         `json:"cost_per_serving,omitempty"` // Set when the recipe's servings are
+	Complete       bool             `json:"complete"`                   // Whether every ingredient was priced
+	Ingredients    []IngredientCost `json:"ingredients"`                // In the recipe's order
+}
+
+// Reasons an ingredient is left out of a RecipeCost.
+const (
+	CostExcludedNoPrice      = "no_price"      // The ingredient has no unit price
+	CostExcludedNoQuantity   = "no_quantity"   // The recipe gives no quantity, e.g. "salt to taste"
+	CostExcludedUnitMismatch = "unit_mismatch" // The quantity's unit cannot be converted to the price's
+)
+
+// IngredientCost is one recipe ingredient's contribution to a RecipeCost.
+type IngredientCost struct {
+	IngredientID   uuid.UUID `json:"ingredient_id"`
+	IngredientName *string   `json:"ingredient_name,omitempty"`
+	Cost           *float64  `json:"cost,omitempty"`   // Unset when excluded
+	Excluded       bool      `json:"excluded"`         // Left out of the total
+	Reason         string    `json:"reason,omitempty"` // Why it was excluded: no_price, no_quantity or unit_mismatch
+}
@@ -0,0 +1,79 @@
+package models
+
+import "github.com/google/uuid"
+
+// DifficultyEstimate is a difficulty computed from a recipe's contents, for recipes whose difficulty is not set.
+type DifficultyEstimate struct {
+	RecipeID   uuid.UUID          `json:"recipe_id"`
+	Score      int                `json:"score"`            // 0-100, the sum of the factors' points
+	Difficulty Difficulty         `json:"difficulty"`       // The score's band: easy below 35, hard from 65
+	Manual     *Difficulty        `json:"manual,omitempty"` // The difficulty set on the recipe, if any
+	Factors    []DifficultyFactor `json:"factors"`          // What the score is made of, in a fixed order
+}
+
+// DifficultyFactor is one heuristic's contribution to a DifficultyEstimate.
+type DifficultyFactor struct {
+	Name   string `json:"name"`   // steps, ingredients, total_time or temperature_steps
+	Value  int    `json:"value"`  // The measured amount, e.g. the number of steps or minutes
+	Points int    `json:"points"` // Points added to the score
+}
+
+// Score bands of a DifficultyEstimate.
+const (
+	difficultyMediumFrom = 35
+	difficultyHardFrom   = 65
+)
+
+// EstimateDifficulty scores how hard a recipe is to make from its number of steps (up to 30 points),
+// its number of ingredients (up to 25), its total time (up to 30) and whether any step needs a
+// temperature (15). The recipe's total time should be filled in (see FillTotalTime).
+func EstimateDifficulty(recipe *Recipe) DifficultyEstimate {
+	steps, ingredients := len(recipe.Steps), len(recipe.Ingredients)
+	totalMinutes := 0
+	if recipe.TotalTimeMinutes != nil {
+		totalMinutes = *recipe.TotalTimeMinutes
+	}
+	temperatureSteps := 0
+	for _, step := range recipe.Steps {
+		if step.Temperature != nil {
+			temperatureSteps++
+		}
+	}
+
+	timePoints := 0
+	switch {
+	case totalMinutes > 120:
+		timePoints = 30
+	case totalMinutes > 60:
+		timePoints = 20
+	case totalMinutes > 30:
+		timePoints = 10
+	}
+	temperaturePoints := 0
+	if temperatureSteps > 0 {
+		temperaturePoints = 15
+	}
+
+	estimate := DifficultyEstimate{
+		RecipeID: recipe.ID,
+		Manual:   recipe.Difficulty,
+		Factors: []DifficultyFactor{
+			{Name: "steps", Value: steps, Points: min(steps*3, 30)},
+			{Name: "ingredients", Value: ingredients, Points: min(ingredients*2, 25)},
+			{Name: "total_time", Value: totalMinutes, Points: timePoints},
+			{Name: "temperature_steps", Value: temperatureSteps, Points: temperaturePoints},
+		},
+	}
+	for _, factor := range estimate.Factors {
+		estimate.Score += factor.Points
+	}
+	switch {
+	case estimate.Score >= difficultyHardFrom:
+		estimate.Difficulty = DifficultyHard
+	case estimate.Score >= difficultyMediumFrom:
+		estimate.Difficulty = DifficultyMedium
+	default:
+		estimate.Difficulty = DifficultyEasy
+	}
+	return estimate
+}
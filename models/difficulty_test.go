@@ -0,0 +1,40 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateDifficulty(t *testing.T) {
+	estimate := EstimateDifficulty(&Recipe{})
+	assert.Equal(t, 0, estimate.Score)
+	assert.Equal(t, DifficultyEasy, estimate.Difficulty)
+
+	total, oven := 150, "180°C"
+	manual := DifficultyMedium
+	recipe := &Recipe{TotalTimeMinutes: &total, Difficulty: &manual}
+	for i := 0; i < 12; i++ {
+		recipe.Steps = append(recipe.Steps, RecipeStep{StepNumber: i + 1})
+		recipe.Ingredients = append(recipe.Ingredients, RecipeIngredient{})
+	}
+	recipe.Steps[5].Temperature = &oven
+
+	estimate = EstimateDifficulty(recipe)
+	assert.Equal(t, []DifficultyFactor{
+		{Name: "steps", Value: 12, Points: 30},
+		{Name: "ingredients", Value: 12, Points: 24},
+		{Name: "total_time", Value: 150, Points: 30},
+		{Name: "temperature_steps", Value: 1, Points: 15},
+	}, estimate.Factors)
+	assert.Equal(t, 99, estimate.Score)
+	assert.Equal(t, DifficultyHard, estimate.Difficulty)
+	assert.Equal(t, &manual, estimate.Manual)
+
+	// 5 steps (15) + 6 ingredients (12) + 45 minutes (10) = 37
+	total = 45
+	recipe = &Recipe{TotalTimeMinutes: &total, Steps: make([]RecipeStep, 5), Ingredients: make([]RecipeIngredient, 6)}
+	estimate = EstimateDifficulty(recipe)
+	assert.Equal(t, 37, estimate.Score)
+	assert.Equal(t, DifficultyMedium, estimate.Difficulty)
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Equipment is a tool a recipe needs, e.g. "cast iron skillet".
+type Equipment struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// RecipeEquipmentRequest is used when creating/updating recipe equipment by name.
+type RecipeEquipmentRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=100"`
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -8,10 +9,80 @@ import (
 
 // Ingredient represents an ingredient item.
 type Ingredient struct {
-	ID        uuid.UUID `json:"id" db:"id"`
-	Name      string    `json:"name" db:"name"`
-	Category  *string   `json:"category,omitempty" db:"category"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ID          uuid.UUID  `json:"id" db:"id"`
+	Name        string     `json:"name" db:"name"`
+	Category    *string    `json:"category,omitempty" db:"category"`
+	Seasons     []int      `json:"seasons,omitempty" db:"seasons"`             // Months (1-12) the ingredient is in season; empty means year-round
+	UnitPrice   *float64   `json:"unit_price,omitempty" db:"unit_price"`       // Price of one PriceUnitID, or of one item without a unit
+	PriceUnitID *uuid.UUID `json:"price_unit_id,omitempty" db:"price_unit_id"` // e.g. kilogram for a price per kg
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+}
+
+// IngredientRequest is used for updating (renaming/recategorising) an ingredient.
+type IngredientRequest struct {
+	Name     string  `json:"name" validate:"required,min=1,max=255"`
+	Category *string `json:"category" validate:"omitempty,max=100"`
+}
+
+// IngredientPatchRequest fills in some of an ingredient's details, e.g. the category of one created on the fly
+// for a recipe. Only the fields present in the request change; null clears one.
+type IngredientPatchRequest struct {
+	Category    *string    `json:"category" validate:"omitempty,min=1,max=100"`
+	UnitPrice   *float64   `json:"unit_price" validate:"omitempty,gte=0"`
+	PriceUnitID *uuid.UUID `json:"price_unit_id"`
+	// Fields lists the JSON keys present in the request, in a fixed order; set by the handler.
+	Fields []string `json:"-"`
+}
+
+// IngredientListParams selects a page of ingredients.
+type IngredientListParams struct {
+	Limit         int  // Maximum number of ingredients to return
+	Offset        int  // Number of ingredients to skip
+	Uncategorized bool // Only ingredients without a category
+}
+
+// IngredientCount is an ingredient with the number of recipes that use it.
+type IngredientCount struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Category *string   `json:"category,omitempty"`
+	Count    int       `json:"count"`
+}
+
+// IngredientSeasonsRequest sets the months an ingredient is in season, e.g. [6, 7, 8] for summer in the
+// northern hemisphere. An empty list marks it available year-round.
+type IngredientSeasonsRequest struct {
+	Seasons []int `json:"seasons" validate:"max=12,unique,dive,min=1,max=12"`
+}
+
+// IngredientPriceRequest sets the price used for recipe cost estimates: unit_price per one price_unit_id,
+// e.g. 2.40 per kilogram, or per item when price_unit_id is omitted (e.g. per egg). A null unit_price clears it.
+type IngredientPriceRequest struct {
+	UnitPrice   *float64   `json:"unit_price" validate:"omitempty,gte=0"`
+	PriceUnitID *uuid.UUID `json:"price_unit_id" validate:"excluded_without=UnitPrice"`
+}
+
+// IngredientPrice is the price of one of a recipe's ingredients, as used for its cost.
+type IngredientPrice struct {
+	IngredientID uuid.UUID  `json:"ingredient_id"`
+	UnitPrice    float64    `json:"unit_price"`
+	PriceUnitID  *uuid.UUID `json:"price_unit_id,omitempty"`
+}
+
+// IngredientStats summarises how an ingredient is used across recipes.
+type IngredientStats struct {
+	IngredientID   uuid.UUID             `json:"ingredient_id"`
+	IngredientName string                `json:"ingredient_name"`
+	RecipeCount    int                   `json:"recipe_count"`
+	Units          []IngredientUnitUsage `json:"units"` // Most used first
+}
+
+// IngredientUnitUsage describes the uses of an ingredient with one unit.
+// Quantities are averaged per unit, since amounts in different units cannot be compared directly.
+type IngredientUnitUsage struct {
+	Unit            *MeasurementUnit `json:"unit,omitempty"`             // Nil for uses without a unit, e.g. "2 eggs"
+	RecipeCount     int              `json:"recipe_count"`               // Recipes using the ingredient with this unit
+	AverageQuantity *float64         `json:"average_quantity,omitempty"` // Over the uses that give a quantity
 }
 
 // MeasurementUnit represents a unit of measurement.
@@ -30,22 +101,68 @@ type RecipeIngredient struct {
 	RecipeID     uuid.UUID  `json:"-" db:"recipe_id"` // Often omitted from JSON if part of a Recipe struct
 	IngredientID uuid.UUID  `json:"ingredient_id" db:"ingredient_id"`
 	Quantity     *float64   `json:"quantity,omitempty" db:"quantity"`
+	QuantityMin  *float64   `json:"quantity_min,omitempty" db:"quantity_min"` // Set with QuantityMax for ranges such as "2-3"
+	QuantityMax  *float64   `json:"quantity_max,omitempty" db:"quantity_max"`
 	UnitID       *uuid.UUID `json:"unit_id,omitempty" db:"unit_id"`
 	Notes        *string    `json:"notes,omitempty" db:"notes"`
+	Preparation  *string    `json:"preparation,omitempty" db:"preparation"` // e.g. "finely chopped"
 	SortOrder    int        `json:"sort_order" db:"sort_order"`
 
 	// Fields to populate from related tables for richer API responses
-	IngredientName        *string            `json:"ingredient_name,omitempty"`        // From Ingredient table
-	IngredientDescription *string            `json:"ingredient_description,omitempty"` // From Ingredient table
-	Unit                  *MeasurementUnit   `json:"unit,omitempty"`                   // Populated from MeasurementUnit table
+	IngredientName     *string          `json:"ingredient_name,omitempty"`     // From Ingredient table
+	IngredientCategory *string          `json:"ingredient_category,omitempty"` // From Ingredient table
+	Unit               *MeasurementUnit `json:"unit,omitempty"`                // Populated from MeasurementUnit table
+	Converted          *bool            `json:"converted,omitempty"`           // With ?units=, whether the quantity is in the requested system
 }
 
 // RecipeIngredientRequest is used when creating/updating recipe ingredients.
-// It might reference an existing ingredient by ID or allow creating a new one (more complex, for now by ID).
+// Exactly one of IngredientName or IngredientID must be given: a name is found-or-created,
+// while an ID must reference an existing ingredient (e.g. one picked from autocomplete).
+// The quantity may be given as a number or as text such as "1 1/2", but not both.
+// A range such as "2-3" is given as QuantityMin and QuantityMax instead, both together.
 type RecipeIngredientRequest struct {
-	IngredientName string     `json:"ingredient_name" validate:"required"`
+	IngredientName string     `json:"ingredient_name" validate:"required_without=IngredientID,excluded_with=IngredientID,max=255"` // ingredients.name is VARCHAR(255)
+	IngredientID   *uuid.UUID `json:"ingredient_id" validate:"required_without=IngredientName"`
 	Quantity       *float64   `json:"quantity" validate:"omitempty,gt=0"`
-	UnitName       *string    `json:"unit_name" validate:"omitempty"` // e.g., "grams", "ml", "cup"; backend will find or create
-	Notes          *string    `json:"notes"`
-	SortOrder      int        `json:"sort_order" validate:"gte=0"`
+	QuantityText   *string    `json:"quantity_text" validate:"omitempty,excluded_with=Quantity"` // e.g. "1 1/2" or "½"; parsed into Quantity
+	// required_with comes before omitempty so that it is still checked when this bound is missing.
+	QuantityMin *float64 `json:"quantity_min" validate:"required_with=QuantityMax,omitempty,gt=0,excluded_with=Quantity QuantityText"`
+	QuantityMax *float64 `json:"quantity_max" validate:"required_with=QuantityMin,omitempty,gt=0,excluded_with=Quantity QuantityText"`
+	UnitName    *string  `json:"unit_name" validate:"omitempty,max=50"` // e.g., "grams", "ml", "cup"; backend will find or create; VARCHAR(50)
+	Notes       *string  `json:"notes"`
+	Preparation *string  `json:"preparation" validate:"omitempty,max=255"` // e.g. "finely chopped", "room temperature"
+	SortOrder   *int     `json:"sort_order" validate:"omitempty,gte=0"`    // Unique within a recipe; omitted lines are numbered after the highest given, in the order sent
+}
+
+// ParsedIngredient is how a free-text ingredient line would be read on import, e.g. "2 cups flour, sifted"
+// as quantity 2, unit "cups", name "flour" and notes "sifted". Fields the line does not give are omitted.
+type ParsedIngredient struct {
+	Text     string   `json:"text"` // The line as submitted
+	Quantity *float64 `json:"quantity,omitempty"`
+	Unit     *string  `json:"unit,omitempty"`
+	Name     string   `json:"name"`            // Empty for a blank line
+	Notes    *string  `json:"notes,omitempty"` // The text after the first comma
+}
+
+// IngredientOrderRequest lists every ingredient of a recipe, by ingredient ID, in the desired order.
+type IngredientOrderRequest struct {
+	IngredientIDs []uuid.UUID `json:"ingredient_ids" validate:"required,min=1,unique"`
+}
+
+// RoundQuantities rounds the ingredient's quantity or quantity range to the given number of decimals.
+func (ri *RecipeIngredient) RoundQuantities(decimals int) {
+	ri.Quantity = RoundQuantity(ri.Quantity, decimals)
+	ri.QuantityMin = RoundQuantity(ri.QuantityMin, decimals)
+	ri.QuantityMax = RoundQuantity(ri.QuantityMax, decimals)
+}
+
+// RoundQuantity rounds a quantity to the given number of decimals, e.g. 0.3333333 -> 0.333 for 3.
+// A nil quantity stays nil.
+func RoundQuantity(quantity *float64, decimals int) *float64 {
+	if quantity == nil {
+		return nil
+	}
+	scale := math.Pow10(decimals)
+	rounded := math.Round(*quantity*scale) / scale
+	return &rounded
 }
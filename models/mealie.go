@@ -0,0 +1,68 @@
+package models
+
+import "encoding/json"
+
+// MealieRecipe is the subset of a recipe in Mealie's JSON export that can be mapped onto a RecipeRequest.
+// Fields whose shape changed between Mealie versions are kept raw and decoded during mapping.
+type MealieRecipe struct {
+	Name               string              `json:"name"`
+	Description        string              `json:"description"`
+	RecipeServings     float64             `json:"recipeServings"` // Newer exports; 0 when unset
+	RecipeYield        string              `json:"recipeYield"`    // e.g. "4 servings" or "24 cookies"
+	PrepTime           string              `json:"prepTime"`       // Free text ("1 hour 15 minutes") or ISO 8601 ("PT75M")
+	CookTime           string              `json:"cookTime"`
+	PerformTime        string              `json:"performTime"` // Older exports use this instead of cookTime
+	TotalTime          string              `json:"totalTime"`
+	OrgURL             string              `json:"orgURL"`
+	RecipeIngredient   []json.RawMessage   `json:"recipeIngredient"` // Objects, or plain strings in old exports
+	RecipeInstructions []MealieInstruction `json:"recipeInstructions"`
+	Tags               []MealieNamed       `json:"tags"`
+	RecipeCategory     []MealieNamed       `json:"recipeCategory"`
+	Tools              []MealieNamed       `json:"tools"`
+	Notes              []MealieNote        `json:"notes"`
+}
+
+// MealieIngredient is a structured ingredient line. When DisableAmount is set, only Note is meaningful
+// and holds the whole line, e.g. "1 cup flour".
+type MealieIngredient struct {
+	Title         string       `json:"title"` // Section heading shown above this line
+	Note          string       `json:"note"`
+	Quantity      float64      `json:"quantity"`
+	Unit          *MealieNamed `json:"unit"`
+	Food          *MealieNamed `json:"food"`
+	DisableAmount bool         `json:"disableAmount"`
+	OriginalText  string       `json:"originalText"`
+}
+
+// MealieInstruction is one instruction step.
+type MealieInstruction struct {
+	Title string `json:"title"` // Section heading shown above this step
+	Text  string `json:"text"`
+}
+
+// MealieNamed is any Mealie object referenced by name: tags, categories, tools, units and foods.
+type MealieNamed struct {
+	Name string `json:"name"`
+}
+
+// MealieNote is a titled note attached to a recipe.
+type MealieNote struct {
+	Title string `json:"title"`
+	Text  string `json:"text"`
+}
+
+// RecipeImportResult reports how one recipe of an import was handled. Either Recipe or Errors is set.
+type RecipeImportResult struct {
+	Index    int               `json:"index"` // Position in the uploaded export
+	Title    string            `json:"title"`
+	Recipe   *Recipe           `json:"recipe,omitempty"`
+	Errors   map[string]string `json:"errors,omitempty"`   // Validation or store errors; nothing was created
+	Warnings []string          `json:"warnings,omitempty"` // Data that could not be mapped and was dropped
+}
+
+// RecipeImportResponse reports the outcome of an import.
+type RecipeImportResponse struct {
+	Imported int                  `json:"imported"`
+	Failed   int                  `json:"failed"`
+	Results  []RecipeImportResult `json:"results"`
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// vulgarFractions maps the Unicode fraction characters common in recipes to their values.
+var vulgarFractions = map[rune]float64{
+	'½': 1.0 / 2, '⅓': 1.0 / 3, '⅔': 2.0 / 3, '¼': 1.0 / 4, '¾': 3.0 / 4,
+	'⅕': 1.0 / 5, '⅖': 2.0 / 5, '⅗': 3.0 / 5, '⅘': 4.0 / 5, '⅙': 1.0 / 6,
+	'⅚': 5.0 / 6, '⅛': 1.0 / 8, '⅜': 3.0 / 8, '⅝': 5.0 / 8, '⅞': 7.0 / 8,
+}
+
+// MaxQuantity is the largest quantity the DECIMAL(10,3) quantity columns can store.
+const MaxQuantity = 9999999.999
+
+// ParseQuantity parses a quantity as written in recipes: a number ("2", "1.5"), a fraction ("3/4"),
+// a mixed number ("1 1/2") or one using a Unicode fraction ("½", "1½", "1 ½").
+// Quantities above MaxQuantity are rejected, as are "NaN" and "Inf", which strconv would accept.
+func ParseQuantity(s string) (float64, error) {
+	q, err := parseQuantity(s)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(q) || math.IsInf(q, 0) {
+		return 0, fmt.Errorf("invalid quantity %q: expected a number, fraction or mixed number", s)
+	}
+	if q > MaxQuantity {
+		return 0, fmt.Errorf("invalid quantity %q: must be at most %g", s, MaxQuantity)
+	}
+	return q, nil
+}
+
+// parseQuantity does the parsing for ParseQuantity, without the range check.
+func parseQuantity(s string) (float64, error) {
+	text := strings.TrimSpace(strings.ReplaceAll(s, "⁄", "/")) // U+2044 fraction slash
+	if text == "" {
+		return 0, fmt.Errorf("invalid quantity %q: empty", s)
+	}
+
+	// A trailing Unicode fraction, optionally after a whole number: "½", "1½", "1 ½".
+	runes := []rune(text)
+	if frac, ok := vulgarFractions[runes[len(runes)-1]]; ok {
+		whole := strings.TrimSpace(string(runes[:len(runes)-1]))
+		if whole == "" {
+			return frac, nil
+		}
+		n, err := strconv.ParseUint(whole, 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("invalid quantity %q: expected a whole number before the fraction", s)
+		}
+		return float64(n) + frac, nil
+	}
+
+	fields := strings.Fields(text)
+	switch len(fields) {
+	case 1:
+		if strings.Contains(fields[0], "/") {
+			return parseFraction(s, fields[0])
+		}
+		f, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil || f < 0 {
+			return 0, fmt.Errorf("invalid quantity %q: expected a number, fraction or mixed number", s)
+		}
+		return f, nil
+	case 2:
+		n, err := strconv.ParseUint(fields[0], 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("invalid quantity %q: expected a whole number before the fraction", s)
+		}
+		frac, err := parseFraction(s, fields[1])
+		if err != nil {
+			return 0, err
+		}
+		return float64(n) + frac, nil
+	default:
+		return 0, fmt.Errorf("invalid quantity %q: expected a number, fraction or mixed number", s)
+	}
+}
+
+// parseFraction parses "a/b" with whole a and b; s is the full input, for error messages.
+func parseFraction(s, fraction string) (float64, error) {
+	numStr, denStr, _ := strings.Cut(fraction, "/")
+	num, errNum := strconv.ParseUint(numStr, 10, 32)
+	den, errDen := strconv.ParseUint(denStr, 10, 32)
+	if errNum != nil || errDen != nil {
+		return 0, fmt.Errorf("invalid quantity %q: malformed fraction %q", s, fraction)
+	}
+	if den == 0 {
+		return 0, fmt.Errorf("invalid quantity %q: zero denominator", s)
+	}
+	return float64(num) / float64(den), nil
+}
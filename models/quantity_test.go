@@ -0,0 +1,34 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+	}{
+		{"2", 2},
+		{"1.5", 1.5},
+		{" 3/4 ", 0.75},
+		{"1 1/2", 1.5},
+		{"½", 0.5},
+		{"1½", 1.5},
+		{"2 ¼", 2.25},
+		{"1⁄3", 1.0 / 3},
+	}
+	for _, tt := range tests {
+		got, err := ParseQuantity(tt.in)
+		if assert.NoError(t, err, tt.in) {
+			assert.InDelta(t, tt.want, got, 1e-9, tt.in)
+		}
+	}
+
+	for _, in := range []string{"", "abc", "1/0", "1/2/3", "-1", "1.5 1/2", "a½", "1 2 3", "/2", "NaN", "nan", "Inf", "+Inf", "-Inf", "infinity", "1e300", "10000000", "4000000000 1/2"} {
+		_, err := ParseQuantity(in)
+		assert.Error(t, err, in)
+	}
+}
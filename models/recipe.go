@@ -1,32 +1,93 @@
 package models
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 // Recipe represents a cooking recipe.
-// Note: total_time_minutes and search_vector are generated columns in the DB.
+// Note: total_time_minutes is a generated column in the DB, and search_vector is maintained by the store.
 // total_time_minutes is included here as it's useful data to return.
 // search_vector is typically handled at the DB query level and not directly in the model for CRUD.
 type Recipe struct {
 	ID               uuid.UUID  `json:"id" db:"id"`
 	Title            string     `json:"title" db:"title"`
+	Slug             string     `json:"slug" db:"slug"` // URL-friendly unique identifier derived from the title
 	Description      *string    `json:"description,omitempty" db:"description"`
 	PhotoFilename    *string    `json:"photo_filename,omitempty" db:"photo_filename"`
 	Serves           *int       `json:"serves,omitempty" db:"serves"`
+	YieldQuantity    *float64   `json:"yield_quantity,omitempty" db:"yield_quantity"` // e.g. 24 in "makes 24 cookies"; independent of Serves
+	YieldUnit        *string    `json:"yield_unit,omitempty" db:"yield_unit"`         // e.g. "cookies"
+	SourceName       *string    `json:"source_name,omitempty" db:"source_name"`       // Where the recipe came from, e.g. a cookbook or website
+	SourceURL        *string    `json:"source_url,omitempty" db:"source_url"`
+	Notes            *string    `json:"notes,omitempty" db:"notes"` // General notes or chef's tips; only on the full recipe, not in lists
 	PrepTimeMinutes  *int       `json:"prep_time_minutes,omitempty" db:"prep_time_minutes"`
 	CookTimeMinutes  *int       `json:"cook_time_minutes,omitempty" db:"cook_time_minutes"`
 	TotalTimeMinutes *int       `json:"total_time_minutes,omitempty" db:"total_time_minutes"` // Read-only from DB
+	Difficulty       *Difficulty `json:"difficulty,omitempty" db:"difficulty"`
 	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt        time.Time  `json:"updated_at" db:"updated_at"`
 	CreatedBy        *uuid.UUID `json:"created_by,omitempty" db:"created_by"`
+	IngredientCount  int        `json:"ingredient_count"` // Computed; lets list views show a count without the full ingredient list
+	Lang             *string    `json:"lang,omitempty"`   // Set when Title/Description come from a translation
+	IsOwner          bool       `json:"is_owner"`         // Whether created_by is the authenticated user; always false when anonymous
 
 	// Fields for related data, to be populated when fetching a full recipe
 	Ingredients []RecipeIngredient `json:"ingredients,omitempty"`
 	Steps       []RecipeStep       `json:"steps,omitempty"`
 	Tags        []Tag              `json:"tags,omitempty"`
+	Equipment   []Equipment        `json:"equipment,omitempty"`
+
+	// IngredientGroups replaces Ingredients when the client asks for ingredients grouped by category.
+	IngredientGroups []IngredientGroup `json:"ingredient_groups,omitempty"`
+
+	// Warnings lists likely data-entry mistakes that did not fail validation. Only set on create and update responses.
+	Warnings []ValidationWarning `json:"warnings,omitempty"`
+}
+
+// ValidationWarning flags input that is valid but suspicious, such as a cook time of 0.
+// Field is keyed like validation errors, e.g. "Ingredients[2].UnitName".
+type ValidationWarning struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// FillTotalTime sets TotalTimeMinutes to prep + cook when the database returned NULL for it but at least
+// one of them is set; a missing one counts as 0. Databases that drifted from database_design.sql may have
+// a plain, unmaintained total_time_minutes column, so the API does not rely on it being generated.
+func (r *Recipe) FillTotalTime() {
+	if r.TotalTimeMinutes != nil || (r.PrepTimeMinutes == nil && r.CookTimeMinutes == nil) {
+		return
+	}
+	total := 0
+	if r.PrepTimeMinutes != nil {
+		total += *r.PrepTimeMinutes
+	}
+	if r.CookTimeMinutes != nil {
+		total += *r.CookTimeMinutes
+	}
+	r.TotalTimeMinutes = &total
+}
+
+// RoundQuantities rounds every ingredient quantity, grouped or not, to the given number of decimals.
+func (r *Recipe) RoundQuantities(decimals int) {
+	for i := range r.Ingredients {
+		r.Ingredients[i].RoundQuantities(decimals)
+	}
+	for _, group := range r.IngredientGroups {
+		for i := range group.Ingredients {
+			group.Ingredients[i].RoundQuantities(decimals)
+		}
+	}
+}
+
+// IngredientGroup is a set of recipe ingredients sharing an ingredient category, e.g. "Produce".
+type IngredientGroup struct {
+	Category    *string            `json:"category"` // Null for uncategorised ingredients
+	Ingredients []RecipeIngredient `json:"ingredients"`
 }
 
 // RecipeRequest is used for creating or updating a recipe.
@@ -37,11 +98,167 @@ type RecipeRequest struct {
 	Description     *string            `json:"description"`
 	PhotoFilename   *string            `json:"photo_filename" validate:"omitempty,max=255"`
 	Serves          *int               `json:"serves" validate:"omitempty,gt=0"`
+	YieldQuantity   *float64           `json:"yield_quantity" validate:"omitempty,gt=0"`
+	YieldUnit       *string            `json:"yield_unit" validate:"omitempty,min=1,max=50,excluded_without=YieldQuantity"`
+	SourceName      *string            `json:"source_name" validate:"omitempty,max=255"`
+	SourceURL       *string            `json:"source_url" validate:"omitempty,max=2048,http_url"`
+	Notes           *string            `json:"notes" validate:"omitempty,max=10000"`
 	PrepTimeMinutes *int               `json:"prep_time_minutes" validate:"omitempty,gte=0"`
 	CookTimeMinutes *int               `json:"cook_time_minutes" validate:"omitempty,gte=0"`
+	Difficulty      *Difficulty        `json:"difficulty" validate:"omitempty,oneof=easy medium hard"`
 	CreatedBy       *uuid.UUID         `json:"created_by"` // Optional, depends on auth context
 
 	Ingredients []RecipeIngredientRequest `json:"ingredients" validate:"omitempty,dive"`
 	Steps       []RecipeStepRequest       `json:"steps" validate:"omitempty,dive"`
 	Tags        []RecipeTagRequest        `json:"tags" validate:"omitempty,dive"`      // For creating/associating tags by name
+	Equipment   []RecipeEquipmentRequest  `json:"equipment" validate:"omitempty,dive"` // Found or created by name, like tags
+}
+
+// RecipeCursor identifies a position in the default recipe ordering (updated_at DESC, id DESC)
+// and is used for keyset pagination.
+type RecipeCursor struct {
+	UpdatedAt time.Time
+	ID        uuid.UUID
+}
+
+// RecipeListParams holds the pagination options for listing recipes.
+// When After is set, keyset pagination is used and Offset is ignored.
+// Recipes are listed most recently updated first, except with UpdatedSince, which lists oldest changes first.
+type RecipeListParams struct {
+	Limit  int           // Maximum number of recipes to return; 0 means no limit
+	Offset int           // Number of recipes to skip (offset pagination)
+	After  *RecipeCursor // Return recipes strictly after this position (keyset pagination)
+	Lang   string        // Preferred language for title/description; falls back to the base recipe when untranslated
+
+	// Filters
+	Difficulty         *Difficulty // Only recipes with this difficulty
+	MinServes          *int        // Only recipes serving at least this many
+	MaxServes          *int        // Only recipes serving at most this many
+	Equipment          string      // Only recipes needing equipment with this name (case-insensitive)
+	IngredientContains string      // Only recipes with an ingredient whose name contains this text (case-insensitive)
+	ExcludeTags        []string    // Skip recipes carrying any of these tags (case-insensitive)
+	Untagged           bool        // Only recipes without any tag
+	InSeasonMonth      int         // Only recipes whose ingredients are all in season in this month (1-12); 0 disables
+
+	UpdatedSince *time.Time // Only recipes updated at or after this time, ordered by updated_at ascending (incremental sync)
+
+	Sort RecipeSort // Ignored with UpdatedSince; the zero value is updated_at descending
+}
+
+// RecipeSortFields are the fields recipes can be listed by.
+var RecipeSortFields = []string{"title", "created_at", "updated_at", "prep_time_minutes", "cook_time_minutes", "total_time_minutes"}
+
+// RecipeSort is a list ordering; recipes with equal values are ordered by id in the same direction.
+type RecipeSort struct {
+	Field string // One of RecipeSortFields; empty means updated_at
+	Desc  bool
+}
+
+// DefaultRecipeSort is the ordering used when neither the request nor the deployment chooses one.
+var DefaultRecipeSort = RecipeSort{Field: "updated_at", Desc: true}
+
+// ParseRecipeSort parses a sort such as "title" (ascending) or "-created_at" (descending).
+func ParseRecipeSort(s string) (RecipeSort, error) {
+	sort := RecipeSort{Field: strings.TrimPrefix(s, "-"), Desc: strings.HasPrefix(s, "-")}
+	for _, field := range RecipeSortFields {
+		if sort.Field == field {
+			return sort, nil
+		}
+	}
+	return RecipeSort{}, fmt.Errorf("sort must be one of %s, optionally prefixed with - for descending", strings.Join(RecipeSortFields, ", "))
+}
+
+// String returns the sort in the form ParseRecipeSort accepts.
+func (s RecipeSort) String() string {
+	if s.Desc {
+		return "-" + s.Field
+	}
+	return s.Field
+}
+
+// RecipeListResponse is the paginated envelope returned when listing recipes.
+type RecipeListResponse struct {
+	Recipes    []*Recipe `json:"recipes"`
+	Page       int       `json:"page,omitempty"` // Only set in offset mode
+	PageSize   int       `json:"page_size"`
+	NextCursor *string   `json:"next_cursor,omitempty"` // Pass as ?after= to fetch the next page; absent on the last page
+}
+
+// RecipeTranslation holds a recipe's title and description in another language.
+type RecipeTranslation struct {
+	RecipeID    uuid.UUID `json:"recipe_id" db:"recipe_id"`
+	Lang        string    `json:"lang" db:"lang"`
+	Title       string    `json:"title" db:"title"`
+	Description *string   `json:"description,omitempty" db:"description"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// RecipeTranslationRequest is used for creating or replacing a recipe translation.
+type RecipeTranslationRequest struct {
+	Title       string  `json:"title" validate:"required,min=3,max=255"`
+	Description *string `json:"description"`
+}
+
+// RecipePhotoResponse describes the stored files after a photo upload.
+type RecipePhotoResponse struct {
+	PhotoFilename     string `json:"photo_filename"`
+	ThumbnailFilename string `json:"thumbnail_filename"`
+}
+
+// SimilarRecipe is a recipe ranked by how many tags and ingredients it shares with another recipe.
+type SimilarRecipe struct {
+	Recipe
+	SharedTags        int `json:"shared_tags"`
+	SharedIngredients int `json:"shared_ingredients"`
+	Score             int `json:"score"` // shared_tags + shared_ingredients
+}
+
+// RecipeSearchParams controls a full-text recipe search.
+type RecipeSearchParams struct {
+	Query  string
+	Prefix bool // Match words starting with each term, e.g. "choco" matches "chocolate"
+	Limit  int
+	// FuzzyThreshold, when positive, is the minimum title similarity (0-1) of the fuzzy search run
+	// when the full-text search finds nothing.
+	FuzzyThreshold float64
+}
+
+// RecipeSearchResult is a recipe matched by full-text search, with its relevance.
+type RecipeSearchResult struct {
+	Recipe
+	Rank  float32 `json:"rank"`  // ts_rank of the match, or title similarity when fuzzy; higher is more relevant
+	Fuzzy bool    `json:"fuzzy"` // Found by the fuzzy title search because the full-text search found nothing
+}
+
+// RecipeBulkDeleteRequest lists the recipes to delete in one call.
+type RecipeBulkDeleteRequest struct {
+	RecipeIDs []uuid.UUID `json:"recipe_ids" validate:"required,min=1,max=1000"`
+}
+
+// RecipeBulkDeleteResponse reports the outcome of a bulk delete.
+type RecipeBulkDeleteResponse struct {
+	Deleted  int `json:"deleted"`
+	NotFound int `json:"not_found"`
+}
+
+// RecipeExistsRequest lists recipe IDs to check, e.g. the references held by a meal plan.
+type RecipeExistsRequest struct {
+	RecipeIDs []uuid.UUID `json:"recipe_ids" validate:"required,min=1,max=1000"`
+}
+
+// RecipeExistsResponse splits the requested IDs by whether a recipe has them, each listed once in request order.
+type RecipeExistsResponse struct {
+	Existing []uuid.UUID `json:"existing"`
+	Missing  []uuid.UUID `json:"missing"`
+}
+
+// RecipeStats summarises the whole recipe catalogue, e.g. for a dashboard.
+type RecipeStats struct {
+	TotalRecipes           int       `json:"total_recipes"`
+	TotalIngredients       int       `json:"total_ingredients"`         // Distinct ingredients used by at least one recipe
+	TotalTags              int       `json:"total_tags"`                // Including unused and archived tags
+	AveragePrepTimeMinutes *float64  `json:"average_prep_time_minutes"` // Over recipes that have one; null when none do
+	AverageCookTimeMinutes *float64  `json:"average_cook_time_minutes"`
+	MostCommonTag          *TagCount `json:"most_common_tag"` // Null when no recipe has an unarchived tag
 }
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRecipeSort(t *testing.T) {
+	sort, err := ParseRecipeSort("-created_at")
+	assert.NoError(t, err)
+	assert.Equal(t, RecipeSort{Field: "created_at", Desc: true}, sort)
+	assert.Equal(t, "-created_at", sort.String())
+
+	sort, err = ParseRecipeSort("title")
+	assert.NoError(t, err)
+	assert.Equal(t, RecipeSort{Field: "title"}, sort)
+
+	for _, invalid := range []string{"", "-", "created_at DESC", "rating"} {
+		_, err = ParseRecipeSort(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestRecipe_FillTotalTime(t *testing.T) {
+	ten, five := 10, 5
+
+	recipe := &Recipe{PrepTimeMinutes: &ten, CookTimeMinutes: &five}
+	recipe.FillTotalTime()
+	assert.Equal(t, 15, *recipe.TotalTimeMinutes)
+
+	recipe = &Recipe{CookTimeMinutes: &five}
+	recipe.FillTotalTime()
+	assert.Equal(t, 5, *recipe.TotalTimeMinutes, "a missing prep time counts as 0")
+
+	stored := 12
+	recipe = &Recipe{PrepTimeMinutes: &ten, CookTimeMinutes: &five, TotalTimeMinutes: &stored}
+	recipe.FillTotalTime()
+	assert.Equal(t, 12, *recipe.TotalTimeMinutes, "a stored total is kept")
+
+	recipe = &Recipe{}
+	recipe.FillTotalTime()
+	assert.Nil(t, recipe.TotalTimeMinutes)
+}
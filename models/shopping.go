@@ -0,0 +1,56 @@
+package models
+
+import "github.com/google/uuid"
+
+// ShoppingListRequest lists the recipes to shop for. The same recipe may be listed more than once.
+type ShoppingListRequest struct {
+	Recipes []ShoppingListRecipeRequest `json:"recipes" validate:"required,min=1,max=50,dive"`
+}
+
+// ShoppingListRecipeRequest is one recipe to shop for, optionally scaled to a number of servings.
+type ShoppingListRecipeRequest struct {
+	RecipeID uuid.UUID `json:"recipe_id" validate:"required"`
+	Servings *int      `json:"servings" validate:"omitempty,gt=0"` // Scales quantities by servings / serves; ignored when the recipe has no serves
+}
+
+// ShoppingListDiffRequest lists the recipes to shop for and what is already in the pantry.
+type ShoppingListDiffRequest struct {
+	Recipes []ShoppingListRecipeRequest `json:"recipes" validate:"required,min=1,max=50,dive"`
+	Pantry  []PantryItem                `json:"pantry" validate:"omitempty,max=500,dive"`
+}
+
+// PantryItem is an amount of an ingredient already on hand. A quantity without a unit counts items;
+// no quantity at all means there is plenty, so the ingredient is left off the list entirely.
+type PantryItem struct {
+	IngredientID uuid.UUID  `json:"ingredient_id" validate:"required"`
+	Quantity     *float64   `json:"quantity" validate:"omitempty,gt=0"`
+	UnitID       *uuid.UUID `json:"unit_id" validate:"omitempty,excluded_without=Quantity"`
+}
+
+// ShoppingListLine is one ingredient line of a recipe, as loaded for building a shopping list.
+type ShoppingListLine struct {
+	RecipeID           uuid.UUID
+	RecipeServes       *int
+	IngredientID       uuid.UUID
+	IngredientName     string
+	IngredientCategory *string
+	Quantity           *float64         // For a range such as "2-3", the upper bound, so the list buys enough
+	Unit               *MeasurementUnit // Includes BaseUnitID and ConversionFactor for normalization
+}
+
+// ShoppingListItem is the consolidated amount of one ingredient to buy.
+// An ingredient appears once per unit family that cannot be converted into another,
+// e.g. once in grams (covering g and kg) and once in pieces.
+type ShoppingListItem struct {
+	IngredientID       uuid.UUID        `json:"ingredient_id"`
+	IngredientName     string           `json:"ingredient_name"`
+	IngredientCategory *string          `json:"ingredient_category,omitempty"`
+	Quantity           *float64         `json:"quantity,omitempty"` // Null for unquantified lines such as "salt to taste"
+	Unit               *MeasurementUnit `json:"unit,omitempty"`
+	RecipeIDs          []uuid.UUID      `json:"recipe_ids"` // Recipes that need this item
+}
+
+// ShoppingListResponse is the consolidated shopping list, grouped by ingredient category.
+type ShoppingListResponse struct {
+	Items []ShoppingListItem `json:"items"`
+}
@@ -13,14 +13,39 @@ type RecipeStep struct {
 	StepNumber      int       `json:"step_number" db:"step_number"`
 	Instruction     string    `json:"instruction" db:"instruction"`
 	DurationMinutes *int      `json:"duration_minutes,omitempty" db:"duration_minutes"`
+	DurationSeconds *int      `json:"duration_seconds,omitempty" db:"duration_seconds"`
 	Temperature     *string   `json:"temperature,omitempty" db:"temperature"`
+	StepType        StepType  `json:"step_type" db:"step_type"`
 	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+
+	// TotalDurationSeconds combines duration_minutes and duration_seconds; computed, not stored.
+	TotalDurationSeconds *int `json:"total_duration_seconds,omitempty" db:"-"`
 }
 
 // RecipeStepRequest is used when creating/updating recipe steps.
 type RecipeStepRequest struct {
-	StepNumber      int     `json:"step_number" validate:"required,gte=1"`
-	Instruction     string  `json:"instruction" validate:"required,min=1"`
-	DurationMinutes *int    `json:"duration_minutes" validate:"omitempty,gte=0"`
+	StepNumber      int    `json:"step_number" validate:"required,gte=1"`
+	Instruction     string `json:"instruction" validate:"required,min=1"`
+	DurationMinutes *int   `json:"duration_minutes" validate:"omitempty,gte=0"`
+	// DurationSeconds is 0-59 when DurationMinutes is also given, otherwise the whole duration in seconds.
+	DurationSeconds *int    `json:"duration_seconds" validate:"omitempty,gte=0"`
 	Temperature     *string `json:"temperature" validate:"omitempty,max=50"`
+	// StepType defaults to "normal" when omitted.
+	StepType *StepType `json:"step_type" validate:"omitempty,oneof=normal optional make_ahead"`
+}
+
+// TotalDurationSeconds combines a minutes and seconds duration into seconds.
+// It returns nil when neither part is set.
+func TotalDurationSeconds(minutes, seconds *int) *int {
+	if minutes == nil && seconds == nil {
+		return nil
+	}
+	total := 0
+	if minutes != nil {
+		total += *minutes * 60
+	}
+	if seconds != nil {
+		total += *seconds
+	}
+	return &total
 }
@@ -12,6 +12,7 @@ type Tag struct {
 	Name        string    `json:"name" db:"name"`
 	Description *string   `json:"description,omitempty" db:"description"`
 	Color       *string   `json:"color,omitempty" db:"color"` // Hex color code
+	Archived    bool      `json:"archived" db:"archived"`     // Hidden from tag listings; still applies to recipes
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 }
 
@@ -23,3 +24,23 @@ type Tag struct {
 type RecipeTagRequest struct {
 	Name string `json:"name" validate:"required,min=1,max=100"`
 }
+
+// TagAssignmentRequest lists the recipes to link to a tag in bulk.
+type TagAssignmentRequest struct {
+	RecipeIDs []uuid.UUID `json:"recipe_ids" validate:"required,min=1,max=1000"`
+}
+
+// TagAssignmentResponse reports the outcome of a bulk tag assignment.
+// Skipped counts recipes that already had the tag, duplicate IDs and IDs of recipes that do not exist.
+type TagAssignmentResponse struct {
+	Added   int `json:"added"`
+	Skipped int `json:"skipped"`
+}
+
+// TagCount is a tag with the number of recipes that carry it.
+type TagCount struct {
+	ID       uuid.UUID `json:"id"`
+	Name     string    `json:"name"`
+	Count    int       `json:"count"`
+	Archived bool      `json:"archived"`
+}
@@ -0,0 +1,164 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AdminStore defines the interface for maintenance operations.
+type AdminStore interface {
+	RecomputeTotalTimes(ctx context.Context) (*models.TotalTimeCheckReport, error)
+	DeleteUnusedTags(ctx context.Context) (int, error)
+	ReindexSearch(ctx context.Context, batchSize int) (*models.SearchReindexReport, error)
+}
+
+// DBAdminStore implements the AdminStore interface using a pgxpool.Pool.
+type DBAdminStore struct {
+	db *pgxpool.Pool
+}
+
+// NewAdminStore creates a new DBAdminStore.
+func NewAdminStore(db *pgxpool.Pool) *DBAdminStore {
+	return &DBAdminStore{db: db}
+}
+
+// RecomputeTotalTimes checks that every recipe's total_time_minutes equals prep_time_minutes + cook_time_minutes.
+// database_design.sql declares it a generated column, but databases created from older schemas may
+// have a plain column; in that case the mismatches are also corrected. This runs in a single transaction.
+func (s *DBAdminStore) RecomputeTotalTimes(ctx context.Context) (*models.TotalTimeCheckReport, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	report := &models.TotalTimeCheckReport{Mismatches: []models.TotalTimeMismatch{}}
+	generatedSQL := `
+		SELECT COALESCE(bool_or(is_generated = 'ALWAYS'), false)
+		FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = 'recipes' AND column_name = 'total_time_minutes';`
+	if err := tx.QueryRow(ctx, generatedSQL).Scan(&report.Generated); err != nil {
+		return nil, fmt.Errorf("failed to inspect total_time_minutes column: %w", err)
+	}
+	if err := tx.QueryRow(ctx, "SELECT COUNT(*) FROM recipes").Scan(&report.Checked); err != nil {
+		return nil, fmt.Errorf("failed to count recipes: %w", err)
+	}
+
+	mismatchSQL := `
+		SELECT id, title, prep_time_minutes, cook_time_minutes, total_time_minutes,
+		       prep_time_minutes + cook_time_minutes AS expected
+		FROM recipes
+		WHERE total_time_minutes IS DISTINCT FROM prep_time_minutes + cook_time_minutes
+		ORDER BY id;`
+	rows, err := tx.Query(ctx, mismatchSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check total times: %w", err)
+	}
+	for rows.Next() {
+		var m models.TotalTimeMismatch
+		if err := rows.Scan(&m.RecipeID, &m.Title, &m.PrepTimeMinutes, &m.CookTimeMinutes, &m.TotalTimeMinutes, &m.ExpectedMinutes); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan total time mismatch: %w", err)
+		}
+		report.Mismatches = append(report.Mismatches, m)
+	}
+	rows.Close()
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error iterating total time mismatches: %w", rows.Err())
+	}
+
+	if !report.Generated && len(report.Mismatches) > 0 {
+		fixSQL := `
+			UPDATE recipes
+			SET total_time_minutes = prep_time_minutes + cook_time_minutes
+			WHERE total_time_minutes IS DISTINCT FROM prep_time_minutes + cook_time_minutes;`
+		cmdTag, err := tx.Exec(ctx, fixSQL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fix total times: %w", err)
+		}
+		report.Fixed = int(cmdTag.RowsAffected())
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit total time check: %w", err)
+	}
+	return report, nil
+}
+
+// DeleteUnusedTags deletes tags that are not assigned to any recipe and returns how many were removed.
+func (s *DBAdminStore) DeleteUnusedTags(ctx context.Context) (int, error) {
+	// recipe_tags.tag_id is NOT NULL, so NOT IN is safe here.
+	cmdTag, err := s.db.Exec(ctx, "DELETE FROM tags WHERE id NOT IN (SELECT tag_id FROM recipe_tags)")
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete unused tags: %w", err)
+	}
+	return int(cmdTag.RowsAffected()), nil
+}
+
+// ReindexSearch recomputes every recipe's search_vector from its title, description and ingredient names,
+// weighted as in searchVectorSQL. Recipe writes keep it current, so this is for backfills, e.g. after
+// converting a database whose search_vector is still the older generated column, which cannot be written:
+//
+//	ALTER TABLE recipes ALTER COLUMN search_vector DROP EXPRESSION;
+//
+// While the column is generated nothing is done. Otherwise recipes are updated batchSize at a time in
+// id order, each batch in its own statement, so row locks are only held briefly.
+func (s *DBAdminStore) ReindexSearch(ctx context.Context, batchSize int) (*models.SearchReindexReport, error) {
+	report := &models.SearchReindexReport{}
+	generatedSQL := `
+		SELECT COALESCE(bool_or(is_generated = 'ALWAYS'), false)
+		FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = 'recipes' AND column_name = 'search_vector';`
+	if err := s.db.QueryRow(ctx, generatedSQL).Scan(&report.Generated); err != nil {
+		return nil, fmt.Errorf("failed to inspect search_vector column: %w", err)
+	}
+	if report.Generated {
+		return report, nil
+	}
+
+	batchSQL := `
+		WITH batch AS (
+			SELECT id FROM recipes WHERE id > $1 ORDER BY id LIMIT $2
+		)
+		UPDATE recipes r
+		SET search_vector = ` + searchVectorSQL + `
+		FROM batch
+		WHERE r.id = batch.id
+		RETURNING r.id;`
+	var lastID uuid.UUID // uuid.Nil sorts before every other id
+	for {
+		rows, err := s.db.Query(ctx, batchSQL, lastID, batchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reindex search batch: %w", err)
+		}
+		count := 0
+		for rows.Next() {
+			var id uuid.UUID
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan reindexed recipe id: %w", err)
+			}
+			if bytes.Compare(id[:], lastID[:]) > 0 {
+				lastID = id
+			}
+			count++
+		}
+		rows.Close()
+		if rows.Err() != nil {
+			return nil, fmt.Errorf("error reindexing search batch: %w", rows.Err())
+		}
+		if count == 0 {
+			return report, nil
+		}
+		report.Reindexed += count
+		report.Batches++
+		if count < batchSize {
+			return report, nil
+		}
+	}
+}
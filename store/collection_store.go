@@ -0,0 +1,245 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// CollectionStore defines the interface for recipe collection data operations.
+type CollectionStore interface {
+	CreateCollection(ctx context.Context, collectionReq *models.CollectionRequest) (*models.Collection, error)
+	GetCollection(ctx context.Context, id uuid.UUID) (*models.Collection, error)
+	ListCollectionRecipes(ctx context.Context, collectionID uuid.UUID) ([]models.CollectionRecipe, error)
+	AddRecipeToCollection(ctx context.Context, collectionID, recipeID uuid.UUID, sortOrder *int) ([]models.CollectionRecipe, error)
+	RemoveRecipeFromCollection(ctx context.Context, collectionID, recipeID uuid.UUID) error
+	ReorderCollectionRecipes(ctx context.Context, collectionID uuid.UUID, recipeIDs []uuid.UUID) ([]models.CollectionRecipe, error)
+}
+
+// DBCollectionStore implements the CollectionStore interface using a pgxpool.Pool.
+type DBCollectionStore struct {
+	db *pgxpool.Pool
+}
+
+// NewCollectionStore creates a new DBCollectionStore.
+func NewCollectionStore(db *pgxpool.Pool) *DBCollectionStore {
+	return &DBCollectionStore{db: db}
+}
+
+// CreateCollection inserts a new, empty collection and returns it.
+func (s *DBCollectionStore) CreateCollection(ctx context.Context, collectionReq *models.CollectionRequest) (*models.Collection, error) {
+	collection := &models.Collection{}
+	insertSQL := `
+		INSERT INTO collections (name, description, created_by, is_public)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, name, description, created_by, is_public, created_at, updated_at;`
+	err := s.db.QueryRow(ctx, insertSQL, collectionReq.Name, collectionReq.Description, collectionReq.CreatedBy, collectionReq.IsPublic).Scan(
+		&collection.ID, &collection.Name, &collection.Description, &collection.CreatedBy, &collection.IsPublic,
+		&collection.CreatedAt, &collection.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create collection: %w", err)
+	}
+	return collection, nil
+}
+
+// GetCollection returns a collection with its recipe count.
+func (s *DBCollectionStore) GetCollection(ctx context.Context, id uuid.UUID) (*models.Collection, error) {
+	collection := &models.Collection{}
+	query := `
+		SELECT c.id, c.name, c.description, c.created_by, c.is_public, c.created_at, c.updated_at,
+		       (SELECT COUNT(*) FROM collection_recipes cr WHERE cr.collection_id = c.id)
+		FROM collections c
+		WHERE c.id = $1;`
+	err := s.db.QueryRow(ctx, query, id).Scan(
+		&collection.ID, &collection.Name, &collection.Description, &collection.CreatedBy, &collection.IsPublic,
+		&collection.CreatedAt, &collection.UpdatedAt, &collection.RecipeCount,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("collection with ID %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get collection %s: %w", id, err)
+	}
+	return collection, nil
+}
+
+// ListCollectionRecipes returns summaries of a collection's recipes in collection order.
+func (s *DBCollectionStore) ListCollectionRecipes(ctx context.Context, collectionID uuid.UUID) ([]models.CollectionRecipe, error) {
+	var exists bool
+	if err := s.db.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM collections WHERE id = $1)", collectionID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to check collection %s: %w", collectionID, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("collection with ID %s not found", collectionID)
+	}
+
+	listSQL := `
+		SELECT r.id, r.title, r.description, r.photo_filename, r.serves, r.yield_quantity, r.yield_unit,
+		       r.prep_time_minutes, r.cook_time_minutes, r.total_time_minutes,
+		       r.created_at, r.updated_at, r.created_by, r.difficulty, r.slug, r.source_name, r.source_url,
+		       (SELECT COUNT(*) FROM recipe_ingredients ri WHERE ri.recipe_id = r.id) AS ingredient_count,
+		       cr.sort_order, cr.added_at
+		FROM collection_recipes cr
+		JOIN recipes r ON r.id = cr.recipe_id
+		WHERE cr.collection_id = $1
+		ORDER BY cr.sort_order, cr.added_at, r.id;`
+	rows, err := s.db.Query(ctx, listSQL, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recipes of collection %s: %w", collectionID, err)
+	}
+	defer rows.Close()
+
+	recipes := []models.CollectionRecipe{}
+	for rows.Next() {
+		var cr models.CollectionRecipe
+		err := rows.Scan(
+			&cr.ID, &cr.Title, &cr.Description, &cr.PhotoFilename, &cr.Serves, &cr.YieldQuantity, &cr.YieldUnit,
+			&cr.PrepTimeMinutes, &cr.CookTimeMinutes, &cr.TotalTimeMinutes,
+			&cr.CreatedAt, &cr.UpdatedAt, &cr.CreatedBy, &cr.Difficulty, &cr.Slug, &cr.SourceName, &cr.SourceURL,
+			&cr.IngredientCount, &cr.SortOrder, &cr.AddedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan collection recipe: %w", err)
+		}
+		cr.FillTotalTime()
+		recipes = append(recipes, cr)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error iterating collection recipes: %w", rows.Err())
+	}
+	return recipes, nil
+}
+
+// lockCollection touches a collection inside tx, which serialises concurrent changes to its recipe order
+// and marks it as changed.
+func lockCollection(ctx context.Context, tx pgx.Tx, collectionID uuid.UUID) error {
+	err := tx.QueryRow(ctx, "UPDATE collections SET updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING id", collectionID).Scan(&collectionID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("collection with ID %s not found", collectionID)
+		}
+		return fmt.Errorf("failed to lock collection %s: %w", collectionID, err)
+	}
+	return nil
+}
+
+// AddRecipeToCollection inserts a recipe into a collection at sortOrder, moving later recipes down,
+// and returns the collection's recipes. A nil sortOrder, or one past the end, appends the recipe.
+// It returns ErrUnknownRecipe if the recipe does not exist and ErrConflict if it is already in the collection.
+func (s *DBCollectionStore) AddRecipeToCollection(ctx context.Context, collectionID, recipeID uuid.UUID, sortOrder *int) ([]models.CollectionRecipe, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // Rollback if commit is not called
+
+	if err := lockCollection(ctx, tx, collectionID); err != nil {
+		return nil, err
+	}
+
+	var count int
+	if err := tx.QueryRow(ctx, "SELECT COUNT(*) FROM collection_recipes WHERE collection_id = $1", collectionID).Scan(&count); err != nil {
+		return nil, fmt.Errorf("failed to count recipes of collection %s: %w", collectionID, err)
+	}
+	position := count
+	if sortOrder != nil && *sortOrder < count {
+		position = *sortOrder
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE collection_recipes SET sort_order = sort_order + 1 WHERE collection_id = $1 AND sort_order >= $2", collectionID, position)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make room in collection %s: %w", collectionID, err)
+	}
+	_, err = tx.Exec(ctx, "INSERT INTO collection_recipes (collection_id, recipe_id, sort_order) VALUES ($1, $2, $3)", collectionID, recipeID, position)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("%w: recipe %s is already in collection %s", ErrConflict, recipeID, collectionID)
+		}
+		if isForeignKeyViolation(err) {
+			return nil, fmt.Errorf("%w: recipe with ID %s does not exist", ErrUnknownRecipe, recipeID)
+		}
+		return nil, fmt.Errorf("failed to add recipe %s to collection %s: %w", recipeID, collectionID, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return s.ListCollectionRecipes(ctx, collectionID)
+}
+
+// RemoveRecipeFromCollection removes a recipe from a collection and closes the gap in the order.
+// Removing a recipe that is not in the collection is not an error.
+func (s *DBCollectionStore) RemoveRecipeFromCollection(ctx context.Context, collectionID, recipeID uuid.UUID) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // Rollback if commit is not called
+
+	if err := lockCollection(ctx, tx, collectionID); err != nil {
+		return err
+	}
+
+	var removed int
+	err = tx.QueryRow(ctx, "DELETE FROM collection_recipes WHERE collection_id = $1 AND recipe_id = $2 RETURNING sort_order", collectionID, recipeID).Scan(&removed)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to remove recipe %s from collection %s: %w", recipeID, collectionID, err)
+	}
+	_, err = tx.Exec(ctx, "UPDATE collection_recipes SET sort_order = sort_order - 1 WHERE collection_id = $1 AND sort_order > $2", collectionID, removed)
+	if err != nil {
+		return fmt.Errorf("failed to close gap in collection %s: %w", collectionID, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ReorderCollectionRecipes sets the order of a collection's recipes to their position in recipeIDs,
+// in one transaction, and returns the reordered recipes. recipeIDs must list each of the collection's
+// recipes exactly once, otherwise ErrOrderMismatch is returned and nothing changes.
+func (s *DBCollectionStore) ReorderCollectionRecipes(ctx context.Context, collectionID uuid.UUID, recipeIDs []uuid.UUID) ([]models.CollectionRecipe, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // Rollback if commit is not called
+
+	if err := lockCollection(ctx, tx, collectionID); err != nil {
+		return nil, err
+	}
+
+	var current int
+	if err := tx.QueryRow(ctx, "SELECT COUNT(*) FROM collection_recipes WHERE collection_id = $1", collectionID).Scan(&current); err != nil {
+		return nil, fmt.Errorf("failed to count recipes of collection %s: %w", collectionID, err)
+	}
+
+	cmdTag, err := tx.Exec(ctx, `
+		UPDATE collection_recipes cr
+		SET sort_order = o.position - 1
+		FROM unnest($2::uuid[]) WITH ORDINALITY AS o(recipe_id, position)
+		WHERE cr.collection_id = $1 AND cr.recipe_id = o.recipe_id;`, collectionID, recipeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reorder recipes of collection %s: %w", collectionID, err)
+	}
+	// Every listed recipe must be in the collection, and every recipe of the collection must be listed.
+	if int(cmdTag.RowsAffected()) != len(recipeIDs) || current != len(recipeIDs) {
+		return nil, fmt.Errorf("%w: collection %s has %d recipes, %d of the %d listed matched",
+			ErrOrderMismatch, collectionID, current, cmdTag.RowsAffected(), len(recipeIDs))
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return s.ListCollectionRecipes(ctx, collectionID)
+}
@@ -3,28 +3,104 @@ package store
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
 	"time"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/multitracer"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/gaanon/gorecipes_v2/config" // Adjust import path if needed
 )
 
 // NewDBPool creates a new database connection pool.
 func NewDBPool(cfg config.DBConfig) (*pgxpool.Pool, error) {
-	dbPool, err := pgxpool.New(context.Background(), cfg.ConnectionString())
+	poolCfg, err := pgxpool.ParseConfig(cfg.ConnectionString())
 	if err != nil {
-		return nil, fmt.Errorf("unable to create connection pool: %w", err)
+		return nil, fmt.Errorf("unable to parse database config: %w", err)
+	}
+	queryMode, err := queryExecMode(cfg.QueryMode)
+	if err != nil {
+		return nil, err
+	}
+	poolCfg.ConnConfig.DefaultQueryExecMode = queryMode
+	var tracers []pgx.QueryTracer
+	if cfg.SlowQueryThreshold > 0 {
+		tracers = append(tracers, &SlowQueryTracer{
+			Threshold:  cfg.SlowQueryThreshold,
+			RedactArgs: cfg.RedactSlowQueryArgs,
+			Logger:     slog.Default(),
+		})
+	}
+	if cfg.AcquireTimeout > 0 {
+		// The pool only calls acquire hooks on the connection tracer, so it has to be set there.
+		tracers = append(tracers, &AcquireTimeoutTracer{Timeout: cfg.AcquireTimeout})
+	}
+	switch len(tracers) {
+	case 0:
+	case 1:
+		poolCfg.ConnConfig.Tracer = tracers[0]
+	default:
+		poolCfg.ConnConfig.Tracer = multitracer.New(tracers...)
+	}
+
+	if cfg.StatementTimeout > 0 {
+		// Enforced by PostgreSQL itself, so runaway queries die even if the Go context is never cancelled.
+		poolCfg.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)
 	}
 
-	// Ping the database to verify the connection.
-	// Use a context with a timeout for the ping.
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	dbPool, err := pgxpool.NewWithConfig(context.Background(), poolCfg)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create connection pool: %w", err)
+	}
 
-	if err = dbPool.Ping(ctx); err != nil {
-		dbPool.Close() // Close the pool if ping fails
-		return nil, fmt.Errorf("unable to ping database: %w", err)
+	// Ping the database to verify the connection, retrying with exponential backoff so the
+	// app can start before the database is ready (e.g. under docker-compose or Kubernetes).
+	attempts := max(cfg.ConnectAttempts, 1)
+	for attempt := 1; ; attempt++ {
+		// Use a context with a timeout for each ping.
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		err = dbPool.Ping(ctx)
+		cancel()
+		if err == nil {
+			break
+		}
+		if attempt >= attempts {
+			dbPool.Close() // Close the pool if every ping failed
+			return nil, fmt.Errorf("unable to ping database after %d attempt(s): %w", attempt, err)
+		}
+		wait := connectBackoff(cfg.ConnectRetryInterval, attempt)
+		slog.Warn("database not reachable, retrying", "attempt", attempt, "max_attempts", attempts, "retry_in", wait, "error", err)
+		time.Sleep(wait)
 	}
 
 	fmt.Println("Successfully connected to the database!")
 	return dbPool, nil
 }
+
+// queryExecMode maps the DB_QUERY_MODE setting to a pgx query mode. Empty and "extended" keep pgx's
+// default; "simple" avoids prepared statements, which PgBouncer in transaction mode cannot share across
+// the server connections it hands out.
+func queryExecMode(mode string) (pgx.QueryExecMode, error) {
+	switch strings.ToLower(mode) {
+	case "", "extended":
+		return pgx.QueryExecModeCacheStatement, nil
+	case "simple":
+		return pgx.QueryExecModeSimpleProtocol, nil
+	default:
+		return 0, fmt.Errorf("unknown database query mode %q: use \"extended\" or \"simple\"", mode)
+	}
+}
+
+// maxConnectBackoff caps the wait between startup connection attempts.
+const maxConnectBackoff = 30 * time.Second
+
+// connectBackoff returns how long to wait after the given failed attempt (1-based):
+// base, 2*base, 4*base, ... capped at maxConnectBackoff.
+func connectBackoff(base time.Duration, attempt int) time.Duration {
+	wait := base
+	for i := 1; i < attempt && wait < maxConnectBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, maxConnectBackoff)
+}
@@ -0,0 +1,34 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, connectBackoff(time.Second, 1))
+	assert.Equal(t, 2*time.Second, connectBackoff(time.Second, 2))
+	assert.Equal(t, 8*time.Second, connectBackoff(time.Second, 4))
+	assert.Equal(t, maxConnectBackoff, connectBackoff(time.Second, 10))
+	assert.Equal(t, maxConnectBackoff, connectBackoff(time.Minute, 1))
+	assert.Equal(t, time.Duration(0), connectBackoff(0, 3))
+}
+
+func TestQueryExecMode(t *testing.T) {
+	for mode, want := range map[string]pgx.QueryExecMode{
+		"":         pgx.QueryExecModeCacheStatement,
+		"extended": pgx.QueryExecModeCacheStatement,
+		"simple":   pgx.QueryExecModeSimpleProtocol,
+		"Simple":   pgx.QueryExecModeSimpleProtocol,
+	} {
+		got, err := queryExecMode(mode)
+		assert.NoError(t, err, mode)
+		assert.Equal(t, want, got, mode)
+	}
+
+	_, err := queryExecMode("prepared")
+	assert.Error(t, err)
+}
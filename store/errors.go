@@ -0,0 +1,60 @@
+package store
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrConflict is returned when a write would violate a uniqueness rule,
+// e.g. renaming an ingredient to a name that is already taken.
+var ErrConflict = errors.New("conflict")
+
+// ErrUnknownIngredient is returned when a request references an ingredient ID that does not exist.
+var ErrUnknownIngredient = errors.New("unknown ingredient")
+
+// ErrUnknownRecipe is returned when a request references a recipe ID that does not exist,
+// e.g. when adding a recipe to a collection.
+var ErrUnknownRecipe = errors.New("unknown recipe")
+
+// ErrUnknownUnit is returned when a request references a measurement unit ID that does not exist.
+var ErrUnknownUnit = errors.New("unknown measurement unit")
+
+// ErrOrderMismatch is returned when a reorder request does not list exactly the items the recipe or collection has.
+var ErrOrderMismatch = errors.New("order does not match the current items")
+
+// RelationError identifies the ingredient, step, tag or equipment of a recipe request that a write failed on.
+// It wraps the underlying error, so errors.Is still sees sentinels such as ErrUnknownIngredient.
+type RelationError struct {
+	Relation string `json:"relation"` // "ingredients", "steps", "tags" or "equipment"
+	Index    int    `json:"index"`    // Position in the request's list
+	Name     string `json:"name"`     // Ingredient name or ID, step number, tag or equipment name
+	Err      error  `json:"-"`
+}
+
+func (e *RelationError) Error() string { return e.Err.Error() }
+func (e *RelationError) Unwrap() error { return e.Err }
+
+// relationError wraps err, already annotated with a message, as a RelationError.
+func relationError(relation string, index int, name string, err error) error {
+	return &RelationError{Relation: relation, Index: index, Name: name, Err: err}
+}
+
+// isUniqueViolation reports whether err is a PostgreSQL unique_violation (SQLSTATE 23505).
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// isForeignKeyViolation reports whether err is a PostgreSQL foreign_key_violation (SQLSTATE 23503).
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503"
+}
+
+// isUndefinedFunction reports whether err is a PostgreSQL undefined_function (SQLSTATE 42883),
+// which is also raised for a missing operator.
+func isUndefinedFunction(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "42883"
+}
@@ -0,0 +1,280 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// IngredientStore defines the interface for ingredient data operations.
+type IngredientStore interface {
+	UpdateIngredient(ctx context.Context, id uuid.UUID, ingredientReq *models.IngredientRequest) (*models.Ingredient, error)
+	IngredientStats(ctx context.Context, id uuid.UUID) (*models.IngredientStats, error)
+	SetIngredientSeasons(ctx context.Context, id uuid.UUID, months []int) (*models.Ingredient, error)
+	PopularIngredients(ctx context.Context, limit int) ([]models.IngredientCount, error)
+	SetIngredientPrice(ctx context.Context, id uuid.UUID, priceReq *models.IngredientPriceRequest) (*models.Ingredient, error)
+	PatchIngredient(ctx context.Context, id uuid.UUID, patch *models.IngredientPatchRequest) (*models.Ingredient, error)
+	ListIngredients(ctx context.Context, params models.IngredientListParams) ([]models.Ingredient, error)
+}
+
+// DBIngredientStore implements the IngredientStore interface using a pgxpool.Pool.
+type DBIngredientStore struct {
+	db *pgxpool.Pool
+}
+
+// NewIngredientStore creates a new DBIngredientStore.
+func NewIngredientStore(db *pgxpool.Pool) *DBIngredientStore {
+	return &DBIngredientStore{db: db}
+}
+
+// ingredientColumns are the columns scanIngredient reads, in order.
+const ingredientColumns = "id, name, category, seasons, unit_price, price_unit_id, created_at"
+
+// scanIngredient scans a row of ingredientColumns.
+func scanIngredient(row pgx.Row) (*models.Ingredient, error) {
+	ingredient := &models.Ingredient{}
+	err := row.Scan(&ingredient.ID, &ingredient.Name, &ingredient.Category, &ingredient.Seasons,
+		&ingredient.UnitPrice, &ingredient.PriceUnitID, &ingredient.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return ingredient, nil
+}
+
+// UpdateIngredient renames an ingredient and updates its category.
+// Every recipe referencing the ingredient picks up the new name, since recipes link to it by ID;
+// their search vectors are refreshed in the same transaction.
+// It returns ErrConflict if another ingredient already uses the requested name, in any case.
+func (s *DBIngredientStore) UpdateIngredient(ctx context.Context, id uuid.UUID, ingredientReq *models.IngredientRequest) (*models.Ingredient, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // Rollback if commit is not called
+
+	var existingID uuid.UUID
+	err = tx.QueryRow(ctx, "SELECT id FROM ingredients WHERE LOWER(name) = LOWER($1) AND id <> $2", ingredientReq.Name, id).Scan(&existingID)
+	if err == nil {
+		return nil, fmt.Errorf("%w: ingredient %q already exists with ID %s", ErrConflict, ingredientReq.Name, existingID)
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to check ingredient name %s: %w", ingredientReq.Name, err)
+	}
+
+	updateSQL := `
+		UPDATE ingredients
+		SET name = $2, category = $3
+		WHERE id = $1
+		RETURNING ` + ingredientColumns + `;`
+	ingredient, err := scanIngredient(tx.QueryRow(ctx, updateSQL, id, ingredientReq.Name, ingredientReq.Category))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("ingredient with ID %s not found for update", id)
+		}
+		if isUniqueViolation(err) { // Lost a race with a concurrent create/rename
+			return nil, fmt.Errorf("%w: ingredient %q already exists", ErrConflict, ingredientReq.Name)
+		}
+		return nil, fmt.Errorf("failed to update ingredient %s: %w", id, err)
+	}
+
+	err = refreshSearchVectors(ctx, tx, "r.id IN (SELECT recipe_id FROM recipe_ingredients WHERE ingredient_id = $1)", id)
+	if err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return ingredient, nil
+}
+
+// IngredientStats reports how many recipes use an ingredient and, per unit it is used with,
+// how often and in what average quantity.
+func (s *DBIngredientStore) IngredientStats(ctx context.Context, id uuid.UUID) (*models.IngredientStats, error) {
+	stats := &models.IngredientStats{IngredientID: id, Units: []models.IngredientUnitUsage{}}
+	err := s.db.QueryRow(ctx, `
+		SELECT i.name, (SELECT COUNT(*) FROM recipe_ingredients ri WHERE ri.ingredient_id = i.id)
+		FROM ingredients i
+		WHERE i.id = $1;`, id).Scan(&stats.IngredientName, &stats.RecipeCount)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("ingredient with ID %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get ingredient %s: %w", id, err)
+	}
+
+	rows, err := s.db.Query(ctx, `
+		SELECT mu.id, mu.name, mu.abbreviation, mu.system,
+		       COUNT(*), ROUND(AVG(ri.quantity), 3)::float8
+		FROM recipe_ingredients ri
+		LEFT JOIN measurement_units mu ON ri.unit_id = mu.id
+		WHERE ri.ingredient_id = $1
+		GROUP BY mu.id, mu.name, mu.abbreviation, mu.system
+		ORDER BY COUNT(*) DESC, mu.name NULLS LAST;`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unit usage for ingredient %s: %w", id, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var usage models.IngredientUnitUsage
+		var unit models.MeasurementUnit
+		if err := rows.Scan(&unit.ID, &unit.Name, &unit.Abbreviation, &unit.System, &usage.RecipeCount, &usage.AverageQuantity); err != nil {
+			return nil, fmt.Errorf("failed to scan unit usage for ingredient %s: %w", id, err)
+		}
+		if unit.ID != nil {
+			usage.Unit = &unit
+		}
+		stats.Units = append(stats.Units, usage)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error iterating unit usage for ingredient %s: %w", id, rows.Err())
+	}
+	return stats, nil
+}
+
+// SetIngredientSeasons records the months (1-12) an ingredient is in season, replacing any previous ones.
+// An empty list clears them, marking the ingredient available year-round.
+func (s *DBIngredientStore) SetIngredientSeasons(ctx context.Context, id uuid.UUID, months []int) (*models.Ingredient, error) {
+	var seasons []int // NULL when empty
+	if len(months) > 0 {
+		seasons = slices.Sorted(slices.Values(months))
+	}
+	updateSQL := `
+		UPDATE ingredients
+		SET seasons = $2
+		WHERE id = $1
+		RETURNING ` + ingredientColumns + `;`
+	ingredient, err := scanIngredient(s.db.QueryRow(ctx, updateSQL, id, seasons))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("ingredient with ID %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to set seasons of ingredient %s: %w", id, err)
+	}
+	return ingredient, nil
+}
+
+// PopularIngredients returns the limit ingredients used by the most recipes, most used first; ties are ordered by name.
+// A recipe listing an ingredient twice counts once, and ingredients no recipe uses are left out.
+func (s *DBIngredientStore) PopularIngredients(ctx context.Context, limit int) ([]models.IngredientCount, error) {
+	query := `
+		SELECT i.id, i.name, i.category, COUNT(DISTINCT ri.recipe_id) AS recipe_count
+		FROM ingredients i
+		JOIN recipe_ingredients ri ON ri.ingredient_id = i.id
+		GROUP BY i.id, i.name, i.category
+		ORDER BY recipe_count DESC, i.name
+		LIMIT $1;`
+	rows, err := s.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query popular ingredients: %w", err)
+	}
+	defer rows.Close()
+
+	ingredients := []models.IngredientCount{}
+	for rows.Next() {
+		var ingredient models.IngredientCount
+		if err := rows.Scan(&ingredient.ID, &ingredient.Name, &ingredient.Category, &ingredient.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan popular ingredient: %w", err)
+		}
+		ingredients = append(ingredients, ingredient)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error iterating popular ingredients: %w", rows.Err())
+	}
+	return ingredients, nil
+}
+
+// SetIngredientPrice records the price used for recipe cost estimates, replacing any previous one.
+// A nil UnitPrice clears it. It returns ErrUnknownUnit if PriceUnitID references no measurement unit.
+func (s *DBIngredientStore) SetIngredientPrice(ctx context.Context, id uuid.UUID, priceReq *models.IngredientPriceRequest) (*models.Ingredient, error) {
+	updateSQL := `
+		UPDATE ingredients
+		SET unit_price = $2, price_unit_id = $3
+		WHERE id = $1
+		RETURNING ` + ingredientColumns + `;`
+	ingredient, err := scanIngredient(s.db.QueryRow(ctx, updateSQL, id, priceReq.UnitPrice, priceReq.PriceUnitID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("ingredient with ID %s not found", id)
+		}
+		if isForeignKeyViolation(err) {
+			return nil, fmt.Errorf("%w: no measurement unit with ID %s", ErrUnknownUnit, *priceReq.PriceUnitID)
+		}
+		return nil, fmt.Errorf("failed to set price of ingredient %s: %w", id, err)
+	}
+	return ingredient, nil
+}
+
+// PatchIngredient changes the fields of an ingredient listed in patch.Fields, leaving the others as they are.
+// It returns ErrUnknownUnit if PriceUnitID references no measurement unit.
+func (s *DBIngredientStore) PatchIngredient(ctx context.Context, id uuid.UUID, patch *models.IngredientPatchRequest) (*models.Ingredient, error) {
+	values := map[string]any{
+		"category":      patch.Category,
+		"unit_price":    patch.UnitPrice,
+		"price_unit_id": patch.PriceUnitID,
+	}
+	args := []any{id}
+	var assignments []string
+	for _, field := range patch.Fields {
+		value, ok := values[field]
+		if !ok {
+			return nil, fmt.Errorf("cannot patch ingredient field %q", field)
+		}
+		args = append(args, value)
+		assignments = append(assignments, fmt.Sprintf("%s = $%d", field, len(args)))
+	}
+	if len(assignments) == 0 {
+		return nil, errors.New("no ingredient fields to patch")
+	}
+
+	updateSQL := `
+		UPDATE ingredients
+		SET ` + strings.Join(assignments, ", ") + `
+		WHERE id = $1
+		RETURNING ` + ingredientColumns + `;`
+	ingredient, err := scanIngredient(s.db.QueryRow(ctx, updateSQL, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("ingredient with ID %s not found", id)
+		}
+		if isForeignKeyViolation(err) {
+			return nil, fmt.Errorf("%w: no measurement unit with ID %s", ErrUnknownUnit, *patch.PriceUnitID)
+		}
+		return nil, fmt.Errorf("failed to patch ingredient %s: %w", id, err)
+	}
+	return ingredient, nil
+}
+
+// ListIngredients returns a page of ingredients ordered by name.
+func (s *DBIngredientStore) ListIngredients(ctx context.Context, params models.IngredientListParams) ([]models.Ingredient, error) {
+	query := "SELECT " + ingredientColumns + " FROM ingredients"
+	if params.Uncategorized {
+		query += " WHERE category IS NULL OR category = ''"
+	}
+	query += " ORDER BY name, id LIMIT $1 OFFSET $2;"
+	rows, err := s.db.Query(ctx, query, params.Limit, params.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ingredients: %w", err)
+	}
+	defer rows.Close()
+
+	ingredients := []models.Ingredient{}
+	for rows.Next() {
+		ingredient, err := scanIngredient(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ingredient: %w", err)
+		}
+		ingredients = append(ingredients, *ingredient)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error iterating ingredients: %w", rows.Err())
+	}
+	return ingredients, nil
+}
@@ -36,6 +36,21 @@ func (m *MockRecipeStore) EXPECT() *MockRecipeStoreMockRecorder {
 	return m.recorder
 }
 
+// AddIngredient mocks base method.
+func (m *MockRecipeStore) AddIngredient(ctx context.Context, recipeID uuid.UUID, ingReq *models.RecipeIngredientRequest) (*models.RecipeIngredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddIngredient", ctx, recipeID, ingReq)
+	ret0, _ := ret[0].(*models.RecipeIngredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddIngredient indicates an expected call of AddIngredient.
+func (mr *MockRecipeStoreMockRecorder) AddIngredient(ctx, recipeID, ingReq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddIngredient", reflect.TypeOf((*MockRecipeStore)(nil).AddIngredient), ctx, recipeID, ingReq)
+}
+
 // CreateRecipe mocks base method.
 func (m *MockRecipeStore) CreateRecipe(ctx context.Context, recipeReq *models.RecipeRequest) (*models.Recipe, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +80,36 @@ func (mr *MockRecipeStoreMockRecorder) DeleteRecipe(ctx, id interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipe", reflect.TypeOf((*MockRecipeStore)(nil).DeleteRecipe), ctx, id)
 }
 
+// DeleteRecipes mocks base method.
+func (m *MockRecipeStore) DeleteRecipes(ctx context.Context, ids []uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecipes", ctx, ids)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRecipes indicates an expected call of DeleteRecipes.
+func (mr *MockRecipeStoreMockRecorder) DeleteRecipes(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecipes", reflect.TypeOf((*MockRecipeStore)(nil).DeleteRecipes), ctx, ids)
+}
+
+// ExistingRecipeIDs mocks base method.
+func (m *MockRecipeStore) ExistingRecipeIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistingRecipeIDs", ctx, ids)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistingRecipeIDs indicates an expected call of ExistingRecipeIDs.
+func (mr *MockRecipeStoreMockRecorder) ExistingRecipeIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistingRecipeIDs", reflect.TypeOf((*MockRecipeStore)(nil).ExistingRecipeIDs), ctx, ids)
+}
+
 // GetRecipeByID mocks base method.
 func (m *MockRecipeStore) GetRecipeByID(ctx context.Context, id uuid.UUID) (*models.Recipe, error) {
 	m.ctrl.T.Helper()
@@ -80,19 +125,228 @@ func (mr *MockRecipeStoreMockRecorder) GetRecipeByID(ctx, id interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeByID", reflect.TypeOf((*MockRecipeStore)(nil).GetRecipeByID), ctx, id)
 }
 
+// GetRecipeBySlug mocks base method.
+func (m *MockRecipeStore) GetRecipeBySlug(ctx context.Context, slug string) (*models.Recipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeBySlug", ctx, slug)
+	ret0, _ := ret[0].(*models.Recipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeBySlug indicates an expected call of GetRecipeBySlug.
+func (mr *MockRecipeStoreMockRecorder) GetRecipeBySlug(ctx, slug interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeBySlug", reflect.TypeOf((*MockRecipeStore)(nil).GetRecipeBySlug), ctx, slug)
+}
+
+// GetRecipePhoto mocks base method.
+func (m *MockRecipeStore) GetRecipePhoto(ctx context.Context, recipeID uuid.UUID) (*string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipePhoto", ctx, recipeID)
+	ret0, _ := ret[0].(*string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipePhoto indicates an expected call of GetRecipePhoto.
+func (mr *MockRecipeStoreMockRecorder) GetRecipePhoto(ctx, recipeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipePhoto", reflect.TypeOf((*MockRecipeStore)(nil).GetRecipePhoto), ctx, recipeID)
+}
+
+// GetRecipeTags mocks base method.
+func (m *MockRecipeStore) GetRecipeTags(ctx context.Context, recipeID uuid.UUID) ([]models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeTags", ctx, recipeID)
+	ret0, _ := ret[0].([]models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeTags indicates an expected call of GetRecipeTags.
+func (mr *MockRecipeStoreMockRecorder) GetRecipeTags(ctx, recipeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeTags", reflect.TypeOf((*MockRecipeStore)(nil).GetRecipeTags), ctx, recipeID)
+}
+
+// GetRecipeTranslation mocks base method.
+func (m *MockRecipeStore) GetRecipeTranslation(ctx context.Context, recipeID uuid.UUID, lang string) (*models.RecipeTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipeTranslation", ctx, recipeID, lang)
+	ret0, _ := ret[0].(*models.RecipeTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipeTranslation indicates an expected call of GetRecipeTranslation.
+func (mr *MockRecipeStoreMockRecorder) GetRecipeTranslation(ctx, recipeID, lang interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipeTranslation", reflect.TypeOf((*MockRecipeStore)(nil).GetRecipeTranslation), ctx, recipeID, lang)
+}
+
+// GetStep mocks base method.
+func (m *MockRecipeStore) GetStep(ctx context.Context, recipeID uuid.UUID, stepNumber int) (*models.RecipeStep, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStep", ctx, recipeID, stepNumber)
+	ret0, _ := ret[0].(*models.RecipeStep)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStep indicates an expected call of GetStep.
+func (mr *MockRecipeStoreMockRecorder) GetStep(ctx, recipeID, stepNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStep", reflect.TypeOf((*MockRecipeStore)(nil).GetStep), ctx, recipeID, stepNumber)
+}
+
+// IngredientPrices mocks base method.
+func (m *MockRecipeStore) IngredientPrices(ctx context.Context, recipeID uuid.UUID) ([]models.IngredientPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IngredientPrices", ctx, recipeID)
+	ret0, _ := ret[0].([]models.IngredientPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IngredientPrices indicates an expected call of IngredientPrices.
+func (mr *MockRecipeStoreMockRecorder) IngredientPrices(ctx, recipeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IngredientPrices", reflect.TypeOf((*MockRecipeStore)(nil).IngredientPrices), ctx, recipeID)
+}
+
+// ListConversionUnits mocks base method.
+func (m *MockRecipeStore) ListConversionUnits(ctx context.Context) ([]*models.MeasurementUnit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListConversionUnits", ctx)
+	ret0, _ := ret[0].([]*models.MeasurementUnit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListConversionUnits indicates an expected call of ListConversionUnits.
+func (mr *MockRecipeStoreMockRecorder) ListConversionUnits(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConversionUnits", reflect.TypeOf((*MockRecipeStore)(nil).ListConversionUnits), ctx)
+}
+
 // ListRecipes mocks base method.
-func (m *MockRecipeStore) ListRecipes(ctx context.Context) ([]*models.Recipe, error) {
+func (m *MockRecipeStore) ListRecipes(ctx context.Context, params models.RecipeListParams) ([]*models.Recipe, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListRecipes", ctx)
+	ret := m.ctrl.Call(m, "ListRecipes", ctx, params)
 	ret0, _ := ret[0].([]*models.Recipe)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListRecipes indicates an expected call of ListRecipes.
-func (mr *MockRecipeStoreMockRecorder) ListRecipes(ctx interface{}) *gomock.Call {
+func (mr *MockRecipeStoreMockRecorder) ListRecipes(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipes", reflect.TypeOf((*MockRecipeStore)(nil).ListRecipes), ctx, params)
+}
+
+// RecipeStats mocks base method.
+func (m *MockRecipeStore) RecipeStats(ctx context.Context) (*models.RecipeStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecipeStats", ctx)
+	ret0, _ := ret[0].(*models.RecipeStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecipeStats indicates an expected call of RecipeStats.
+func (mr *MockRecipeStoreMockRecorder) RecipeStats(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecipeStats", reflect.TypeOf((*MockRecipeStore)(nil).RecipeStats), ctx)
+}
+
+// RemoveIngredient mocks base method.
+func (m *MockRecipeStore) RemoveIngredient(ctx context.Context, recipeID uuid.UUID, ingredientID uuid.UUID) ([]models.RecipeIngredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveIngredient", ctx, recipeID, ingredientID)
+	ret0, _ := ret[0].([]models.RecipeIngredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveIngredient indicates an expected call of RemoveIngredient.
+func (mr *MockRecipeStoreMockRecorder) RemoveIngredient(ctx, recipeID, ingredientID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveIngredient", reflect.TypeOf((*MockRecipeStore)(nil).RemoveIngredient), ctx, recipeID, ingredientID)
+}
+
+// ReorderIngredients mocks base method.
+func (m *MockRecipeStore) ReorderIngredients(ctx context.Context, recipeID uuid.UUID, ingredientIDs []uuid.UUID) ([]models.RecipeIngredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderIngredients", ctx, recipeID, ingredientIDs)
+	ret0, _ := ret[0].([]models.RecipeIngredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderIngredients indicates an expected call of ReorderIngredients.
+func (mr *MockRecipeStoreMockRecorder) ReorderIngredients(ctx, recipeID, ingredientIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderIngredients", reflect.TypeOf((*MockRecipeStore)(nil).ReorderIngredients), ctx, recipeID, ingredientIDs)
+}
+
+// SearchRecipes mocks base method.
+func (m *MockRecipeStore) SearchRecipes(ctx context.Context, params models.RecipeSearchParams) ([]*models.RecipeSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchRecipes", ctx, params)
+	ret0, _ := ret[0].([]*models.RecipeSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchRecipes indicates an expected call of SearchRecipes.
+func (mr *MockRecipeStoreMockRecorder) SearchRecipes(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchRecipes", reflect.TypeOf((*MockRecipeStore)(nil).SearchRecipes), ctx, params)
+}
+
+// SetRecipePhoto mocks base method.
+func (m *MockRecipeStore) SetRecipePhoto(ctx context.Context, recipeID uuid.UUID, filename string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRecipePhoto", ctx, recipeID, filename)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRecipePhoto indicates an expected call of SetRecipePhoto.
+func (mr *MockRecipeStoreMockRecorder) SetRecipePhoto(ctx, recipeID, filename interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRecipePhoto", reflect.TypeOf((*MockRecipeStore)(nil).SetRecipePhoto), ctx, recipeID, filename)
+}
+
+// ShoppingListLines mocks base method.
+func (m *MockRecipeStore) ShoppingListLines(ctx context.Context, recipeIDs []uuid.UUID) ([]models.ShoppingListLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShoppingListLines", ctx, recipeIDs)
+	ret0, _ := ret[0].([]models.ShoppingListLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShoppingListLines indicates an expected call of ShoppingListLines.
+func (mr *MockRecipeStoreMockRecorder) ShoppingListLines(ctx, recipeIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShoppingListLines", reflect.TypeOf((*MockRecipeStore)(nil).ShoppingListLines), ctx, recipeIDs)
+}
+
+// SimilarRecipes mocks base method.
+func (m *MockRecipeStore) SimilarRecipes(ctx context.Context, recipeID uuid.UUID, limit int) ([]*models.SimilarRecipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SimilarRecipes", ctx, recipeID, limit)
+	ret0, _ := ret[0].([]*models.SimilarRecipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SimilarRecipes indicates an expected call of SimilarRecipes.
+func (mr *MockRecipeStoreMockRecorder) SimilarRecipes(ctx, recipeID, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecipes", reflect.TypeOf((*MockRecipeStore)(nil).ListRecipes), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SimilarRecipes", reflect.TypeOf((*MockRecipeStore)(nil).SimilarRecipes), ctx, recipeID, limit)
 }
 
 // UpdateRecipe mocks base method.
@@ -109,3 +363,18 @@ func (mr *MockRecipeStoreMockRecorder) UpdateRecipe(ctx, id, recipeReq interface
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRecipe", reflect.TypeOf((*MockRecipeStore)(nil).UpdateRecipe), ctx, id, recipeReq)
 }
+
+// UpsertRecipeTranslation mocks base method.
+func (m *MockRecipeStore) UpsertRecipeTranslation(ctx context.Context, recipeID uuid.UUID, lang string, translationReq *models.RecipeTranslationRequest) (*models.RecipeTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertRecipeTranslation", ctx, recipeID, lang, translationReq)
+	ret0, _ := ret[0].(*models.RecipeTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertRecipeTranslation indicates an expected call of UpsertRecipeTranslation.
+func (mr *MockRecipeStoreMockRecorder) UpsertRecipeTranslation(ctx, recipeID, lang, translationReq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertRecipeTranslation", reflect.TypeOf((*MockRecipeStore)(nil).UpsertRecipeTranslation), ctx, recipeID, lang, translationReq)
+}
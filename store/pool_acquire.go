@@ -0,0 +1,66 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrPoolExhausted is the cause of a connection acquire that gave up because every pooled
+// connection stayed in use for the whole acquire timeout.
+var ErrPoolExhausted = errors.New("database connection pool exhausted")
+
+// AcquireTimeoutTracer is a pgxpool.AcquireTracer that bounds how long a query waits for a free
+// connection, so requests fail fast under load instead of hanging until their own deadline.
+// pgx reports such a failure as a plain context.DeadlineExceeded; to tell it apart from other
+// deadlines, the tracer marks contexts prepared with TrackPoolExhaustion (see PoolExhausted).
+// It does not trace queries; the query methods only satisfy pgx.QueryTracer.
+type AcquireTimeoutTracer struct {
+	Timeout time.Duration
+}
+
+type acquireCancelCtxKey struct{}
+
+type poolExhaustionCtxKey struct{}
+
+// TraceAcquireStart gives the acquire its own deadline, with ErrPoolExhausted as the cause.
+func (t *AcquireTimeoutTracer) TraceAcquireStart(ctx context.Context, _ *pgxpool.Pool, _ pgxpool.TraceAcquireStartData) context.Context {
+	ctx, cancel := context.WithTimeoutCause(ctx, t.Timeout, ErrPoolExhausted)
+	return context.WithValue(ctx, acquireCancelCtxKey{}, cancel)
+}
+
+// TraceAcquireEnd releases the acquire deadline and records whether it is what made the acquire fail.
+func (t *AcquireTimeoutTracer) TraceAcquireEnd(ctx context.Context, _ *pgxpool.Pool, data pgxpool.TraceAcquireEndData) {
+	if data.Err != nil && errors.Is(context.Cause(ctx), ErrPoolExhausted) {
+		if exhausted, ok := ctx.Value(poolExhaustionCtxKey{}).(*atomic.Bool); ok {
+			exhausted.Store(true)
+		}
+	}
+	if cancel, ok := ctx.Value(acquireCancelCtxKey{}).(context.CancelFunc); ok {
+		cancel()
+	}
+}
+
+// TraceQueryStart does nothing.
+func (t *AcquireTimeoutTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	return ctx
+}
+
+// TraceQueryEnd does nothing.
+func (t *AcquireTimeoutTracer) TraceQueryEnd(context.Context, *pgx.Conn, pgx.TraceQueryEndData) {}
+
+// TrackPoolExhaustion returns a context in which store calls record acquire timeouts, for PoolExhausted.
+func TrackPoolExhaustion(ctx context.Context) context.Context {
+	return context.WithValue(ctx, poolExhaustionCtxKey{}, new(atomic.Bool))
+}
+
+// PoolExhausted reports whether a store call made with ctx, which must come from TrackPoolExhaustion,
+// failed because no connection became free within the acquire timeout.
+func PoolExhausted(ctx context.Context) bool {
+	exhausted, ok := ctx.Value(poolExhaustionCtxKey{}).(*atomic.Bool)
+	return ok && exhausted.Load()
+}
@@ -0,0 +1,36 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcquireTimeoutTracer(t *testing.T) {
+	tracer := &AcquireTimeoutTracer{Timeout: time.Millisecond}
+
+	// The acquire deadline expires while waiting: the tracked context records the exhaustion.
+	ctx := TrackPoolExhaustion(context.Background())
+	acquireCtx := tracer.TraceAcquireStart(ctx, nil, pgxpool.TraceAcquireStartData{})
+	<-acquireCtx.Done()
+	tracer.TraceAcquireEnd(acquireCtx, nil, pgxpool.TraceAcquireEndData{Err: acquireCtx.Err()})
+	assert.True(t, PoolExhausted(ctx))
+
+	// A successful acquire, or one cut short by the caller's own deadline, is not exhaustion.
+	ctx = TrackPoolExhaustion(context.Background())
+	acquireCtx = tracer.TraceAcquireStart(ctx, nil, pgxpool.TraceAcquireStartData{})
+	tracer.TraceAcquireEnd(acquireCtx, nil, pgxpool.TraceAcquireEndData{})
+	assert.Error(t, acquireCtx.Err(), "the acquire deadline is released")
+	assert.False(t, PoolExhausted(ctx))
+
+	parent, cancel := context.WithCancel(TrackPoolExhaustion(context.Background()))
+	acquireCtx = (&AcquireTimeoutTracer{Timeout: time.Hour}).TraceAcquireStart(parent, nil, pgxpool.TraceAcquireStartData{})
+	cancel()
+	tracer.TraceAcquireEnd(acquireCtx, nil, pgxpool.TraceAcquireEndData{Err: acquireCtx.Err()})
+	assert.False(t, PoolExhausted(parent))
+
+	assert.False(t, PoolExhausted(context.Background()), "untracked contexts never report exhaustion")
+}
@@ -0,0 +1,87 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// findOrCreateEquipment finds equipment by name or creates it if not found.
+func findOrCreateEquipment(ctx context.Context, tx pgx.Tx, name string) (uuid.UUID, error) {
+	var equipmentID uuid.UUID
+	err := tx.QueryRow(ctx, "SELECT id FROM equipment WHERE name = $1", name).Scan(&equipmentID)
+	if err == nil {
+		return equipmentID, nil // Found
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, fmt.Errorf("failed to query equipment by name %s: %w", name, err)
+	}
+
+	// Not found, create new equipment (DB defaults created_at)
+	equipmentID = uuid.New()
+	_, err = tx.Exec(ctx, "INSERT INTO equipment (id, name) VALUES ($1, $2)", equipmentID, name)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create new equipment %s: %w", name, err)
+	}
+	return equipmentID, nil
+}
+
+// insertRecipeEquipment links the requested equipment to a recipe, creating unknown equipment by name.
+// Repeated names are linked once, comparing case-insensitively like tags.
+func insertRecipeEquipment(ctx context.Context, tx pgx.Tx, recipeID uuid.UUID, equipment []models.RecipeEquipmentRequest) error {
+	seen := make(map[string]bool, len(equipment))
+	for i, eqReq := range equipment {
+		key := strings.ToLower(eqReq.Name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		equipmentID, err := findOrCreateEquipment(ctx, tx, eqReq.Name)
+		if err != nil {
+			return relationError("equipment", i, eqReq.Name, fmt.Errorf("processing equipment %s: %w", eqReq.Name, err))
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO recipe_equipment (recipe_id, equipment_id)
+			VALUES ($1, $2)
+			ON CONFLICT DO NOTHING;`,
+			recipeID, equipmentID)
+		if err != nil {
+			return relationError("equipment", i, eqReq.Name, fmt.Errorf("failed to insert recipe equipment link for %s: %w", eqReq.Name, err))
+		}
+	}
+	return nil
+}
+
+// getRecipeEquipment returns the equipment linked to a recipe, ordered by name.
+func (s *DBRecipeStore) getRecipeEquipment(ctx context.Context, recipeID uuid.UUID) ([]models.Equipment, error) {
+	equipmentSQL := `
+		SELECT e.id, e.name, e.created_at
+		FROM recipe_equipment re
+		JOIN equipment e ON re.equipment_id = e.id
+		WHERE re.recipe_id = $1
+		ORDER BY e.name;`
+	rows, err := s.db.Query(ctx, equipmentSQL, recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get equipment for recipe %s: %w", recipeID, err)
+	}
+	defer rows.Close()
+
+	var equipment []models.Equipment
+	for rows.Next() {
+		var eq models.Equipment
+		if err := rows.Scan(&eq.ID, &eq.Name, &eq.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan equipment for recipe %s: %w", recipeID, err)
+		}
+		equipment = append(equipment, eq)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error iterating equipment for recipe %s: %w", recipeID, rows.Err())
+	}
+	return equipment, nil
+}
@@ -0,0 +1,61 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ReorderIngredients sets the sort_order of a recipe's ingredients to their position in ingredientIDs,
+// in one transaction, and returns the reordered ingredients. ingredientIDs must list each of the
+// recipe's ingredients exactly once, otherwise ErrOrderMismatch is returned and nothing changes.
+func (s *DBRecipeStore) ReorderIngredients(ctx context.Context, recipeID uuid.UUID, ingredientIDs []uuid.UUID) ([]models.RecipeIngredient, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // Rollback if commit is not called
+
+	// Touching the recipe locks it against concurrent edits and marks it as changed for caching.
+	err = tx.QueryRow(ctx, "UPDATE recipes SET updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING id", recipeID).Scan(&recipeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("recipe with ID %s not found", recipeID)
+		}
+		return nil, fmt.Errorf("failed to lock recipe %s: %w", recipeID, err)
+	}
+
+	var current int
+	err = tx.QueryRow(ctx, "SELECT COUNT(*) FROM recipe_ingredients WHERE recipe_id = $1", recipeID).Scan(&current)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count ingredients of recipe %s: %w", recipeID, err)
+	}
+
+	cmdTag, err := tx.Exec(ctx, `
+		UPDATE recipe_ingredients ri
+		SET sort_order = o.position - 1
+		FROM unnest($2::uuid[]) WITH ORDINALITY AS o(ingredient_id, position)
+		WHERE ri.recipe_id = $1 AND ri.ingredient_id = o.ingredient_id;`, recipeID, ingredientIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reorder ingredients of recipe %s: %w", recipeID, err)
+	}
+	// Every listed ingredient must belong to the recipe, and every ingredient of the recipe must be listed.
+	if int(cmdTag.RowsAffected()) != len(ingredientIDs) || current != len(ingredientIDs) {
+		return nil, fmt.Errorf("%w: recipe %s has %d ingredients, %d of the %d listed matched",
+			ErrOrderMismatch, recipeID, current, cmdTag.RowsAffected(), len(ingredientIDs))
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	recipe, err := s.GetRecipeByID(ctx, recipeID)
+	if err != nil {
+		return nil, err
+	}
+	return recipe.Ingredients, nil
+}
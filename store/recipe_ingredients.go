@@ -0,0 +1,129 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// AddIngredient appends an ingredient to a recipe, after its current ingredients; ingReq.SortOrder is
+// ignored. The ingredient and unit are found or created as in CreateRecipe, and an unknown ingredient_id
+// yields ErrUnknownIngredient; one the recipe already has yields ErrConflict. It returns the added ingredient
+// as GetRecipeByID lists it.
+func (s *DBRecipeStore) AddIngredient(ctx context.Context, recipeID uuid.UUID, ingReq *models.RecipeIngredientRequest) (*models.RecipeIngredient, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // Rollback if commit is not called
+
+	// Touching the recipe locks it, so concurrent additions get distinct sort_orders.
+	err = tx.QueryRow(ctx, "UPDATE recipes SET updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING id", recipeID).Scan(&recipeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("recipe with ID %s not found", recipeID)
+		}
+		return nil, fmt.Errorf("failed to lock recipe %s: %w", recipeID, err)
+	}
+
+	ingredientID, err := resolveIngredientID(ctx, tx, *ingReq)
+	if err != nil {
+		return nil, fmt.Errorf("processing ingredient %s: %w", ingredientRef(*ingReq), err)
+	}
+	var unitIDPtr *uuid.UUID
+	if ingReq.UnitName != nil && *ingReq.UnitName != "" {
+		foundUnitID, err := findOrCreateMeasurementUnit(ctx, tx, *ingReq.UnitName)
+		if err != nil {
+			return nil, fmt.Errorf("processing measurement unit %s: %w", *ingReq.UnitName, err)
+		}
+		unitIDPtr = &foundUnitID
+	}
+
+	var linkID uuid.UUID
+	err = tx.QueryRow(ctx, `
+		INSERT INTO recipe_ingredients (recipe_id, ingredient_id, quantity, quantity_min, quantity_max, unit_id, notes, preparation, sort_order)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, COALESCE(MAX(sort_order) + 1, 0)
+		FROM recipe_ingredients WHERE recipe_id = $1
+		RETURNING id;`,
+		recipeID, ingredientID, ingReq.Quantity, ingReq.QuantityMin, ingReq.QuantityMax, unitIDPtr, ingReq.Notes, ingReq.Preparation).Scan(&linkID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("%w: recipe %s already has ingredient %s", ErrConflict, recipeID, ingredientRef(*ingReq))
+		}
+		return nil, fmt.Errorf("failed to insert recipe ingredient link for %s: %w", ingredientRef(*ingReq), err)
+	}
+	if err = refreshSearchVectors(ctx, tx, "r.id = $1", recipeID); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	recipe, err := s.GetRecipeByID(ctx, recipeID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range recipe.Ingredients {
+		if recipe.Ingredients[i].ID == linkID {
+			return &recipe.Ingredients[i], nil
+		}
+	}
+	return nil, fmt.Errorf("added ingredient %s not found in recipe %s", linkID, recipeID) // Removed concurrently
+}
+
+// RemoveIngredient removes an ingredient from a recipe, renumbers the sort_order of the remaining
+// ingredients from 0 without gaps, and returns them. The ingredient itself is not deleted.
+func (s *DBRecipeStore) RemoveIngredient(ctx context.Context, recipeID, ingredientID uuid.UUID) ([]models.RecipeIngredient, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // Rollback if commit is not called
+
+	// Touching the recipe locks it against concurrent edits and marks it as changed for caching.
+	err = tx.QueryRow(ctx, "UPDATE recipes SET updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING id", recipeID).Scan(&recipeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("recipe with ID %s not found", recipeID)
+		}
+		return nil, fmt.Errorf("failed to lock recipe %s: %w", recipeID, err)
+	}
+
+	cmdTag, err := tx.Exec(ctx, "DELETE FROM recipe_ingredients WHERE recipe_id = $1 AND ingredient_id = $2", recipeID, ingredientID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove ingredient %s from recipe %s: %w", ingredientID, recipeID, err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return nil, fmt.Errorf("ingredient %s not found in recipe %s", ingredientID, recipeID)
+	}
+
+	// Renumber in the order the ingredients are listed in (see GetRecipeByID), which also
+	// settles ties left by clients that sent every sort_order as 0.
+	_, err = tx.Exec(ctx, `
+		UPDATE recipe_ingredients ri
+		SET sort_order = o.position - 1
+		FROM (SELECT id, ROW_NUMBER() OVER (ORDER BY sort_order, id) AS position
+		      FROM recipe_ingredients WHERE recipe_id = $1) o
+		WHERE ri.id = o.id AND ri.sort_order <> o.position - 1;`, recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to renumber ingredients of recipe %s: %w", recipeID, err)
+	}
+	if err = refreshSearchVectors(ctx, tx, "r.id = $1", recipeID); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	recipe, err := s.GetRecipeByID(ctx, recipeID)
+	if err != nil {
+		return nil, err
+	}
+	return recipe.Ingredients, nil
+}
@@ -0,0 +1,35 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetRecipePhoto returns the stored photo filename of a recipe, or nil if it has no photo.
+func (s *DBRecipeStore) GetRecipePhoto(ctx context.Context, recipeID uuid.UUID) (*string, error) {
+	var filename *string
+	err := s.db.QueryRow(ctx, "SELECT photo_filename FROM recipes WHERE id = $1", recipeID).Scan(&filename)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("recipe with ID %s not found", recipeID)
+		}
+		return nil, fmt.Errorf("failed to get photo for recipe %s: %w", recipeID, err)
+	}
+	return filename, nil
+}
+
+// SetRecipePhoto records the photo filename of a recipe.
+func (s *DBRecipeStore) SetRecipePhoto(ctx context.Context, recipeID uuid.UUID, filename string) error {
+	cmdTag, err := s.db.Exec(ctx, "UPDATE recipes SET photo_filename = $2 WHERE id = $1", recipeID, filename)
+	if err != nil {
+		return fmt.Errorf("failed to set photo for recipe %s: %w", recipeID, err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return fmt.Errorf("recipe with ID %s not found", recipeID)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// prefixTSQuery builds a to_tsquery expression matching every word of query as a prefix,
+// e.g. "choco cake" -> "choco:* & cake:*". Only letters and digits are kept, so user input
+// cannot inject tsquery operators such as &, |, ! or parentheses. It returns "" if no terms remain.
+func prefixTSQuery(query string) string {
+	terms := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, term := range terms {
+		terms[i] = term + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+// searchResultColumns are the recipe columns scanSearchResults reads before the rank, for the recipe aliased r.
+const searchResultColumns = `r.id, r.title, r.description, r.photo_filename, r.serves, r.yield_quantity, r.yield_unit,
+		       r.prep_time_minutes, r.cook_time_minutes, r.total_time_minutes,
+		       r.created_at, r.updated_at, r.created_by, r.difficulty, r.slug, r.source_name, r.source_url`
+
+// SearchRecipes finds recipes whose title, description or ingredient names match the query, most relevant first.
+// search_vector weighs title matches above description matches above ingredient matches (see searchVectorSQL),
+// and ts_rank applies those weights. With params.Prefix every term matches as a word prefix; otherwise the
+// query is parsed by plainto_tsquery. When nothing matches and params.FuzzyThreshold is positive, recipes
+// whose titles are similar to the query are returned instead (see searchRecipesFuzzy).
+func (s *DBRecipeStore) SearchRecipes(ctx context.Context, params models.RecipeSearchParams) ([]*models.RecipeSearchResult, error) {
+	tsQuery, queryArg := "plainto_tsquery('english', $1)", params.Query
+	if params.Prefix {
+		tsQuery, queryArg = "to_tsquery('english', $1)", prefixTSQuery(params.Query)
+	}
+
+	results := []*models.RecipeSearchResult{}
+	if queryArg != "" {
+		// The search_vector GIN index serves the @@ match; ranking only runs over matching rows.
+		searchSQL := fmt.Sprintf(`
+		SELECT %s,
+		       ts_rank(r.search_vector, q.query) AS rank
+		FROM recipes r, %s AS q(query)
+		WHERE r.search_vector @@ q.query
+		ORDER BY rank DESC, r.updated_at DESC, r.id DESC
+		LIMIT $2;`, searchResultColumns, tsQuery)
+		rows, err := s.db.Query(ctx, searchSQL, queryArg, params.Limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search recipes: %w", err)
+		}
+		if results, err = scanSearchResults(rows); err != nil {
+			return nil, err
+		}
+	}
+	if len(results) > 0 || params.FuzzyThreshold <= 0 {
+		return results, nil
+	}
+	fuzzy, err := s.searchRecipesFuzzy(ctx, params)
+	if isUndefinedFunction(err) {
+		// pg_trgm is not installed; the fallback is a nicety, so the search still succeeds without it.
+		slog.Warn("skipping fuzzy recipe search, pg_trgm extension is missing", "error", err)
+		return results, nil
+	}
+	return fuzzy, err
+}
+
+// searchRecipesFuzzy finds recipes whose titles have a pg_trgm similarity of at least params.FuzzyThreshold
+// to the query, most similar first, so "chocolat cak" still finds "Chocolate Cake".
+func (s *DBRecipeStore) searchRecipesFuzzy(ctx context.Context, params models.RecipeSearchParams) ([]*models.RecipeSearchResult, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // Read-only; nothing to commit
+
+	// The % operator, which the title trigram index serves, compares against this setting; setting it
+	// locally keeps it from leaking to other users of the pooled connection.
+	_, err = tx.Exec(ctx, "SELECT set_config('pg_trgm.similarity_threshold', $1, true)", strconv.FormatFloat(params.FuzzyThreshold, 'f', -1, 64))
+	if err != nil {
+		return nil, fmt.Errorf("failed to set similarity threshold: %w", err)
+	}
+	searchSQL := fmt.Sprintf(`
+		SELECT %s,
+		       similarity(r.title, $1) AS rank
+		FROM recipes r
+		WHERE r.title %% $1
+		ORDER BY rank DESC, r.updated_at DESC, r.id DESC
+		LIMIT $2;`, searchResultColumns)
+	rows, err := tx.Query(ctx, searchSQL, params.Query, params.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search recipes by title similarity: %w", err)
+	}
+	results, err := scanSearchResults(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		result.Fuzzy = true
+	}
+	return results, nil
+}
+
+// scanSearchResults reads rows of searchResultColumns followed by a rank, and closes them.
+func scanSearchResults(rows pgx.Rows) ([]*models.RecipeSearchResult, error) {
+	defer rows.Close()
+
+	results := []*models.RecipeSearchResult{}
+	for rows.Next() {
+		result := &models.RecipeSearchResult{}
+		err := rows.Scan(
+			&result.ID, &result.Title, &result.Description, &result.PhotoFilename, &result.Serves, &result.YieldQuantity, &result.YieldUnit,
+			&result.PrepTimeMinutes, &result.CookTimeMinutes, &result.TotalTimeMinutes,
+			&result.CreatedAt, &result.UpdatedAt, &result.CreatedBy, &result.Difficulty, &result.Slug, &result.SourceName, &result.SourceURL,
+			&result.Rank,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recipe search result: %w", err)
+		}
+		result.FillTotalTime()
+		results = append(results, result)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error iterating recipe search results: %w", rows.Err())
+	}
+	return results, nil
+}
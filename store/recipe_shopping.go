@@ -0,0 +1,72 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/google/uuid"
+)
+
+// ShoppingListLines loads every ingredient line of the given recipes, with the unit conversion data
+// needed to consolidate them. It fails with a "not found" error if any recipe does not exist.
+func (s *DBRecipeStore) ShoppingListLines(ctx context.Context, recipeIDs []uuid.UUID) ([]models.ShoppingListLine, error) {
+	rows, err := s.db.Query(ctx, "SELECT id FROM recipes WHERE id = ANY($1)", recipeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up recipes: %w", err)
+	}
+	found := make(map[uuid.UUID]bool, len(recipeIDs))
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan recipe ID: %w", err)
+		}
+		found[id] = true
+	}
+	rows.Close()
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error iterating recipe IDs: %w", rows.Err())
+	}
+	for _, id := range recipeIDs {
+		if !found[id] {
+			return nil, fmt.Errorf("recipe with ID %s not found", id)
+		}
+	}
+
+	linesSQL := `
+		SELECT r.id, r.serves, i.id, i.name, i.category, COALESCE(ri.quantity, ri.quantity_max),
+		       mu.id, mu.name, mu.abbreviation, mu.system, mu.base_unit_id, mu.conversion_factor
+		FROM recipes r
+		JOIN recipe_ingredients ri ON ri.recipe_id = r.id
+		JOIN ingredients i ON i.id = ri.ingredient_id
+		LEFT JOIN measurement_units mu ON mu.id = ri.unit_id
+		WHERE r.id = ANY($1)
+		ORDER BY r.id, ri.sort_order, ri.id;`
+	rows, err = s.db.Query(ctx, linesSQL, recipeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shopping list lines: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []models.ShoppingListLine
+	for rows.Next() {
+		var line models.ShoppingListLine
+		var unit models.MeasurementUnit
+		err := rows.Scan(
+			&line.RecipeID, &line.RecipeServes, &line.IngredientID, &line.IngredientName, &line.IngredientCategory, &line.Quantity,
+			&unit.ID, &unit.Name, &unit.Abbreviation, &unit.System, &unit.BaseUnitID, &unit.ConversionFactor,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan shopping list line: %w", err)
+		}
+		if unit.ID != nil {
+			line.Unit = &unit
+		}
+		lines = append(lines, line)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error iterating shopping list lines: %w", rows.Err())
+	}
+	return lines, nil
+}
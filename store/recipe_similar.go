@@ -0,0 +1,72 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/google/uuid"
+)
+
+// SimilarRecipes returns up to limit recipes sharing tags or ingredients with the given recipe,
+// highest overlap first. The recipe itself is never included.
+func (s *DBRecipeStore) SimilarRecipes(ctx context.Context, recipeID uuid.UUID, limit int) ([]*models.SimilarRecipe, error) {
+	var exists bool
+	if err := s.db.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM recipes WHERE id = $1)", recipeID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to check recipe %s: %w", recipeID, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("recipe with ID %s not found", recipeID)
+	}
+
+	similarSQL := `
+		WITH shared_tags AS (
+			SELECT rt.recipe_id, COUNT(DISTINCT rt.tag_id) AS n
+			FROM recipe_tags rt
+			JOIN recipe_tags src ON src.tag_id = rt.tag_id AND src.recipe_id = $1
+			WHERE rt.recipe_id <> $1
+			GROUP BY rt.recipe_id
+		), shared_ingredients AS (
+			SELECT ri.recipe_id, COUNT(DISTINCT ri.ingredient_id) AS n
+			FROM recipe_ingredients ri
+			JOIN recipe_ingredients src ON src.ingredient_id = ri.ingredient_id AND src.recipe_id = $1
+			WHERE ri.recipe_id <> $1
+			GROUP BY ri.recipe_id
+		)
+		SELECT r.id, r.title, r.description, r.photo_filename, r.serves, r.yield_quantity, r.yield_unit,
+		       r.prep_time_minutes, r.cook_time_minutes, r.total_time_minutes,
+		       r.created_at, r.updated_at, r.created_by, r.difficulty, r.slug, r.source_name, r.source_url,
+		       COALESCE(st.n, 0) AS shared_tags, COALESCE(si.n, 0) AS shared_ingredients,
+		       COALESCE(st.n, 0) + COALESCE(si.n, 0) AS score
+		FROM recipes r
+		LEFT JOIN shared_tags st ON st.recipe_id = r.id
+		LEFT JOIN shared_ingredients si ON si.recipe_id = r.id
+		WHERE st.recipe_id IS NOT NULL OR si.recipe_id IS NOT NULL
+		ORDER BY score DESC, r.updated_at DESC, r.id DESC
+		LIMIT $2;`
+	rows, err := s.db.Query(ctx, similarSQL, recipeID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find recipes similar to %s: %w", recipeID, err)
+	}
+	defer rows.Close()
+
+	similar := []*models.SimilarRecipe{}
+	for rows.Next() {
+		sr := &models.SimilarRecipe{}
+		err := rows.Scan(
+			&sr.ID, &sr.Title, &sr.Description, &sr.PhotoFilename, &sr.Serves, &sr.YieldQuantity, &sr.YieldUnit,
+			&sr.PrepTimeMinutes, &sr.CookTimeMinutes, &sr.TotalTimeMinutes,
+			&sr.CreatedAt, &sr.UpdatedAt, &sr.CreatedBy, &sr.Difficulty, &sr.Slug, &sr.SourceName, &sr.SourceURL,
+			&sr.SharedTags, &sr.SharedIngredients, &sr.Score,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan similar recipe: %w", err)
+		}
+		sr.FillTotalTime()
+		similar = append(similar, sr)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error iterating similar recipes: %w", rows.Err())
+	}
+	return similar, nil
+}
@@ -0,0 +1,88 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxSlugBaseLength leaves room in the 255-character column for a "-<counter>" suffix.
+const maxSlugBaseLength = 240
+
+// slugify turns a title into a URL-safe slug: lowercase ASCII letters and digits separated by single hyphens.
+// Accents are stripped, e.g. "Crème Brûlée!" -> "creme-brulee". Titles with no usable characters become "recipe".
+func slugify(title string) string {
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range norm.NFD.String(strings.ToLower(title)) {
+		if unicode.Is(unicode.Mn, r) { // Combining accent split off by NFD
+			continue
+		}
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingHyphen = false
+			b.WriteRune(r)
+			if b.Len() >= maxSlugBaseLength {
+				break
+			}
+			continue
+		}
+		pendingHyphen = true
+	}
+	if b.Len() == 0 {
+		return "recipe"
+	}
+	return b.String()
+}
+
+// uniqueSlug derives a slug from title that no other recipe uses, appending "-2", "-3", ... on collision.
+// The recipe being updated (excludeID) may keep its own slug.
+func uniqueSlug(ctx context.Context, tx pgx.Tx, title string, excludeID uuid.UUID) (string, error) {
+	base := slugify(title)
+	// Slugs only contain [a-z0-9-], so base is safe to use in a LIKE pattern.
+	rows, err := tx.Query(ctx, "SELECT slug FROM recipes WHERE (slug = $1 OR slug LIKE $1 || '-%') AND id <> $2", base, excludeID)
+	if err != nil {
+		return "", fmt.Errorf("failed to check slug %s: %w", base, err)
+	}
+	defer rows.Close()
+
+	taken := map[string]bool{}
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return "", fmt.Errorf("failed to scan slug: %w", err)
+		}
+		taken[slug] = true
+	}
+	if rows.Err() != nil {
+		return "", fmt.Errorf("error iterating slugs: %w", rows.Err())
+	}
+
+	slug := base
+	for n := 2; taken[slug]; n++ {
+		slug = fmt.Sprintf("%s-%d", base, n)
+	}
+	return slug, nil
+}
+
+// GetRecipeBySlug retrieves a full recipe by its slug.
+func (s *DBRecipeStore) GetRecipeBySlug(ctx context.Context, slug string) (*models.Recipe, error) {
+	var id uuid.UUID
+	err := s.db.QueryRow(ctx, "SELECT id FROM recipes WHERE slug = $1", slug).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("recipe with slug %s not found", slug)
+		}
+		return nil, fmt.Errorf("failed to get recipe by slug %s: %w", slug, err)
+	}
+	return s.GetRecipeByID(ctx, id)
+}
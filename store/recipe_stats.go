@@ -0,0 +1,48 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/google/uuid"
+)
+
+// RecipeStats computes catalogue-wide totals and averages in a single round trip.
+// Averages are rounded to one decimal. The most common tag is chosen like the top of PopularTags:
+// archived tags are skipped and ties go to the alphabetically first name.
+func (s *DBRecipeStore) RecipeStats(ctx context.Context) (*models.RecipeStats, error) {
+	statsSQL := `
+		SELECT (SELECT COUNT(*) FROM recipes),
+		       (SELECT COUNT(DISTINCT ingredient_id) FROM recipe_ingredients),
+		       (SELECT COUNT(*) FROM tags),
+		       (SELECT ROUND(AVG(prep_time_minutes)::numeric, 1)::float8 FROM recipes),
+		       (SELECT ROUND(AVG(cook_time_minutes)::numeric, 1)::float8 FROM recipes),
+		       top.id, top.name, top.recipe_count
+		FROM (SELECT 1) AS one
+		LEFT JOIN LATERAL (
+			SELECT t.id, t.name, COUNT(*) AS recipe_count
+			FROM tags t
+			JOIN recipe_tags rt ON rt.tag_id = t.id
+			WHERE NOT t.archived
+			GROUP BY t.id, t.name
+			ORDER BY recipe_count DESC, t.name
+			LIMIT 1
+		) AS top ON true;`
+	stats := &models.RecipeStats{}
+	var tagID *uuid.UUID
+	var tagName *string
+	var tagCount *int
+	err := s.db.QueryRow(ctx, statsSQL).Scan(
+		&stats.TotalRecipes, &stats.TotalIngredients, &stats.TotalTags,
+		&stats.AveragePrepTimeMinutes, &stats.AverageCookTimeMinutes,
+		&tagID, &tagName, &tagCount,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute recipe stats: %w", err)
+	}
+	if tagID != nil {
+		stats.MostCommonTag = &models.TagCount{ID: *tagID, Name: *tagName, Count: *tagCount}
+	}
+	return stats, nil
+}
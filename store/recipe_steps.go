@@ -0,0 +1,31 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetStep retrieves a single step of a recipe by its step number.
+func (s *DBRecipeStore) GetStep(ctx context.Context, recipeID uuid.UUID, stepNumber int) (*models.RecipeStep, error) {
+	stepSQL := `
+		SELECT id, recipe_id, step_number, instruction, duration_minutes, duration_seconds, temperature, step_type, created_at
+		FROM recipe_steps
+		WHERE recipe_id = $1 AND step_number = $2;`
+	step := &models.RecipeStep{}
+	err := s.db.QueryRow(ctx, stepSQL, recipeID, stepNumber).Scan(
+		&step.ID, &step.RecipeID, &step.StepNumber, &step.Instruction, &step.DurationMinutes, &step.DurationSeconds, &step.Temperature, &step.StepType, &step.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("step %d not found for recipe %s", stepNumber, recipeID)
+		}
+		return nil, fmt.Errorf("failed to get step %d for recipe %s: %w", stepNumber, recipeID, err)
+	}
+	step.TotalDurationSeconds = models.TotalDurationSeconds(step.DurationMinutes, step.DurationSeconds)
+	return step, nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors" // Added for pgx.ErrNoRows check
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/gaanon/gorecipes_v2/models" // Adjust import path if needed
 	"github.com/google/uuid"
@@ -15,16 +17,37 @@ import (
 type RecipeStore interface {
 	CreateRecipe(ctx context.Context, recipeReq *models.RecipeRequest) (*models.Recipe, error)
 	GetRecipeByID(ctx context.Context, id uuid.UUID) (*models.Recipe, error)
-	ListRecipes(ctx context.Context) ([]*models.Recipe, error) // Simplified for now, add filters/pagination later
+	ListRecipes(ctx context.Context, params models.RecipeListParams) ([]*models.Recipe, error)
 	UpdateRecipe(ctx context.Context, id uuid.UUID, recipeReq *models.RecipeRequest) (*models.Recipe, error)
 	DeleteRecipe(ctx context.Context, id uuid.UUID) error
+	GetRecipeTranslation(ctx context.Context, recipeID uuid.UUID, lang string) (*models.RecipeTranslation, error)
+	UpsertRecipeTranslation(ctx context.Context, recipeID uuid.UUID, lang string, translationReq *models.RecipeTranslationRequest) (*models.RecipeTranslation, error)
+	GetStep(ctx context.Context, recipeID uuid.UUID, stepNumber int) (*models.RecipeStep, error)
+	GetRecipePhoto(ctx context.Context, recipeID uuid.UUID) (*string, error)
+	SetRecipePhoto(ctx context.Context, recipeID uuid.UUID, filename string) error
+	SimilarRecipes(ctx context.Context, recipeID uuid.UUID, limit int) ([]*models.SimilarRecipe, error)
+	GetRecipeBySlug(ctx context.Context, slug string) (*models.Recipe, error)
+	SearchRecipes(ctx context.Context, params models.RecipeSearchParams) ([]*models.RecipeSearchResult, error)
+	DeleteRecipes(ctx context.Context, ids []uuid.UUID) (int, error)
+	ExistingRecipeIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error)
+	ShoppingListLines(ctx context.Context, recipeIDs []uuid.UUID) ([]models.ShoppingListLine, error)
+	ReorderIngredients(ctx context.Context, recipeID uuid.UUID, ingredientIDs []uuid.UUID) ([]models.RecipeIngredient, error)
+	AddIngredient(ctx context.Context, recipeID uuid.UUID, ingReq *models.RecipeIngredientRequest) (*models.RecipeIngredient, error)
+	RemoveIngredient(ctx context.Context, recipeID, ingredientID uuid.UUID) ([]models.RecipeIngredient, error)
+	RecipeStats(ctx context.Context) (*models.RecipeStats, error)
+	GetRecipeTags(ctx context.Context, recipeID uuid.UUID) ([]models.Tag, error)
+	ListConversionUnits(ctx context.Context) ([]*models.MeasurementUnit, error)
+	IngredientPrices(ctx context.Context, recipeID uuid.UUID) ([]models.IngredientPrice, error)
 }
 
-// findOrCreateIngredient finds an ingredient by name or creates it if not found.
+// findOrCreateIngredient finds an ingredient by name, compared case-insensitively, or creates it if not found.
+// If a concurrent transaction creates the same name (in any case) first, the unique index on LOWER(name)
+// rejects the insert and that ingredient is reused instead.
 func findOrCreateIngredient(ctx context.Context, tx pgx.Tx, ingredientName string) (uuid.UUID, error) {
 	var ingredientID uuid.UUID
 	// Try to find existing ingredient
-	err := tx.QueryRow(ctx, "SELECT id FROM ingredients WHERE name = $1", ingredientName).Scan(&ingredientID)
+	findSQL := "SELECT id FROM ingredients WHERE LOWER(name) = LOWER($1)"
+	err := tx.QueryRow(ctx, findSQL, ingredientName).Scan(&ingredientID)
 	if err == nil {
 		return ingredientID, nil // Found
 	}
@@ -32,15 +55,55 @@ func findOrCreateIngredient(ctx context.Context, tx pgx.Tx, ingredientName strin
 		return uuid.Nil, fmt.Errorf("failed to query ingredient by name %s: %w", ingredientName, err)
 	}
 
-	// Not found, create new ingredient (DB defaults created_at)
-	ingredientID = uuid.New()
-	_, err = tx.Exec(ctx, "INSERT INTO ingredients (id, name) VALUES ($1, $2)", ingredientID, ingredientName)
+	// Not found, create new ingredient (DB defaults created_at).
+	// A savepoint keeps the transaction usable if the insert loses a race.
+	savepoint, err := tx.Begin(ctx)
 	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create savepoint: %w", err)
+	}
+	ingredientID = uuid.New()
+	_, err = savepoint.Exec(ctx, "INSERT INTO ingredients (id, name) VALUES ($1, $2)", ingredientID, ingredientName)
+	if err == nil {
+		if err = savepoint.Commit(ctx); err != nil {
+			return uuid.Nil, fmt.Errorf("failed to release savepoint: %w", err)
+		}
+		return ingredientID, nil
+	}
+	savepoint.Rollback(ctx)
+	if !isUniqueViolation(err) {
 		return uuid.Nil, fmt.Errorf("failed to create new ingredient %s: %w", ingredientName, err)
 	}
+	if err = tx.QueryRow(ctx, findSQL, ingredientName).Scan(&ingredientID); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to query ingredient by name %s after a concurrent create: %w", ingredientName, err)
+	}
 	return ingredientID, nil
 }
 
+// resolveIngredientID returns the ingredient ID for a recipe ingredient request.
+// An explicit IngredientID is checked for existence and used as-is; otherwise the name is found or created.
+func resolveIngredientID(ctx context.Context, tx pgx.Tx, ingReq models.RecipeIngredientRequest) (uuid.UUID, error) {
+	if ingReq.IngredientID == nil {
+		return findOrCreateIngredient(ctx, tx, ingReq.IngredientName)
+	}
+	var exists bool
+	err := tx.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM ingredients WHERE id = $1)", *ingReq.IngredientID).Scan(&exists)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to query ingredient by ID %s: %w", *ingReq.IngredientID, err)
+	}
+	if !exists {
+		return uuid.Nil, fmt.Errorf("%w: no ingredient with ID %s", ErrUnknownIngredient, *ingReq.IngredientID)
+	}
+	return *ingReq.IngredientID, nil
+}
+
+// ingredientRef describes a recipe ingredient request in error messages, by name or by ID.
+func ingredientRef(ingReq models.RecipeIngredientRequest) string {
+	if ingReq.IngredientID != nil {
+		return ingReq.IngredientID.String()
+	}
+	return ingReq.IngredientName
+}
+
 // findOrCreateMeasurementUnit finds a measurement unit by name or creates it if not found.
 // For newly created units, it uses 'other' as the default system and NULL for other optional fields.
 func findOrCreateMeasurementUnit(ctx context.Context, tx pgx.Tx, unitName string) (uuid.UUID, error) {
@@ -55,8 +118,8 @@ func findOrCreateMeasurementUnit(ctx context.Context, tx pgx.Tx, unitName string
 
 	// Not found, create new measurement unit
 	unitID = uuid.New()
-	// DB defaults created_at; system is required, others are optional. Defaulting to 'metric'.
-	_, err = tx.Exec(ctx, "INSERT INTO measurement_units (id, name, system) VALUES ($1, $2, $3)", unitID, unitName, "metric")
+	// DB defaults created_at; system is required, others are optional. The system of a free-text unit is unknown.
+	_, err = tx.Exec(ctx, "INSERT INTO measurement_units (id, name, system) VALUES ($1, $2, $3)", unitID, unitName, models.Other)
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("failed to create new measurement unit %s: %w", unitName, err)
 	}
@@ -83,6 +146,33 @@ func findOrCreateTag(ctx context.Context, tx pgx.Tx, tagName string) (uuid.UUID,
 	return tagID, nil
 }
 
+// uniqueTagRequests removes repeated tag names from a request, comparing case-insensitively.
+// The first spelling of each name wins, so ["quick", "Quick"] yields ["quick"].
+func uniqueTagRequests(tags []models.RecipeTagRequest) []models.RecipeTagRequest {
+	seen := make(map[string]bool, len(tags))
+	unique := make([]models.RecipeTagRequest, 0, len(tags))
+	for _, tag := range tags {
+		key := strings.ToLower(tag.Name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, tag)
+	}
+	return unique
+}
+
+// tagIndex returns the position of the first tag in tags named name, compared case-insensitively
+// like uniqueTagRequests, so errors point at the spelling that was actually used.
+func tagIndex(tags []models.RecipeTagRequest, name string) int {
+	for i, tag := range tags {
+		if strings.EqualFold(tag.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
 // DBRecipeStore implements the RecipeStore interface using a pgxpool.Pool.
 type DBRecipeStore struct {
 	db *pgxpool.Pool
@@ -104,9 +194,13 @@ func (s *DBRecipeStore) CreateRecipe(ctx context.Context, recipeReq *models.Reci
 	defer tx.Rollback(ctx) // Rollback if commit is not called
 
 	newRecipeID := uuid.New()
+	slug, err := uniqueSlug(ctx, tx, recipeReq.Title, newRecipeID)
+	if err != nil {
+		return nil, err
+	}
 	recipeSQL := `
-		INSERT INTO recipes (id, title, description, photo_filename, serves, prep_time_minutes, cook_time_minutes, created_by)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO recipes (id, title, description, photo_filename, serves, prep_time_minutes, cook_time_minutes, created_by, difficulty, slug, yield_quantity, yield_unit, source_name, source_url, notes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		RETURNING id;`
 	var createdRecipeID uuid.UUID
 	err = tx.QueryRow(ctx, recipeSQL,
@@ -118,62 +212,83 @@ func (s *DBRecipeStore) CreateRecipe(ctx context.Context, recipeReq *models.Reci
 		recipeReq.PrepTimeMinutes,
 		recipeReq.CookTimeMinutes,
 		recipeReq.CreatedBy,
+		recipeReq.Difficulty,
+		slug,
+		recipeReq.YieldQuantity,
+		recipeReq.YieldUnit,
+		recipeReq.SourceName,
+		recipeReq.SourceURL,
+		recipeReq.Notes,
 	).Scan(&createdRecipeID)
 	if err != nil {
+		if isUniqueViolation(err) { // Lost a race with a concurrent recipe of the same title
+			return nil, fmt.Errorf("%w: slug %q was taken concurrently, please retry", ErrConflict, slug)
+		}
 		return nil, fmt.Errorf("failed to insert recipe: %w", err)
 	}
 
 	// Insert ingredients with find-or-create logic
-	for _, ingReq := range recipeReq.Ingredients { // ingReq is models.RecipeIngredientRequest
-		ingredientID, err := findOrCreateIngredient(ctx, tx, ingReq.IngredientName)
+	for i, ingReq := range recipeReq.Ingredients { // ingReq is models.RecipeIngredientRequest
+		ingredientID, err := resolveIngredientID(ctx, tx, ingReq)
 		if err != nil {
-			return nil, fmt.Errorf("processing ingredient %s: %w", ingReq.IngredientName, err)
+			return nil, relationError("ingredients", i, ingredientRef(ingReq), fmt.Errorf("processing ingredient %s: %w", ingredientRef(ingReq), err))
 		}
 
 		var unitIDPtr *uuid.UUID // Use a pointer to handle potential NULL unit_id
 		if ingReq.UnitName != nil && *ingReq.UnitName != "" {
 			foundUnitID, err := findOrCreateMeasurementUnit(ctx, tx, *ingReq.UnitName)
 			if err != nil {
-				return nil, fmt.Errorf("processing measurement unit %s: %w", *ingReq.UnitName, err)
+				return nil, relationError("ingredients", i, ingredientRef(ingReq), fmt.Errorf("processing measurement unit %s: %w", *ingReq.UnitName, err))
 			}
 			unitIDPtr = &foundUnitID
 		}
 
 		_, err = tx.Exec(ctx, `
-			INSERT INTO recipe_ingredients (recipe_id, ingredient_id, quantity, unit_id, notes, sort_order)
-			VALUES ($1, $2, $3, $4, $5, $6);`,
-			createdRecipeID, ingredientID, ingReq.Quantity, unitIDPtr, ingReq.Notes, ingReq.SortOrder)
+			INSERT INTO recipe_ingredients (recipe_id, ingredient_id, quantity, quantity_min, quantity_max, unit_id, notes, preparation, sort_order)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);`,
+			createdRecipeID, ingredientID, ingReq.Quantity, ingReq.QuantityMin, ingReq.QuantityMax, unitIDPtr, ingReq.Notes, ingReq.Preparation, ingReq.SortOrder)
 		if err != nil {
-			return nil, fmt.Errorf("failed to insert recipe ingredient link for %s: %w", ingReq.IngredientName, err)
+			if isUniqueViolation(err) { // Names are matched case-insensitively, so "Salt" and "salt" are one ingredient
+				return nil, relationError("ingredients", i, ingredientRef(ingReq), fmt.Errorf("%w: ingredient %s is listed more than once", ErrConflict, ingredientRef(ingReq)))
+			}
+			return nil, relationError("ingredients", i, ingredientRef(ingReq), fmt.Errorf("failed to insert recipe ingredient link for %s: %w", ingredientRef(ingReq), err))
 		}
 	}
 
 	// Insert steps
-	for _, stepReq := range recipeReq.Steps {
+	for i, stepReq := range recipeReq.Steps {
 		_, err = tx.Exec(ctx, `
-			INSERT INTO recipe_steps (recipe_id, step_number, instruction, duration_minutes, temperature)
-			VALUES ($1, $2, $3, $4, $5);`,
-			createdRecipeID, stepReq.StepNumber, stepReq.Instruction, stepReq.DurationMinutes, stepReq.Temperature)
+			INSERT INTO recipe_steps (recipe_id, step_number, instruction, duration_minutes, duration_seconds, temperature, step_type)
+			VALUES ($1, $2, $3, $4, $5, $6, COALESCE($7::step_type, 'normal'));`,
+			createdRecipeID, stepReq.StepNumber, stepReq.Instruction, stepReq.DurationMinutes, stepReq.DurationSeconds, stepReq.Temperature, stepReq.StepType)
 		if err != nil {
-			return nil, fmt.Errorf("failed to insert recipe step %d: %w", stepReq.StepNumber, err)
+			return nil, relationError("steps", i, strconv.Itoa(stepReq.StepNumber), fmt.Errorf("failed to insert recipe step %d: %w", stepReq.StepNumber, err))
 		}
 	}
 
 	// Insert tags with find-or-create logic
-	for _, tagReq := range recipeReq.Tags { // tagReq is models.RecipeTagRequest
+	for _, tagReq := range uniqueTagRequests(recipeReq.Tags) { // tagReq is models.RecipeTagRequest
 		tagID, err := findOrCreateTag(ctx, tx, tagReq.Name)
 		if err != nil {
-			return nil, fmt.Errorf("processing tag %s: %w", tagReq.Name, err)
+			return nil, relationError("tags", tagIndex(recipeReq.Tags, tagReq.Name), tagReq.Name, fmt.Errorf("processing tag %s: %w", tagReq.Name, err))
 		}
 		_, err = tx.Exec(ctx, `
 			INSERT INTO recipe_tags (recipe_id, tag_id)
-			VALUES ($1, $2);`,
+			VALUES ($1, $2)
+			ON CONFLICT DO NOTHING;`,
 			createdRecipeID, tagID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to insert recipe tag link for %s: %w", tagReq.Name, err)
+			return nil, relationError("tags", tagIndex(recipeReq.Tags, tagReq.Name), tagReq.Name, fmt.Errorf("failed to insert recipe tag link for %s: %w", tagReq.Name, err))
 		}
 	}
 
+	if err = insertRecipeEquipment(ctx, tx, createdRecipeID, recipeReq.Equipment); err != nil {
+		return nil, err
+	}
+	if err = refreshSearchVectors(ctx, tx, "r.id = $1", createdRecipeID); err != nil {
+		return nil, err
+	}
+
 	if err = tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
@@ -188,15 +303,15 @@ func (s *DBRecipeStore) GetRecipeByID(ctx context.Context, id uuid.UUID) (*model
 
 	// 1. Get main recipe details
 	recipeSQL := `
-		SELECT r.id, r.title, r.description, r.photo_filename, r.serves, 
+		SELECT r.id, r.title, r.description, r.photo_filename, r.serves, r.yield_quantity, r.yield_unit, 
 		       r.prep_time_minutes, r.cook_time_minutes, r.total_time_minutes, 
-		       r.created_at, r.updated_at, r.created_by
+		       r.created_at, r.updated_at, r.created_by, r.difficulty, r.slug, r.source_name, r.source_url, r.notes
 		FROM recipes r
 		WHERE r.id = $1;`
 	err := s.db.QueryRow(ctx, recipeSQL, id).Scan(
-		&recipe.ID, &recipe.Title, &recipe.Description, &recipe.PhotoFilename, &recipe.Serves,
+		&recipe.ID, &recipe.Title, &recipe.Description, &recipe.PhotoFilename, &recipe.Serves, &recipe.YieldQuantity, &recipe.YieldUnit,
 		&recipe.PrepTimeMinutes, &recipe.CookTimeMinutes, &recipe.TotalTimeMinutes,
-		&recipe.CreatedAt, &recipe.UpdatedAt, &recipe.CreatedBy,
+		&recipe.CreatedAt, &recipe.UpdatedAt, &recipe.CreatedBy, &recipe.Difficulty, &recipe.Slug, &recipe.SourceName, &recipe.SourceURL, &recipe.Notes,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -204,15 +319,19 @@ func (s *DBRecipeStore) GetRecipeByID(ctx context.Context, id uuid.UUID) (*model
 		}
 		return nil, fmt.Errorf("failed to get recipe %s: %w", id, err)
 	}
+	recipe.FillTotalTime()
 
 	// 2. Get recipe ingredients
 	ingredientsSQL := `
 		SELECT 
 			ri.ingredient_id, 
 			i.name AS ingredient_name, 
-			i.category AS ingredient_description, 
+			i.category AS ingredient_category,
 			ri.quantity, 
+			ri.quantity_min,
+			ri.quantity_max,
 			ri.notes, 
+			ri.preparation,
 			ri.sort_order,
 			mu.id AS unit_id,             -- This will be scanned into tempUnit.ID (*uuid.UUID)
 			mu.name AS unit_name,           -- This will be scanned into tempUnit.Name (string)
@@ -222,7 +341,7 @@ func (s *DBRecipeStore) GetRecipeByID(ctx context.Context, id uuid.UUID) (*model
 		JOIN ingredients i ON ri.ingredient_id = i.id
 		LEFT JOIN measurement_units mu ON ri.unit_id = mu.id
 		WHERE ri.recipe_id = $1
-		ORDER BY ri.sort_order;`
+		ORDER BY ri.sort_order, ri.id; -- id breaks ties when clients leave sort_order at 0`
 	rows, err := s.db.Query(ctx, ingredientsSQL, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get ingredients for recipe %s: %w", id, err)
@@ -235,9 +354,12 @@ func (s *DBRecipeStore) GetRecipeByID(ctx context.Context, id uuid.UUID) (*model
 		err := rows.Scan(
 			&ing.IngredientID,
 			&ing.IngredientName,        // Scans i.name
-			&ing.IngredientDescription, // Scans i.description
+			&ing.IngredientCategory,    // Scans i.category
 			&ing.Quantity,
+			&ing.QuantityMin,
+			&ing.QuantityMax,
 			&ing.Notes,
+			&ing.Preparation,
 			&ing.SortOrder,
 			&tempUnit.ID,               // Scans mu.id (which can be NULL)
 			&tempUnit.Name,             // Scans mu.name
@@ -260,10 +382,11 @@ func (s *DBRecipeStore) GetRecipeByID(ctx context.Context, id uuid.UUID) (*model
 	if rows.Err() != nil {
 		return nil, fmt.Errorf("error iterating ingredients for recipe %s: %w", id, rows.Err())
 	}
+	recipe.IngredientCount = len(recipe.Ingredients)
 
 	// 3. Get recipe steps
 	stepsSQL := `
-		SELECT step_number, instruction, duration_minutes, temperature
+		SELECT step_number, instruction, duration_minutes, duration_seconds, temperature, step_type
 		FROM recipe_steps
 		WHERE recipe_id = $1
 		ORDER BY step_number;`
@@ -275,10 +398,11 @@ func (s *DBRecipeStore) GetRecipeByID(ctx context.Context, id uuid.UUID) (*model
 
 	for rows.Next() {
 		var step models.RecipeStep
-		err := rows.Scan(&step.StepNumber, &step.Instruction, &step.DurationMinutes, &step.Temperature)
+		err := rows.Scan(&step.StepNumber, &step.Instruction, &step.DurationMinutes, &step.DurationSeconds, &step.Temperature, &step.StepType)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan step for recipe %s: %w", id, err)
 		}
+		step.TotalDurationSeconds = models.TotalDurationSeconds(step.DurationMinutes, step.DurationSeconds)
 		recipe.Steps = append(recipe.Steps, step)
 	}
 	if rows.Err() != nil {
@@ -286,44 +410,164 @@ func (s *DBRecipeStore) GetRecipeByID(ctx context.Context, id uuid.UUID) (*model
 	}
 
 	// 4. Get recipe tags
-	tagsSQL := `
-		SELECT t.id, t.name
-		FROM recipe_tags rt
-		JOIN tags t ON rt.tag_id = t.id
-		WHERE rt.recipe_id = $1
-		ORDER BY t.name;`
-	rows, err = s.db.Query(ctx, tagsSQL, id)
+	recipe.Tags, err = s.getRecipeTags(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tags for recipe %s: %w", id, err)
+		return nil, err
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var tag models.Tag
-		err := rows.Scan(&tag.ID, &tag.Name)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan tag for recipe %s: %w", id, err)
-		}
-		recipe.Tags = append(recipe.Tags, tag)
-	}
-	if rows.Err() != nil {
-		return nil, fmt.Errorf("error iterating tags for recipe %s: %w", id, rows.Err())
+	// 5. Get recipe equipment
+	recipe.Equipment, err = s.getRecipeEquipment(ctx, id)
+	if err != nil {
+		return nil, err
 	}
 
 	return recipe, nil
 }
 
-// ListRecipes retrieves a list of all recipes with their basic details.
-// TODO: Implement pagination and filtering.
-func (s *DBRecipeStore) ListRecipes(ctx context.Context) ([]*models.Recipe, error) {
-	listSQL := `
-		SELECT id, title, description, photo_filename, serves, 
-		       prep_time_minutes, cook_time_minutes, total_time_minutes, 
-		       created_at, updated_at, created_by
-		FROM recipes
-		ORDER BY updated_at DESC; -- Or by title, created_at, etc.
-	`
-	rows, err := s.db.Query(ctx, listSQL)
+// escapeLikePattern escapes the LIKE wildcards % and _ (and the escape character \) in s, so user input
+// is matched literally.
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// recipeSortColumns maps each of models.RecipeSortFields to the column it orders by.
+var recipeSortColumns = map[string]string{
+	"title":              "r.title",
+	"created_at":         "r.created_at",
+	"updated_at":         "r.updated_at",
+	"prep_time_minutes":  "r.prep_time_minutes",
+	"cook_time_minutes":  "r.cook_time_minutes",
+	"total_time_minutes": "r.total_time_minutes",
+}
+
+// ListRecipes retrieves a page of recipes with their basic details, ordered by params.Sort
+// (updated_at DESC, id DESC by default). It supports both offset pagination (Limit/Offset) and keyset
+// pagination (Limit/After); the cursor holds an updated_at, so keyset pagination needs an updated_at sort.
+func (s *DBRecipeStore) ListRecipes(ctx context.Context, params models.RecipeListParams) ([]*models.Recipe, error) {
+	var conditions []string
+	var args []interface{}
+	sort := params.Sort
+	if sort.Field == "" {
+		sort = models.DefaultRecipeSort
+	}
+	// Sync clients walk changes oldest first, whatever the sort.
+	if params.UpdatedSince != nil {
+		sort = models.RecipeSort{Field: "updated_at"}
+		args = append(args, *params.UpdatedSince)
+		conditions = append(conditions, fmt.Sprintf("r.updated_at >= $%d", len(args)))
+	}
+	sortColumn, ok := recipeSortColumns[sort.Field]
+	if !ok {
+		return nil, fmt.Errorf("unknown sort field %q", sort.Field)
+	}
+	direction, afterOp := "ASC", ">"
+	if sort.Desc {
+		direction, afterOp = "DESC", "<"
+	}
+	if params.After != nil {
+		if sort.Field != "updated_at" {
+			return nil, fmt.Errorf("keyset pagination requires sorting by updated_at, not %s", sort.Field)
+		}
+		// Row comparison matches the ORDER BY, so the id breaks ties between equal timestamps.
+		args = append(args, params.After.UpdatedAt, params.After.ID)
+		conditions = append(conditions, fmt.Sprintf("(r.updated_at, r.id) %s ($%d, $%d)", afterOp, len(args)-1, len(args)))
+	}
+	if params.Difficulty != nil {
+		args = append(args, *params.Difficulty)
+		conditions = append(conditions, fmt.Sprintf("r.difficulty = $%d", len(args)))
+	}
+	// Comparisons against NULL are never true, so recipes without a serving size drop out of these filters.
+	if params.MinServes != nil {
+		args = append(args, *params.MinServes)
+		conditions = append(conditions, fmt.Sprintf("r.serves >= $%d", len(args)))
+	}
+	if params.MaxServes != nil {
+		args = append(args, *params.MaxServes)
+		conditions = append(conditions, fmt.Sprintf("r.serves <= $%d", len(args)))
+	}
+	if params.Equipment != "" {
+		args = append(args, params.Equipment)
+		conditions = append(conditions, fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM recipe_equipment re JOIN equipment e ON e.id = re.equipment_id
+			WHERE re.recipe_id = r.id AND LOWER(e.name) = LOWER($%d))`, len(args)))
+	}
+	if params.IngredientContains != "" {
+		// EXISTS rather than a join, so a recipe with several matching ingredients is listed once.
+		args = append(args, "%"+escapeLikePattern(params.IngredientContains)+"%")
+		conditions = append(conditions, fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM recipe_ingredients rim JOIN ingredients i ON i.id = rim.ingredient_id
+			WHERE rim.recipe_id = r.id AND i.name ILIKE $%d)`, len(args)))
+	}
+	if len(params.ExcludeTags) > 0 {
+		lowered := make([]string, len(params.ExcludeTags))
+		for i, name := range params.ExcludeTags {
+			lowered[i] = strings.ToLower(name)
+		}
+		args = append(args, lowered)
+		conditions = append(conditions, fmt.Sprintf(`NOT EXISTS (
+			SELECT 1 FROM recipe_tags rt JOIN tags t ON t.id = rt.tag_id
+			WHERE rt.recipe_id = r.id AND LOWER(t.name) = ANY($%d))`, len(args)))
+	}
+	if params.Untagged {
+		conditions = append(conditions, "NOT EXISTS (SELECT 1 FROM recipe_tags rt WHERE rt.recipe_id = r.id)")
+	}
+	if params.InSeasonMonth != 0 {
+		// Ingredients without seasons are available year-round.
+		args = append(args, params.InSeasonMonth)
+		conditions = append(conditions, fmt.Sprintf(`NOT EXISTS (
+			SELECT 1 FROM recipe_ingredients ri JOIN ingredients i ON i.id = ri.ingredient_id
+			WHERE ri.recipe_id = r.id AND cardinality(i.seasons) > 0 AND NOT ($%d = ANY(i.seasons)))`, len(args)))
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// Translated title/description replace the base ones when available for the requested language.
+	titleCol, descriptionCol, langCol := "r.title", "r.description", "NULL::text"
+	translationJoin, groupBy := "", "r.id"
+	if params.Lang != "" {
+		args = append(args, params.Lang)
+		translationJoin = fmt.Sprintf("LEFT JOIN recipe_translations tr ON tr.recipe_id = r.id AND tr.lang = $%d", len(args))
+		titleCol, descriptionCol, langCol = "COALESCE(tr.title, r.title)", "COALESCE(tr.description, r.description)", "tr.lang"
+		groupBy = "r.id, tr.recipe_id, tr.lang"
+	}
+
+	// Titles are ordered as displayed, so translated titles sort in the requested language.
+	if sort.Field == "title" {
+		sortColumn = titleCol
+	}
+	orderBy := fmt.Sprintf("%s %s, r.id %s", sortColumn, direction, direction)
+	if strings.HasSuffix(sort.Field, "_minutes") {
+		// Recipes without a time sort last in either direction.
+		orderBy = fmt.Sprintf("%s %s NULLS LAST, r.id %s", sortColumn, direction, direction)
+	}
+
+	paginationClause := ""
+	if params.Limit > 0 {
+		args = append(args, params.Limit)
+		paginationClause = fmt.Sprintf("LIMIT $%d", len(args))
+	}
+	if params.After == nil && params.Offset > 0 {
+		args = append(args, params.Offset)
+		paginationClause += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	// Ingredient counts are aggregated in the same query to avoid an N+1 lookup per recipe.
+	listSQL := fmt.Sprintf(`
+		SELECT r.id, %s, %s, r.photo_filename, r.serves, r.yield_quantity, r.yield_unit, 
+		       r.prep_time_minutes, r.cook_time_minutes, r.total_time_minutes, 
+		       r.created_at, r.updated_at, r.created_by, r.difficulty, r.slug, r.source_name, r.source_url,
+		       COUNT(ri.id) AS ingredient_count, %s
+		FROM recipes r
+		LEFT JOIN recipe_ingredients ri ON ri.recipe_id = r.id
+		%s
+		%s
+		GROUP BY %s
+		ORDER BY %s
+		%s;`, titleCol, descriptionCol, langCol, translationJoin, whereClause, groupBy, orderBy, paginationClause)
+	rows, err := s.db.Query(ctx, listSQL, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list recipes: %w", err)
 	}
@@ -333,13 +577,15 @@ func (s *DBRecipeStore) ListRecipes(ctx context.Context) ([]*models.Recipe, erro
 	for rows.Next() {
 		recipe := &models.Recipe{}
 		err := rows.Scan(
-			&recipe.ID, &recipe.Title, &recipe.Description, &recipe.PhotoFilename, &recipe.Serves,
+			&recipe.ID, &recipe.Title, &recipe.Description, &recipe.PhotoFilename, &recipe.Serves, &recipe.YieldQuantity, &recipe.YieldUnit,
 			&recipe.PrepTimeMinutes, &recipe.CookTimeMinutes, &recipe.TotalTimeMinutes,
-			&recipe.CreatedAt, &recipe.UpdatedAt, &recipe.CreatedBy,
+			&recipe.CreatedAt, &recipe.UpdatedAt, &recipe.CreatedBy, &recipe.Difficulty, &recipe.Slug, &recipe.SourceName, &recipe.SourceURL,
+			&recipe.IngredientCount, &recipe.Lang,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan recipe during list: %w", err)
 		}
+		recipe.FillTotalTime()
 		recipes = append(recipes, recipe)
 	}
 	if rows.Err() != nil {
@@ -360,10 +606,15 @@ func (s *DBRecipeStore) UpdateRecipe(ctx context.Context, id uuid.UUID, recipeRe
 	defer tx.Rollback(ctx)
 
 	// 1. Update the main recipe details in 'recipes' table
+	slug, err := uniqueSlug(ctx, tx, recipeReq.Title, id)
+	if err != nil {
+		return nil, err
+	}
 	updateRecipeSQL := `
 		UPDATE recipes
 		SET title = $2, description = $3, photo_filename = $4, serves = $5, 
-		    prep_time_minutes = $6, cook_time_minutes = $7, created_by = $8, updated_at = CURRENT_TIMESTAMP
+		    prep_time_minutes = $6, cook_time_minutes = $7, created_by = $8, difficulty = $9, slug = $10,
+		    yield_quantity = $11, yield_unit = $12, source_name = $13, source_url = $14, notes = $15, updated_at = CURRENT_TIMESTAMP
 		WHERE id = $1
 		RETURNING id; -- Check if the recipe existed
 	`
@@ -377,11 +628,21 @@ func (s *DBRecipeStore) UpdateRecipe(ctx context.Context, id uuid.UUID, recipeRe
 		recipeReq.PrepTimeMinutes,
 		recipeReq.CookTimeMinutes,
 		recipeReq.CreatedBy,
+		recipeReq.Difficulty,
+		slug,
+		recipeReq.YieldQuantity,
+		recipeReq.YieldUnit,
+		recipeReq.SourceName,
+		recipeReq.SourceURL,
+		recipeReq.Notes,
 	).Scan(&updatedRecipeID)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, fmt.Errorf("recipe with ID %s not found for update", id)
 		}
+		if isUniqueViolation(err) { // Lost a race with a concurrent recipe of the same title
+			return nil, fmt.Errorf("%w: slug %q was taken concurrently, please retry", ErrConflict, slug)
+		}
 		return nil, fmt.Errorf("failed to update recipe %s: %w", id, err)
 	}
 
@@ -394,6 +655,7 @@ func (s *DBRecipeStore) UpdateRecipe(ctx context.Context, id uuid.UUID, recipeRe
 		`DELETE FROM recipe_ingredients WHERE recipe_id = $1;`,
 		`DELETE FROM recipe_steps WHERE recipe_id = $1;`,
 		`DELETE FROM recipe_tags WHERE recipe_id = $1;`,
+		`DELETE FROM recipe_equipment WHERE recipe_id = $1;`,
 	}
 
 	for _, sql := range deleteRelationsSQL {
@@ -405,56 +667,67 @@ func (s *DBRecipeStore) UpdateRecipe(ctx context.Context, id uuid.UUID, recipeRe
 
 	// 3. Insert new associated data (ingredients, steps, tags) - similar to CreateRecipe
 	// Insert ingredients with find-or-create logic
-	for _, ingReq := range recipeReq.Ingredients { // ingReq is models.RecipeIngredientRequest
-		ingredientID, err := findOrCreateIngredient(ctx, tx, ingReq.IngredientName)
+	for i, ingReq := range recipeReq.Ingredients { // ingReq is models.RecipeIngredientRequest
+		ingredientID, err := resolveIngredientID(ctx, tx, ingReq)
 		if err != nil {
-			return nil, fmt.Errorf("processing ingredient %s for update: %w", ingReq.IngredientName, err)
+			return nil, relationError("ingredients", i, ingredientRef(ingReq), fmt.Errorf("processing ingredient %s for update: %w", ingredientRef(ingReq), err))
 		}
 
 		var unitIDPtr *uuid.UUID
 		if ingReq.UnitName != nil && *ingReq.UnitName != "" {
 			foundUnitID, err := findOrCreateMeasurementUnit(ctx, tx, *ingReq.UnitName)
 			if err != nil {
-				return nil, fmt.Errorf("processing measurement unit %s for update: %w", *ingReq.UnitName, err)
+				return nil, relationError("ingredients", i, ingredientRef(ingReq), fmt.Errorf("processing measurement unit %s for update: %w", *ingReq.UnitName, err))
 			}
 			unitIDPtr = &foundUnitID
 		}
 
 		_, err = tx.Exec(ctx, `
-			INSERT INTO recipe_ingredients (recipe_id, ingredient_id, quantity, unit_id, notes, sort_order)
-			VALUES ($1, $2, $3, $4, $5, $6);`,
-			id, ingredientID, ingReq.Quantity, unitIDPtr, ingReq.Notes, ingReq.SortOrder)
+			INSERT INTO recipe_ingredients (recipe_id, ingredient_id, quantity, quantity_min, quantity_max, unit_id, notes, preparation, sort_order)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);`,
+			id, ingredientID, ingReq.Quantity, ingReq.QuantityMin, ingReq.QuantityMax, unitIDPtr, ingReq.Notes, ingReq.Preparation, ingReq.SortOrder)
 		if err != nil {
-			return nil, fmt.Errorf("failed to insert updated recipe ingredient link for %s: %w", ingReq.IngredientName, err)
+			if isUniqueViolation(err) { // Names are matched case-insensitively, so "Salt" and "salt" are one ingredient
+				return nil, relationError("ingredients", i, ingredientRef(ingReq), fmt.Errorf("%w: ingredient %s is listed more than once", ErrConflict, ingredientRef(ingReq)))
+			}
+			return nil, relationError("ingredients", i, ingredientRef(ingReq), fmt.Errorf("failed to insert updated recipe ingredient link for %s: %w", ingredientRef(ingReq), err))
 		}
 	}
 
 	// Insert steps
-	for _, stepReq := range recipeReq.Steps {
+	for i, stepReq := range recipeReq.Steps {
 		_, err = tx.Exec(ctx, `
-			INSERT INTO recipe_steps (recipe_id, step_number, instruction, duration_minutes, temperature)
-			VALUES ($1, $2, $3, $4, $5);`,
-			id, stepReq.StepNumber, stepReq.Instruction, stepReq.DurationMinutes, stepReq.Temperature)
+			INSERT INTO recipe_steps (recipe_id, step_number, instruction, duration_minutes, duration_seconds, temperature, step_type)
+			VALUES ($1, $2, $3, $4, $5, $6, COALESCE($7::step_type, 'normal'));`,
+			id, stepReq.StepNumber, stepReq.Instruction, stepReq.DurationMinutes, stepReq.DurationSeconds, stepReq.Temperature, stepReq.StepType)
 		if err != nil {
-			return nil, fmt.Errorf("failed to insert updated recipe step %d: %w", stepReq.StepNumber, err)
+			return nil, relationError("steps", i, strconv.Itoa(stepReq.StepNumber), fmt.Errorf("failed to insert updated recipe step %d: %w", stepReq.StepNumber, err))
 		}
 	}
 
 	// Insert tags with find-or-create logic
-	for _, tagReq := range recipeReq.Tags { // tagReq is models.RecipeTagRequest
+	for _, tagReq := range uniqueTagRequests(recipeReq.Tags) { // tagReq is models.RecipeTagRequest
 		tagID, err := findOrCreateTag(ctx, tx, tagReq.Name)
 		if err != nil {
-			return nil, fmt.Errorf("processing tag %s for update: %w", tagReq.Name, err)
+			return nil, relationError("tags", tagIndex(recipeReq.Tags, tagReq.Name), tagReq.Name, fmt.Errorf("processing tag %s for update: %w", tagReq.Name, err))
 		}
 		_, err = tx.Exec(ctx, `
 			INSERT INTO recipe_tags (recipe_id, tag_id)
-			VALUES ($1, $2);`,
+			VALUES ($1, $2)
+			ON CONFLICT DO NOTHING;`,
 			id, tagID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to insert updated recipe tag link for %s: %w", tagReq.Name, err)
+			return nil, relationError("tags", tagIndex(recipeReq.Tags, tagReq.Name), tagReq.Name, fmt.Errorf("failed to insert updated recipe tag link for %s: %w", tagReq.Name, err))
 		}
 	}
 
+	if err = insertRecipeEquipment(ctx, tx, id, recipeReq.Equipment); err != nil {
+		return nil, err
+	}
+	if err = refreshSearchVectors(ctx, tx, "r.id = $1", id); err != nil {
+		return nil, err
+	}
+
 	if err = tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("failed to commit update transaction for recipe %s: %w", id, err)
 	}
@@ -480,4 +753,36 @@ func (s *DBRecipeStore) DeleteRecipe(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// DeleteRecipes deletes every listed recipe in a single statement and returns how many were deleted.
+// IDs that match no recipe are ignored; callers can compare the count with the number of IDs requested.
+func (s *DBRecipeStore) DeleteRecipes(ctx context.Context, ids []uuid.UUID) (int, error) {
+	cmdTag, err := s.db.Exec(ctx, `DELETE FROM recipes WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete %d recipes: %w", len(ids), err)
+	}
+	return int(cmdTag.RowsAffected()), nil
+}
+
+// ExistingRecipeIDs returns those of ids that belong to a recipe, in no particular order.
+func (s *DBRecipeStore) ExistingRecipeIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := s.db.Query(ctx, `SELECT id FROM recipes WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check %d recipe IDs: %w", len(ids), err)
+	}
+	defer rows.Close()
+
+	var existing []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan recipe ID: %w", err)
+		}
+		existing = append(existing, id)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error iterating recipe IDs: %w", rows.Err())
+	}
+	return existing, nil
+}
+
 // Implement other RecipeStore methods (GetRecipeByID, ListRecipes, UpdateRecipe, DeleteRecipe) here...
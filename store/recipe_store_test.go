@@ -0,0 +1,75 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gaanon/gorecipes_v2/models"
+)
+
+func TestUniqueTagRequests_DuplicateTags(t *testing.T) {
+	tags := []models.RecipeTagRequest{
+		{Name: "quick"},
+		{Name: "Quick"},
+		{Name: "vegetarian"},
+		{Name: "QUICK"},
+		{Name: "Vegetarian"},
+	}
+
+	unique := uniqueTagRequests(tags)
+
+	assert.Equal(t, []models.RecipeTagRequest{{Name: "quick"}, {Name: "vegetarian"}}, unique)
+}
+
+func TestUniqueTagRequests_Empty(t *testing.T) {
+	assert.Empty(t, uniqueTagRequests(nil))
+}
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"Apple Pie":              "apple-pie",
+		"  Grandma's Apple Pie!": "grandma-s-apple-pie",
+		"Crème Brûlée":           "creme-brulee",
+		"5-Minute  Eggs":         "5-minute-eggs",
+		"!!!":                    "recipe",
+	}
+	for title, want := range tests {
+		assert.Equal(t, want, slugify(title), title)
+	}
+	assert.Len(t, slugify(strings.Repeat("a", 300)), maxSlugBaseLength)
+}
+
+func TestPrefixTSQuery(t *testing.T) {
+	tests := map[string]string{
+		"choco":                "choco:*",
+		"  Choco   cake ":      "Choco:* & cake:*",
+		"pie & !(cake | tart)": "pie:* & cake:* & tart:*",
+		"crème:*":              "crème:*",
+		"&|!():*":              "",
+	}
+	for query, want := range tests {
+		assert.Equal(t, want, prefixTSQuery(query), query)
+	}
+}
+
+func TestEscapeLikePattern(t *testing.T) {
+	assert.Equal(t, "choc", escapeLikePattern("choc"))
+	assert.Equal(t, `100\% rye\_flour \\`, escapeLikePattern(`100% rye_flour \`))
+}
+
+func TestIsGeneratedColumnWrite(t *testing.T) {
+	assert.True(t, isGeneratedColumnWrite(fmt.Errorf("refresh: %w", &pgconn.PgError{Code: "428C9"})))
+	assert.False(t, isGeneratedColumnWrite(&pgconn.PgError{Code: "23505"}))
+	assert.False(t, isGeneratedColumnWrite(errors.New("connection refused")))
+}
+
+func TestIsUndefinedFunction(t *testing.T) {
+	assert.True(t, isUndefinedFunction(fmt.Errorf("search: %w", &pgconn.PgError{Code: "42883"})))
+	assert.False(t, isUndefinedFunction(&pgconn.PgError{Code: "23505"}))
+	assert.False(t, isUndefinedFunction(nil))
+}
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/gaanon/gorecipes_v2/models"
+)
+
+// GetRecipeTags returns the tags of a recipe, ordered by name; the slice is empty, not nil, for an untagged recipe.
+func (s *DBRecipeStore) GetRecipeTags(ctx context.Context, recipeID uuid.UUID) ([]models.Tag, error) {
+	var exists bool
+	if err := s.db.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM recipes WHERE id = $1)", recipeID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to check recipe %s: %w", recipeID, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("recipe with ID %s not found", recipeID)
+	}
+
+	tags, err := s.getRecipeTags(ctx, recipeID)
+	if err != nil {
+		return nil, err
+	}
+	if tags == nil {
+		tags = []models.Tag{}
+	}
+	return tags, nil
+}
+
+// getRecipeTags returns the tags linked to a recipe, ordered by name.
+func (s *DBRecipeStore) getRecipeTags(ctx context.Context, recipeID uuid.UUID) ([]models.Tag, error) {
+	tagsSQL := `
+		SELECT t.id, t.name, t.description, t.color, t.archived, t.created_at
+		FROM recipe_tags rt
+		JOIN tags t ON rt.tag_id = t.id
+		WHERE rt.recipe_id = $1
+		ORDER BY t.name;`
+	rows, err := s.db.Query(ctx, tagsSQL, recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags for recipe %s: %w", recipeID, err)
+	}
+	defer rows.Close()
+
+	var tags []models.Tag
+	for rows.Next() {
+		var tag models.Tag
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.Description, &tag.Color, &tag.Archived, &tag.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan tag for recipe %s: %w", recipeID, err)
+		}
+		tags = append(tags, tag)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error iterating tags for recipe %s: %w", recipeID, rows.Err())
+	}
+	return tags, nil
+}
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetRecipeTranslation retrieves a recipe's translation for the given language.
+// It returns nil (and no error) when the recipe has no translation in that language.
+func (s *DBRecipeStore) GetRecipeTranslation(ctx context.Context, recipeID uuid.UUID, lang string) (*models.RecipeTranslation, error) {
+	translationSQL := `
+		SELECT recipe_id, lang, title, description, created_at, updated_at
+		FROM recipe_translations
+		WHERE recipe_id = $1 AND lang = $2;`
+	translation := &models.RecipeTranslation{}
+	err := s.db.QueryRow(ctx, translationSQL, recipeID, lang).Scan(
+		&translation.RecipeID, &translation.Lang, &translation.Title, &translation.Description,
+		&translation.CreatedAt, &translation.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get %s translation for recipe %s: %w", lang, recipeID, err)
+	}
+	return translation, nil
+}
+
+// UpsertRecipeTranslation creates or replaces a recipe's translation for the given language.
+func (s *DBRecipeStore) UpsertRecipeTranslation(ctx context.Context, recipeID uuid.UUID, lang string, translationReq *models.RecipeTranslationRequest) (*models.RecipeTranslation, error) {
+	upsertSQL := `
+		INSERT INTO recipe_translations (recipe_id, lang, title, description)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (recipe_id, lang) DO UPDATE
+		SET title = EXCLUDED.title, description = EXCLUDED.description
+		RETURNING recipe_id, lang, title, description, created_at, updated_at;`
+	translation := &models.RecipeTranslation{}
+	err := s.db.QueryRow(ctx, upsertSQL, recipeID, lang, translationReq.Title, translationReq.Description).Scan(
+		&translation.RecipeID, &translation.Lang, &translation.Title, &translation.Description,
+		&translation.CreatedAt, &translation.UpdatedAt,
+	)
+	if err != nil {
+		if isForeignKeyViolation(err) { // The recipe_id does not exist
+			return nil, fmt.Errorf("recipe with ID %s not found for translation", recipeID)
+		}
+		return nil, fmt.Errorf("failed to save %s translation for recipe %s: %w", lang, recipeID, err)
+	}
+	return translation, nil
+}
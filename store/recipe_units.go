@@ -0,0 +1,61 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/gaanon/gorecipes_v2/models"
+)
+
+// ListConversionUnits returns every measurement unit with its conversion data (base unit and factor),
+// for converting recipe quantities between systems.
+func (s *DBRecipeStore) ListConversionUnits(ctx context.Context) ([]*models.MeasurementUnit, error) {
+	rows, err := s.db.Query(ctx, "SELECT id, name, abbreviation, system, base_unit_id, conversion_factor FROM measurement_units ORDER BY name;")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list measurement units: %w", err)
+	}
+	defer rows.Close()
+
+	var units []*models.MeasurementUnit
+	for rows.Next() {
+		unit := &models.MeasurementUnit{}
+		err := rows.Scan(&unit.ID, &unit.Name, &unit.Abbreviation, &unit.System, &unit.BaseUnitID, &unit.ConversionFactor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan measurement unit: %w", err)
+		}
+		units = append(units, unit)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error iterating measurement units: %w", rows.Err())
+	}
+	return units, nil
+}
+
+// IngredientPrices returns the prices of a recipe's ingredients; ingredients without a price are left out.
+func (s *DBRecipeStore) IngredientPrices(ctx context.Context, recipeID uuid.UUID) ([]models.IngredientPrice, error) {
+	pricesSQL := `
+		SELECT DISTINCT i.id, i.unit_price, i.price_unit_id
+		FROM recipe_ingredients ri
+		JOIN ingredients i ON i.id = ri.ingredient_id
+		WHERE ri.recipe_id = $1 AND i.unit_price IS NOT NULL;`
+	rows, err := s.db.Query(ctx, pricesSQL, recipeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingredient prices for recipe %s: %w", recipeID, err)
+	}
+	defer rows.Close()
+
+	var prices []models.IngredientPrice
+	for rows.Next() {
+		var price models.IngredientPrice
+		if err := rows.Scan(&price.IngredientID, &price.UnitPrice, &price.PriceUnitID); err != nil {
+			return nil, fmt.Errorf("failed to scan ingredient price for recipe %s: %w", recipeID, err)
+		}
+		prices = append(prices, price)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error iterating ingredient prices for recipe %s: %w", recipeID, rows.Err())
+	}
+	return prices, nil
+}
@@ -0,0 +1,48 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// searchVectorSQL computes the search document of the recipe aliased r: its title with weight A, its
+// description with weight B and its ingredient names with weight C. ts_rank scores A matches highest,
+// so a recipe titled "Chicken soup" ranks above one that merely uses chicken.
+const searchVectorSQL = `
+	setweight(to_tsvector('english', COALESCE(r.title, '')), 'A') ||
+	setweight(to_tsvector('english', COALESCE(r.description, '')), 'B') ||
+	setweight(to_tsvector('english', COALESCE((SELECT string_agg(i.name, ' ')
+	                                           FROM recipe_ingredients ri
+	                                           JOIN ingredients i ON i.id = ri.ingredient_id
+	                                           WHERE ri.recipe_id = r.id), '')), 'C')`
+
+// refreshSearchVectors recomputes search_vector, inside tx, for the recipes matching condition,
+// a WHERE clause over the alias r that may reference args.
+// Databases created before search_vector became a maintained column still generate it from the title
+// and description, and cannot write it; there the refresh is skipped (see AdminStore.ReindexSearch).
+func refreshSearchVectors(ctx context.Context, tx pgx.Tx, condition string, args ...any) error {
+	// A savepoint keeps the transaction usable if the write is rejected.
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create savepoint: %w", err)
+	}
+	_, err = savepoint.Exec(ctx, "UPDATE recipes r SET search_vector = "+searchVectorSQL+" WHERE "+condition, args...)
+	if err != nil {
+		savepoint.Rollback(ctx)
+		if isGeneratedColumnWrite(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to refresh search vectors: %w", err)
+	}
+	return savepoint.Commit(ctx)
+}
+
+// isGeneratedColumnWrite reports whether err is PostgreSQL refusing to write a generated column (SQLSTATE 428C9).
+func isGeneratedColumnWrite(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "428C9"
+}
@@ -0,0 +1,104 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TagStore defines the interface for tag data operations.
+type TagStore interface {
+	AssignTagToRecipes(ctx context.Context, tagID uuid.UUID, recipeIDs []uuid.UUID) (int, error)
+	PopularTags(ctx context.Context, limit int, includeArchived bool) ([]models.TagCount, error)
+	SetTagArchived(ctx context.Context, tagID uuid.UUID, archived bool) (*models.Tag, error)
+}
+
+// DBTagStore implements the TagStore interface using a pgxpool.Pool.
+type DBTagStore struct {
+	db *pgxpool.Pool
+}
+
+// NewTagStore creates a new DBTagStore.
+func NewTagStore(db *pgxpool.Pool) *DBTagStore {
+	return &DBTagStore{db: db}
+}
+
+// AssignTagToRecipes links a tag to every listed recipe in a single insert and returns how many links were added.
+// Recipes that already have the tag, and IDs that match no recipe, are skipped rather than failing the batch.
+func (s *DBTagStore) AssignTagToRecipes(ctx context.Context, tagID uuid.UUID, recipeIDs []uuid.UUID) (int, error) {
+	var exists bool
+	err := s.db.QueryRow(ctx, "SELECT true FROM tags WHERE id = $1", tagID).Scan(&exists)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf("tag with ID %s not found", tagID)
+		}
+		return 0, fmt.Errorf("failed to check tag %s: %w", tagID, err)
+	}
+
+	insertSQL := `
+		INSERT INTO recipe_tags (recipe_id, tag_id)
+		SELECT r.id, $1 FROM recipes r WHERE r.id = ANY($2)
+		ON CONFLICT DO NOTHING;`
+	cmdTag, err := s.db.Exec(ctx, insertSQL, tagID, recipeIDs)
+	if err != nil {
+		if isForeignKeyViolation(err) { // Tag deleted concurrently
+			return 0, fmt.Errorf("tag with ID %s not found", tagID)
+		}
+		return 0, fmt.Errorf("failed to assign tag %s to recipes: %w", tagID, err)
+	}
+	return int(cmdTag.RowsAffected()), nil
+}
+
+// PopularTags returns the limit tags used by the most recipes, most used first; ties are ordered by name.
+// Tags no recipe uses are left out, and so are archived tags unless includeArchived is set.
+func (s *DBTagStore) PopularTags(ctx context.Context, limit int, includeArchived bool) ([]models.TagCount, error) {
+	query := `
+		SELECT t.id, t.name, COUNT(*) AS recipe_count, t.archived
+		FROM tags t
+		JOIN recipe_tags rt ON rt.tag_id = t.id
+		WHERE $2 OR NOT t.archived
+		GROUP BY t.id, t.name, t.archived
+		ORDER BY recipe_count DESC, t.name
+		LIMIT $1;`
+	rows, err := s.db.Query(ctx, query, limit, includeArchived)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query popular tags: %w", err)
+	}
+	defer rows.Close()
+
+	tags := []models.TagCount{}
+	for rows.Next() {
+		var tag models.TagCount
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.Count, &tag.Archived); err != nil {
+			return nil, fmt.Errorf("failed to scan popular tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error iterating popular tags: %w", rows.Err())
+	}
+	return tags, nil
+}
+
+// SetTagArchived archives or unarchives a tag and returns it. Archiving only hides the tag from
+// listings; its recipe links are kept. Setting the state it already has is not an error.
+func (s *DBTagStore) SetTagArchived(ctx context.Context, tagID uuid.UUID, archived bool) (*models.Tag, error) {
+	tag := &models.Tag{}
+	query := `
+		UPDATE tags SET archived = $2
+		WHERE id = $1
+		RETURNING id, name, description, color, archived, created_at;`
+	err := s.db.QueryRow(ctx, query, tagID, archived).Scan(&tag.ID, &tag.Name, &tag.Description, &tag.Color, &tag.Archived, &tag.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("tag with ID %s not found", tagID)
+		}
+		return nil, fmt.Errorf("failed to update tag %s: %w", tagID, err)
+	}
+	return tag, nil
+}
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SlowQueryTracer is a pgx.QueryTracer that logs queries taking at least Threshold.
+type SlowQueryTracer struct {
+	Threshold  time.Duration
+	RedactArgs bool // Log only the SQL, e.g. when arguments may contain personal data
+	Logger     *slog.Logger
+}
+
+type slowQueryCtxKey struct{}
+
+// queryTrace carries the query details from TraceQueryStart to TraceQueryEnd.
+type queryTrace struct {
+	start time.Time
+	sql   string
+	args  []any
+}
+
+// TraceQueryStart records when the query started.
+func (t *SlowQueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, slowQueryCtxKey{}, queryTrace{start: time.Now(), sql: data.SQL, args: data.Args})
+}
+
+// TraceQueryEnd logs the query if it ran for at least the threshold.
+func (t *SlowQueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	trace, ok := ctx.Value(slowQueryCtxKey{}).(queryTrace)
+	if !ok {
+		return
+	}
+	elapsed := time.Since(trace.start)
+	if elapsed < t.Threshold {
+		return
+	}
+
+	attrs := []any{
+		slog.Duration("duration", elapsed),
+		slog.String("sql", trace.sql),
+	}
+	if !t.RedactArgs {
+		attrs = append(attrs, slog.Any("args", trace.args))
+	}
+	if data.Err != nil {
+		attrs = append(attrs, slog.String("error", data.Err.Error()))
+	}
+	t.Logger.WarnContext(ctx, "slow query", attrs...)
+}
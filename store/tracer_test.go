@@ -0,0 +1,40 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlowQueryTracer(t *testing.T) {
+	var buf bytes.Buffer
+	tracer := &SlowQueryTracer{Logger: slog.New(slog.NewTextHandler(&buf, nil))}
+	start := pgx.TraceQueryStartData{SQL: "SELECT * FROM recipes WHERE id = $1", Args: []any{"secret-id"}}
+
+	// Fast queries stay quiet.
+	tracer.Threshold = time.Hour
+	ctx := tracer.TraceQueryStart(context.Background(), nil, start)
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+	assert.Empty(t, buf.String())
+
+	// Slow queries are logged with their SQL and args.
+	tracer.Threshold = 0
+	ctx = tracer.TraceQueryStart(context.Background(), nil, start)
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+	assert.Contains(t, buf.String(), "slow query")
+	assert.Contains(t, buf.String(), "FROM recipes")
+	assert.Contains(t, buf.String(), "secret-id")
+
+	// Args can be redacted.
+	buf.Reset()
+	tracer.RedactArgs = true
+	ctx = tracer.TraceQueryStart(context.Background(), nil, start)
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+	assert.Contains(t, buf.String(), "FROM recipes")
+	assert.NotContains(t, buf.String(), "secret-id")
+}
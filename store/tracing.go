@@ -0,0 +1,429 @@
+package store
+
+import (
+	"context"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer creates the store spans. It resolves the global provider lazily, so it is a no-op
+// until telemetry.SetupTracing installs an exporter.
+var tracer = otel.Tracer("github.com/gaanon/gorecipes_v2/store")
+
+// endSpan records err on the span, if any, and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TracedRecipeStore decorates a RecipeStore with a span per method call.
+// Each method runs in a single transaction or query, so the span also covers the transaction.
+type TracedRecipeStore struct {
+	next RecipeStore
+}
+
+// NewTracedRecipeStore wraps next with tracing.
+func NewTracedRecipeStore(next RecipeStore) *TracedRecipeStore {
+	return &TracedRecipeStore{next: next}
+}
+
+// CreateRecipe wraps RecipeStore.CreateRecipe in a span.
+func (s *TracedRecipeStore) CreateRecipe(ctx context.Context, recipeReq *models.RecipeRequest) (*models.Recipe, error) {
+	ctx, span := tracer.Start(ctx, "RecipeStore.CreateRecipe")
+	result, err := s.next.CreateRecipe(ctx, recipeReq)
+	endSpan(span, err)
+	return result, err
+}
+
+// GetRecipeByID wraps RecipeStore.GetRecipeByID in a span.
+func (s *TracedRecipeStore) GetRecipeByID(ctx context.Context, id uuid.UUID) (*models.Recipe, error) {
+	ctx, span := tracer.Start(ctx, "RecipeStore.GetRecipeByID")
+	result, err := s.next.GetRecipeByID(ctx, id)
+	endSpan(span, err)
+	return result, err
+}
+
+// ListRecipes wraps RecipeStore.ListRecipes in a span.
+func (s *TracedRecipeStore) ListRecipes(ctx context.Context, params models.RecipeListParams) ([]*models.Recipe, error) {
+	ctx, span := tracer.Start(ctx, "RecipeStore.ListRecipes")
+	result, err := s.next.ListRecipes(ctx, params)
+	endSpan(span, err)
+	return result, err
+}
+
+// UpdateRecipe wraps RecipeStore.UpdateRecipe in a span.
+func (s *TracedRecipeStore) UpdateRecipe(ctx context.Context, id uuid.UUID, recipeReq *models.RecipeRequest) (*models.Recipe, error) {
+	ctx, span := tracer.Start(ctx, "RecipeStore.UpdateRecipe")
+	result, err := s.next.UpdateRecipe(ctx, id, recipeReq)
+	endSpan(span, err)
+	return result, err
+}
+
+// DeleteRecipe wraps RecipeStore.DeleteRecipe in a span.
+func (s *TracedRecipeStore) DeleteRecipe(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "RecipeStore.DeleteRecipe")
+	err := s.next.DeleteRecipe(ctx, id)
+	endSpan(span, err)
+	return err
+}
+
+// GetRecipeTranslation wraps RecipeStore.GetRecipeTranslation in a span.
+func (s *TracedRecipeStore) GetRecipeTranslation(ctx context.Context, recipeID uuid.UUID, lang string) (*models.RecipeTranslation, error) {
+	ctx, span := tracer.Start(ctx, "RecipeStore.GetRecipeTranslation")
+	result, err := s.next.GetRecipeTranslation(ctx, recipeID, lang)
+	endSpan(span, err)
+	return result, err
+}
+
+// UpsertRecipeTranslation wraps RecipeStore.UpsertRecipeTranslation in a span.
+func (s *TracedRecipeStore) UpsertRecipeTranslation(ctx context.Context, recipeID uuid.UUID, lang string, translationReq *models.RecipeTranslationRequest) (*models.RecipeTranslation, error) {
+	ctx, span := tracer.Start(ctx, "RecipeStore.UpsertRecipeTranslation")
+	result, err := s.next.UpsertRecipeTranslation(ctx, recipeID, lang, translationReq)
+	endSpan(span, err)
+	return result, err
+}
+
+// GetStep wraps RecipeStore.GetStep in a span.
+func (s *TracedRecipeStore) GetStep(ctx context.Context, recipeID uuid.UUID, stepNumber int) (*models.RecipeStep, error) {
+	ctx, span := tracer.Start(ctx, "RecipeStore.GetStep")
+	result, err := s.next.GetStep(ctx, recipeID, stepNumber)
+	endSpan(span, err)
+	return result, err
+}
+
+// GetRecipePhoto wraps RecipeStore.GetRecipePhoto in a span.
+func (s *TracedRecipeStore) GetRecipePhoto(ctx context.Context, recipeID uuid.UUID) (*string, error) {
+	ctx, span := tracer.Start(ctx, "RecipeStore.GetRecipePhoto")
+	result, err := s.next.GetRecipePhoto(ctx, recipeID)
+	endSpan(span, err)
+	return result, err
+}
+
+// SetRecipePhoto wraps RecipeStore.SetRecipePhoto in a span.
+func (s *TracedRecipeStore) SetRecipePhoto(ctx context.Context, recipeID uuid.UUID, filename string) error {
+	ctx, span := tracer.Start(ctx, "RecipeStore.SetRecipePhoto")
+	err := s.next.SetRecipePhoto(ctx, recipeID, filename)
+	endSpan(span, err)
+	return err
+}
+
+// SimilarRecipes wraps RecipeStore.SimilarRecipes in a span.
+func (s *TracedRecipeStore) SimilarRecipes(ctx context.Context, recipeID uuid.UUID, limit int) ([]*models.SimilarRecipe, error) {
+	ctx, span := tracer.Start(ctx, "RecipeStore.SimilarRecipes")
+	result, err := s.next.SimilarRecipes(ctx, recipeID, limit)
+	endSpan(span, err)
+	return result, err
+}
+
+// GetRecipeBySlug wraps RecipeStore.GetRecipeBySlug in a span.
+func (s *TracedRecipeStore) GetRecipeBySlug(ctx context.Context, slug string) (*models.Recipe, error) {
+	ctx, span := tracer.Start(ctx, "RecipeStore.GetRecipeBySlug")
+	result, err := s.next.GetRecipeBySlug(ctx, slug)
+	endSpan(span, err)
+	return result, err
+}
+
+// SearchRecipes wraps RecipeStore.SearchRecipes in a span.
+func (s *TracedRecipeStore) SearchRecipes(ctx context.Context, params models.RecipeSearchParams) ([]*models.RecipeSearchResult, error) {
+	ctx, span := tracer.Start(ctx, "RecipeStore.SearchRecipes")
+	result, err := s.next.SearchRecipes(ctx, params)
+	endSpan(span, err)
+	return result, err
+}
+
+// DeleteRecipes wraps RecipeStore.DeleteRecipes in a span.
+func (s *TracedRecipeStore) DeleteRecipes(ctx context.Context, ids []uuid.UUID) (int, error) {
+	ctx, span := tracer.Start(ctx, "RecipeStore.DeleteRecipes")
+	result, err := s.next.DeleteRecipes(ctx, ids)
+	endSpan(span, err)
+	return result, err
+}
+
+// ShoppingListLines wraps RecipeStore.ShoppingListLines in a span.
+func (s *TracedRecipeStore) ShoppingListLines(ctx context.Context, recipeIDs []uuid.UUID) ([]models.ShoppingListLine, error) {
+	ctx, span := tracer.Start(ctx, "RecipeStore.ShoppingListLines")
+	result, err := s.next.ShoppingListLines(ctx, recipeIDs)
+	endSpan(span, err)
+	return result, err
+}
+
+// ReorderIngredients wraps RecipeStore.ReorderIngredients in a span.
+func (s *TracedRecipeStore) ReorderIngredients(ctx context.Context, recipeID uuid.UUID, ingredientIDs []uuid.UUID) ([]models.RecipeIngredient, error) {
+	ctx, span := tracer.Start(ctx, "RecipeStore.ReorderIngredients")
+	result, err := s.next.ReorderIngredients(ctx, recipeID, ingredientIDs)
+	endSpan(span, err)
+	return result, err
+}
+
+// RecipeStats wraps RecipeStore.RecipeStats in a span.
+func (s *TracedRecipeStore) RecipeStats(ctx context.Context) (*models.RecipeStats, error) {
+	ctx, span := tracer.Start(ctx, "RecipeStore.RecipeStats")
+	result, err := s.next.RecipeStats(ctx)
+	endSpan(span, err)
+	return result, err
+}
+
+// GetRecipeTags wraps RecipeStore.GetRecipeTags in a span.
+func (s *TracedRecipeStore) GetRecipeTags(ctx context.Context, recipeID uuid.UUID) ([]models.Tag, error) {
+	ctx, span := tracer.Start(ctx, "RecipeStore.GetRecipeTags")
+	result, err := s.next.GetRecipeTags(ctx, recipeID)
+	endSpan(span, err)
+	return result, err
+}
+
+// ListConversionUnits wraps RecipeStore.ListConversionUnits in a span.
+func (s *TracedRecipeStore) ListConversionUnits(ctx context.Context) ([]*models.MeasurementUnit, error) {
+	ctx, span := tracer.Start(ctx, "RecipeStore.ListConversionUnits")
+	result, err := s.next.ListConversionUnits(ctx)
+	endSpan(span, err)
+	return result, err
+}
+
+// IngredientPrices wraps RecipeStore.IngredientPrices in a span.
+func (s *TracedRecipeStore) IngredientPrices(ctx context.Context, recipeID uuid.UUID) ([]models.IngredientPrice, error) {
+	ctx, span := tracer.Start(ctx, "RecipeStore.IngredientPrices")
+	result, err := s.next.IngredientPrices(ctx, recipeID)
+	endSpan(span, err)
+	return result, err
+}
+
+// RemoveIngredient wraps RecipeStore.RemoveIngredient in a span.
+func (s *TracedRecipeStore) RemoveIngredient(ctx context.Context, recipeID, ingredientID uuid.UUID) ([]models.RecipeIngredient, error) {
+	ctx, span := tracer.Start(ctx, "RecipeStore.RemoveIngredient")
+	result, err := s.next.RemoveIngredient(ctx, recipeID, ingredientID)
+	endSpan(span, err)
+	return result, err
+}
+
+// AddIngredient wraps RecipeStore.AddIngredient in a span.
+func (s *TracedRecipeStore) AddIngredient(ctx context.Context, recipeID uuid.UUID, ingReq *models.RecipeIngredientRequest) (*models.RecipeIngredient, error) {
+	ctx, span := tracer.Start(ctx, "RecipeStore.AddIngredient")
+	result, err := s.next.AddIngredient(ctx, recipeID, ingReq)
+	endSpan(span, err)
+	return result, err
+}
+
+// ExistingRecipeIDs wraps RecipeStore.ExistingRecipeIDs in a span.
+func (s *TracedRecipeStore) ExistingRecipeIDs(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	ctx, span := tracer.Start(ctx, "RecipeStore.ExistingRecipeIDs")
+	result, err := s.next.ExistingRecipeIDs(ctx, ids)
+	endSpan(span, err)
+	return result, err
+}
+
+// TracedIngredientStore decorates an IngredientStore with a span per method call.
+type TracedIngredientStore struct {
+	next IngredientStore
+}
+
+// NewTracedIngredientStore wraps next with tracing.
+func NewTracedIngredientStore(next IngredientStore) *TracedIngredientStore {
+	return &TracedIngredientStore{next: next}
+}
+
+// UpdateIngredient wraps IngredientStore.UpdateIngredient in a span.
+func (s *TracedIngredientStore) UpdateIngredient(ctx context.Context, id uuid.UUID, ingredientReq *models.IngredientRequest) (*models.Ingredient, error) {
+	ctx, span := tracer.Start(ctx, "IngredientStore.UpdateIngredient")
+	result, err := s.next.UpdateIngredient(ctx, id, ingredientReq)
+	endSpan(span, err)
+	return result, err
+}
+
+// IngredientStats wraps IngredientStore.IngredientStats in a span.
+func (s *TracedIngredientStore) IngredientStats(ctx context.Context, id uuid.UUID) (*models.IngredientStats, error) {
+	ctx, span := tracer.Start(ctx, "IngredientStore.IngredientStats")
+	result, err := s.next.IngredientStats(ctx, id)
+	endSpan(span, err)
+	return result, err
+}
+
+// SetIngredientSeasons wraps IngredientStore.SetIngredientSeasons in a span.
+func (s *TracedIngredientStore) SetIngredientSeasons(ctx context.Context, id uuid.UUID, months []int) (*models.Ingredient, error) {
+	ctx, span := tracer.Start(ctx, "IngredientStore.SetIngredientSeasons")
+	result, err := s.next.SetIngredientSeasons(ctx, id, months)
+	endSpan(span, err)
+	return result, err
+}
+
+// PopularIngredients wraps IngredientStore.PopularIngredients in a span.
+func (s *TracedIngredientStore) PopularIngredients(ctx context.Context, limit int) ([]models.IngredientCount, error) {
+	ctx, span := tracer.Start(ctx, "IngredientStore.PopularIngredients")
+	result, err := s.next.PopularIngredients(ctx, limit)
+	endSpan(span, err)
+	return result, err
+}
+
+// SetIngredientPrice wraps IngredientStore.SetIngredientPrice in a span.
+func (s *TracedIngredientStore) SetIngredientPrice(ctx context.Context, id uuid.UUID, priceReq *models.IngredientPriceRequest) (*models.Ingredient, error) {
+	ctx, span := tracer.Start(ctx, "IngredientStore.SetIngredientPrice")
+	result, err := s.next.SetIngredientPrice(ctx, id, priceReq)
+	endSpan(span, err)
+	return result, err
+}
+
+// PatchIngredient wraps IngredientStore.PatchIngredient in a span.
+func (s *TracedIngredientStore) PatchIngredient(ctx context.Context, id uuid.UUID, patch *models.IngredientPatchRequest) (*models.Ingredient, error) {
+	ctx, span := tracer.Start(ctx, "IngredientStore.PatchIngredient")
+	result, err := s.next.PatchIngredient(ctx, id, patch)
+	endSpan(span, err)
+	return result, err
+}
+
+// ListIngredients wraps IngredientStore.ListIngredients in a span.
+func (s *TracedIngredientStore) ListIngredients(ctx context.Context, params models.IngredientListParams) ([]models.Ingredient, error) {
+	ctx, span := tracer.Start(ctx, "IngredientStore.ListIngredients")
+	result, err := s.next.ListIngredients(ctx, params)
+	endSpan(span, err)
+	return result, err
+}
+
+// TracedTagStore decorates a TagStore with a span per method call.
+type TracedTagStore struct {
+	next TagStore
+}
+
+// NewTracedTagStore wraps next with tracing.
+func NewTracedTagStore(next TagStore) *TracedTagStore {
+	return &TracedTagStore{next: next}
+}
+
+// AssignTagToRecipes wraps TagStore.AssignTagToRecipes in a span.
+func (s *TracedTagStore) AssignTagToRecipes(ctx context.Context, tagID uuid.UUID, recipeIDs []uuid.UUID) (int, error) {
+	ctx, span := tracer.Start(ctx, "TagStore.AssignTagToRecipes")
+	result, err := s.next.AssignTagToRecipes(ctx, tagID, recipeIDs)
+	endSpan(span, err)
+	return result, err
+}
+
+// PopularTags wraps TagStore.PopularTags in a span.
+func (s *TracedTagStore) PopularTags(ctx context.Context, limit int, includeArchived bool) ([]models.TagCount, error) {
+	ctx, span := tracer.Start(ctx, "TagStore.PopularTags")
+	result, err := s.next.PopularTags(ctx, limit, includeArchived)
+	endSpan(span, err)
+	return result, err
+}
+
+// SetTagArchived wraps TagStore.SetTagArchived in a span.
+func (s *TracedTagStore) SetTagArchived(ctx context.Context, tagID uuid.UUID, archived bool) (*models.Tag, error) {
+	ctx, span := tracer.Start(ctx, "TagStore.SetTagArchived")
+	result, err := s.next.SetTagArchived(ctx, tagID, archived)
+	endSpan(span, err)
+	return result, err
+}
+
+// TracedUnitStore decorates a UnitStore with a span per method call.
+type TracedUnitStore struct {
+	next UnitStore
+}
+
+// NewTracedUnitStore wraps next with tracing.
+func NewTracedUnitStore(next UnitStore) *TracedUnitStore {
+	return &TracedUnitStore{next: next}
+}
+
+// ListUnits wraps UnitStore.ListUnits in a span.
+func (s *TracedUnitStore) ListUnits(ctx context.Context, system *models.MeasurementSystem) ([]*models.MeasurementUnit, error) {
+	ctx, span := tracer.Start(ctx, "UnitStore.ListUnits")
+	result, err := s.next.ListUnits(ctx, system)
+	endSpan(span, err)
+	return result, err
+}
+
+// TracedAdminStore decorates an AdminStore with a span per method call.
+type TracedAdminStore struct {
+	next AdminStore
+}
+
+// NewTracedAdminStore wraps next with tracing.
+func NewTracedAdminStore(next AdminStore) *TracedAdminStore {
+	return &TracedAdminStore{next: next}
+}
+
+// RecomputeTotalTimes wraps AdminStore.RecomputeTotalTimes in a span.
+func (s *TracedAdminStore) RecomputeTotalTimes(ctx context.Context) (*models.TotalTimeCheckReport, error) {
+	ctx, span := tracer.Start(ctx, "AdminStore.RecomputeTotalTimes")
+	result, err := s.next.RecomputeTotalTimes(ctx)
+	endSpan(span, err)
+	return result, err
+}
+
+// DeleteUnusedTags wraps AdminStore.DeleteUnusedTags in a span.
+func (s *TracedAdminStore) DeleteUnusedTags(ctx context.Context) (int, error) {
+	ctx, span := tracer.Start(ctx, "AdminStore.DeleteUnusedTags")
+	result, err := s.next.DeleteUnusedTags(ctx)
+	endSpan(span, err)
+	return result, err
+}
+
+// ReindexSearch wraps AdminStore.ReindexSearch in a span.
+func (s *TracedAdminStore) ReindexSearch(ctx context.Context, batchSize int) (*models.SearchReindexReport, error) {
+	ctx, span := tracer.Start(ctx, "AdminStore.ReindexSearch")
+	result, err := s.next.ReindexSearch(ctx, batchSize)
+	endSpan(span, err)
+	return result, err
+}
+
+// TracedCollectionStore decorates a CollectionStore with a span per method call.
+type TracedCollectionStore struct {
+	next CollectionStore
+}
+
+// NewTracedCollectionStore wraps next with tracing.
+func NewTracedCollectionStore(next CollectionStore) *TracedCollectionStore {
+	return &TracedCollectionStore{next: next}
+}
+
+// CreateCollection wraps CollectionStore.CreateCollection in a span.
+func (s *TracedCollectionStore) CreateCollection(ctx context.Context, collectionReq *models.CollectionRequest) (*models.Collection, error) {
+	ctx, span := tracer.Start(ctx, "CollectionStore.CreateCollection")
+	result, err := s.next.CreateCollection(ctx, collectionReq)
+	endSpan(span, err)
+	return result, err
+}
+
+// GetCollection wraps CollectionStore.GetCollection in a span.
+func (s *TracedCollectionStore) GetCollection(ctx context.Context, id uuid.UUID) (*models.Collection, error) {
+	ctx, span := tracer.Start(ctx, "CollectionStore.GetCollection")
+	result, err := s.next.GetCollection(ctx, id)
+	endSpan(span, err)
+	return result, err
+}
+
+// ListCollectionRecipes wraps CollectionStore.ListCollectionRecipes in a span.
+func (s *TracedCollectionStore) ListCollectionRecipes(ctx context.Context, collectionID uuid.UUID) ([]models.CollectionRecipe, error) {
+	ctx, span := tracer.Start(ctx, "CollectionStore.ListCollectionRecipes")
+	result, err := s.next.ListCollectionRecipes(ctx, collectionID)
+	endSpan(span, err)
+	return result, err
+}
+
+// AddRecipeToCollection wraps CollectionStore.AddRecipeToCollection in a span.
+func (s *TracedCollectionStore) AddRecipeToCollection(ctx context.Context, collectionID, recipeID uuid.UUID, sortOrder *int) ([]models.CollectionRecipe, error) {
+	ctx, span := tracer.Start(ctx, "CollectionStore.AddRecipeToCollection")
+	result, err := s.next.AddRecipeToCollection(ctx, collectionID, recipeID, sortOrder)
+	endSpan(span, err)
+	return result, err
+}
+
+// RemoveRecipeFromCollection wraps CollectionStore.RemoveRecipeFromCollection in a span.
+func (s *TracedCollectionStore) RemoveRecipeFromCollection(ctx context.Context, collectionID, recipeID uuid.UUID) error {
+	ctx, span := tracer.Start(ctx, "CollectionStore.RemoveRecipeFromCollection")
+	err := s.next.RemoveRecipeFromCollection(ctx, collectionID, recipeID)
+	endSpan(span, err)
+	return err
+}
+
+// ReorderCollectionRecipes wraps CollectionStore.ReorderCollectionRecipes in a span.
+func (s *TracedCollectionStore) ReorderCollectionRecipes(ctx context.Context, collectionID uuid.UUID, recipeIDs []uuid.UUID) ([]models.CollectionRecipe, error) {
+	ctx, span := tracer.Start(ctx, "CollectionStore.ReorderCollectionRecipes")
+	result, err := s.next.ReorderCollectionRecipes(ctx, collectionID, recipeIDs)
+	endSpan(span, err)
+	return result, err
+}
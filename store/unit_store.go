@@ -0,0 +1,52 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gaanon/gorecipes_v2/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// UnitStore defines the interface for measurement unit data operations.
+type UnitStore interface {
+	ListUnits(ctx context.Context, system *models.MeasurementSystem) ([]*models.MeasurementUnit, error)
+}
+
+// DBUnitStore implements the UnitStore interface using a pgxpool.Pool.
+type DBUnitStore struct {
+	db *pgxpool.Pool
+}
+
+// NewUnitStore creates a new DBUnitStore.
+func NewUnitStore(db *pgxpool.Pool) *DBUnitStore {
+	return &DBUnitStore{db: db}
+}
+
+// ListUnits returns all measurement units ordered by name, optionally only those of one system.
+func (s *DBUnitStore) ListUnits(ctx context.Context, system *models.MeasurementSystem) ([]*models.MeasurementUnit, error) {
+	listSQL := `
+		SELECT id, name, abbreviation, system, base_unit_id, conversion_factor
+		FROM measurement_units
+		WHERE $1::measurement_system IS NULL OR system = $1
+		ORDER BY name;`
+	rows, err := s.db.Query(ctx, listSQL, system)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list measurement units: %w", err)
+	}
+	defer rows.Close()
+
+	units := []*models.MeasurementUnit{}
+	for rows.Next() {
+		unit := &models.MeasurementUnit{}
+		err := rows.Scan(&unit.ID, &unit.Name, &unit.Abbreviation, &unit.System, &unit.BaseUnitID, &unit.ConversionFactor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan measurement unit: %w", err)
+		}
+		units = append(units, unit)
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error iterating measurement units: %w", rows.Err())
+	}
+	return units, nil
+}